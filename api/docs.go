@@ -15,14 +15,14 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/auth/check": {
+        "/admin/actions": {
             "get": {
                 "security": [
                     {
                         "JWTAuth": []
                     }
                 ],
-                "description": "Checks if the token provided in the Authorization header is still valid.",
+                "description": "Get a list of recorded admin actions, newest first. You must be an admin.",
                 "consumes": [
                     "application/json"
                 ],
@@ -30,14 +30,34 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
+                ],
+                "summary": "Get admin actions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Filter by the ` + "`" + `user` + "`" + ` the action was performed on",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Specify the desired ` + "`" + `page` + "`" + `",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Specify the desired ` + "`" + `page size` + "`" + `",
+                        "name": "page_size",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Check validity of token",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/swagger.MessageResponse"
+                            "$ref": "#/definitions/swagger.AdminActionsResponse"
                         }
                     },
                     "401": {
@@ -46,6 +66,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/swagger.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -55,9 +87,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/login": {
-            "post": {
-                "description": "Log in to your account using your email and password. Returns tokens.",
+        "/admin/audit": {
+            "get": {
+                "security": [
+                    {
+                        "JWTAuth": []
+                    }
+                ],
+                "description": "Get security events of all users. You must be an admin.",
                 "consumes": [
                     "application/json"
                 ],
@@ -65,29 +102,80 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
-                "summary": "Log in to your account with credentials",
+                "summary": "Search the audit log",
                 "parameters": [
                     {
-                        "description": "Login information",
-                        "name": "credentials",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/swagger.LoginRequest"
-                        }
+                        "type": "integer",
+                        "description": "Filter by the ` + "`" + `user` + "`" + ` the event is about",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by the ` + "`" + `user` + "`" + ` who performed the action",
+                        "name": "actor_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by ` + "`" + `event` + "`" + `, e.g. ` + "`" + `user.login_failed` + "`" + `",
+                        "name": "event",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by client ` + "`" + `IP` + "`" + `",
+                        "name": "ip",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by ` + "`" + `request ID` + "`" + `",
+                        "name": "request_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only events at or after this RFC 3339 time",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only events before this RFC 3339 time",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Specify the desired ` + "`" + `page` + "`" + `",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Specify the desired ` + "`" + `page size` + "`" + `",
+                        "name": "page_size",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "400": {
-                        "description": "Bad Request",
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.AuditEventsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/swagger.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/swagger.ErrorResponse"
                         }
@@ -107,9 +195,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/login/telegram": {
-            "post": {
-                "description": "Log in to your account using verification token from header. Returns tokens.",
+        "/admin/users": {
+            "get": {
+                "security": [
+                    {
+                        "JWTAuth": []
+                    }
+                ],
+                "description": "Get a list of users, optionally searched by username or email. You must be an admin.",
                 "consumes": [
                     "application/json"
                 ],
@@ -117,33 +210,50 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
-                "summary": "Log in to your account by Telegram",
+                "summary": "Search users",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Verification token from Telegram",
-                        "name": "Verification",
-                        "in": "header",
-                        "required": true
+                        "description": "Search by ` + "`" + `username` + "`" + ` or ` + "`" + `email` + "`" + `",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Specify the desired ` + "`" + `page` + "`" + `",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Specify the desired ` + "`" + `page size` + "`" + `",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sorting by ` + "`" + `id` + "`" + `, ` + "`" + `username` + "`" + `, ` + "`" + `created_at` + "`" + `. Use ` + "`" + `-` + "`" + ` for desc",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/swagger.AuthResponse"
+                            "$ref": "#/definitions/swagger.UsersResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/swagger.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/swagger.ErrorResponse"
                         }
@@ -163,14 +273,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/logout": {
-            "post": {
+        "/admin/users/{user_id}": {
+            "get": {
                 "security": [
                     {
                         "JWTAuth": []
                     }
                 ],
-                "description": "Log out of your account using your refresh token in the Authorization header.",
+                "description": "Get the user by ID, including whether the account is suspended. You must be an admin.",
                 "consumes": [
                     "application/json"
                 ],
@@ -178,14 +288,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
+                ],
+                "summary": "Get user by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Log out of your account",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/swagger.MessageResponse"
+                            "$ref": "#/definitions/swagger.UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
                         }
                     },
                     "401": {
@@ -194,6 +319,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/swagger.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -203,14 +340,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/refresh": {
-            "post": {
+        "/admin/users/{user_id}/collections": {
+            "get": {
                 "security": [
                     {
                         "JWTAuth": []
                     }
                 ],
-                "description": "Refresh your access token using the refresh token in the Authorization header.",
+                "description": "Get a list of collections of the user with the same filters as ` + "`" + `/collections` + "`" + `. You must be an admin.",
                 "consumes": [
                     "application/json"
                 ],
@@ -218,14 +355,53 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
+                ],
+                "summary": "Get collections of the user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by ` + "`" + `name` + "`" + `",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Specify the desired ` + "`" + `page` + "`" + `",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Specify the desired ` + "`" + `page size` + "`" + `",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sorting by ` + "`" + `id` + "`" + `, ` + "`" + `name` + "`" + `, ` + "`" + `created_at, total_films` + "`" + `. Use ` + "`" + `-` + "`" + ` for desc",
+                        "name": "sort",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Refresh access token",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/swagger.AccessTokenResponse"
+                            "$ref": "#/definitions/swagger.CollectionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
                         }
                     },
                     "401": {
@@ -234,6 +410,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/swagger.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -243,9 +431,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/register": {
-            "post": {
-                "description": "Register a new user using a username and password. Returns user information and tokens.\nBasic permissions are available to you: creating films and collections.",
+        "/admin/users/{user_id}/films": {
+            "get": {
+                "security": [
+                    {
+                        "JWTAuth": []
+                    }
+                ],
+                "description": "Get a list of films of the user with the same filters as ` + "`" + `/films` + "`" + `. You must be an admin.",
                 "consumes": [
                     "application/json"
                 ],
@@ -253,35 +446,63 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
-                "summary": "Register a new user with credentials",
+                "summary": "Get films of the user",
                 "parameters": [
                     {
-                        "description": "Information about the new user ",
-                        "name": "credentials",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/swagger.RegisterRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by ` + "`" + `title` + "`" + `",
+                        "name": "title",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Specify the desired ` + "`" + `page` + "`" + `",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Specify the desired ` + "`" + `page size` + "`" + `",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sorting by ` + "`" + `id` + "`" + `, ` + "`" + `title` + "`" + `, ` + "`" + `rating` + "`" + `, ` + "`" + `year` + "`" + `, ` + "`" + `user_rating` + "`" + `, ` + "`" + `is_viewed` + "`" + `. Use ` + "`" + `-` + "`" + ` for desc",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.FilmsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
                             "$ref": "#/definitions/swagger.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/swagger.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/swagger.ErrorResponse"
                         }
@@ -301,9 +522,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/register/telegram": {
+        "/admin/users/{user_id}/logout": {
             "post": {
-                "description": "Register a new user using verification token from header. Returns user information and tokens.\nBasic permissions are available to you: creating films and collections.",
+                "security": [
+                    {
+                        "JWTAuth": []
+                    }
+                ],
+                "description": "Revoke all refresh and access tokens of the user by ID. You must be an admin.",
                 "consumes": [
                     "application/json"
                 ],
@@ -311,23 +537,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
-                "summary": "Register a new user by Telegram",
+                "summary": "Log the user out",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Verification token from Telegram",
-                        "name": "Verification",
-                        "in": "header",
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/swagger.AuthResponse"
+                            "$ref": "#/definitions/swagger.MessageResponse"
                         }
                     },
                     "400": {
@@ -336,20 +562,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/swagger.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/swagger.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/swagger.ErrorResponse"
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable Entity",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/swagger.ErrorResponse"
                         }
@@ -363,14 +589,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/collections": {
+        "/admin/users/{user_id}/permissions": {
             "get": {
                 "security": [
                     {
                         "JWTAuth": []
                     }
                 ],
-                "description": "Get a list of collections by user ID from authentication token. It also returns metadata.",
+                "description": "Get the permission codes of the user by ID. You must be an admin.",
                 "consumes": [
                     "application/json"
                 ],
@@ -378,52 +604,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "collections"
+                    "admin"
                 ],
-                "summary": "Get user collections",
+                "summary": "Get permissions of the user",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Filter by ` + "`" + `name` + "`" + `",
-                        "name": "name",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Filter by ` + "`" + `film` + "`" + `",
-                        "name": "film",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Filter by ` + "`" + `exclude film` + "`" + `",
-                        "name": "exclude_film",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Specify the desired ` + "`" + `page` + "`" + `",
-                        "name": "page",
-                        "in": "query"
-                    },
                     {
                         "type": "integer",
-                        "description": "Specify the desired ` + "`" + `page size` + "`" + `",
-                        "name": "page_size",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Sorting by ` + "`" + `id` + "`" + `, ` + "`" + `name` + "`" + `, ` + "`" + `created_at, total_films` + "`" + `. Use ` + "`" + `-` + "`" + ` for desc",
-                        "name": "sort",
-                        "in": "query"
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/swagger.CollectionsResponse"
+                            "$ref": "#/definitions/swagger.PermissionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
                         }
                     },
                     "401": {
@@ -432,8 +635,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/swagger.ErrorResponse"
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable Entity",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/swagger.ErrorResponse"
                         }
@@ -452,7 +661,7 @@ const docTemplate = `{
                         "JWTAuth": []
                     }
                 ],
-                "description": "Add a new collection. You will be granted the permissions to get, update, and delete it.",
+                "description": "Grant permission codes, such as ` + "`" + `film:create` + "`" + ` or ` + "`" + `admin:*` + "`" + `, to the user by ID. Codes of films and collections, such as ` + "`" + `film:1:read` + "`" + `, give access to an object the user does not own; the object must exist. You must be an admin.",
                 "consumes": [
                     "application/json"
                 ],
@@ -460,25 +669,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "collections"
+                    "admin"
                 ],
-                "summary": "Add new collection",
+                "summary": "Grant permissions to the user",
                 "parameters": [
                     {
-                        "description": "Information about the new collection",
-                        "name": "collection",
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Permission codes",
+                        "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/swagger.CollectionRequest"
+                            "$ref": "#/definitions/swagger.PermissionCodesRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/swagger.CollectionResponse"
+                            "$ref": "#/definitions/swagger.MessageResponse"
                         }
                     },
                     "400": {
@@ -493,8 +709,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/swagger.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/swagger.ErrorResponse"
                         }
@@ -512,16 +734,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/collections/{collection_id}": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "JWTAuth": []
                     }
                 ],
-                "description": "Get the collection by ID. You must have permissions to get this collection.",
+                "description": "Revoke permission codes from the user by ID. Other users keep them. The access of owners to their films and collections cannot be revoked. You must be an admin.",
                 "consumes": [
                     "application/json"
                 ],
@@ -529,23 +749,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "collections"
+                    "admin"
                 ],
-                "summary": "Get collection by ID",
+                "summary": "Revoke permissions from the user",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Collection ID",
-                        "name": "collection_id",
+                        "description": "User ID",
+                        "name": "user_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Permission codes",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swagger.PermissionCodesRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/swagger.CollectionResponse"
+                            "$ref": "#/definitions/swagger.MessageResponse"
                         }
                     },
                     "400": {
@@ -566,6 +795,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/swagger.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -573,14 +814,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/admin/users/{user_id}/suspend": {
+            "post": {
                 "security": [
                     {
                         "JWTAuth": []
                     }
                 ],
-                "description": "Update the collection by ID. You must have the permissions to update it.",
+                "description": "Suspend the user by ID and revoke all its tokens. A suspended user cannot log in or use API keys. You must be an admin.",
                 "consumes": [
                     "application/json"
                 ],
@@ -588,32 +831,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "collections"
+                    "admin"
                 ],
-                "summary": "Update the collection",
+                "summary": "Suspend the user",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Collection ID",
-                        "name": "collection_id",
+                        "description": "User ID",
+                        "name": "user_id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "New information about the collection",
-                        "name": "film",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/swagger.CollectionRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/swagger.FilmResponse"
+                            "$ref": "#/definitions/swagger.MessageResponse"
                         }
                     },
                     "400": {
@@ -640,18 +874,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/swagger.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
-                        "schema": {
-                            "$ref": "#/definitions/swagger.ErrorResponse"
-                        }
-                    },
-                    "422": {
-                        "description": "Unprocessable Entity",
-                        "schema": {
-                            "$ref": "#/definitions/swagger.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -659,14 +881,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/admin/users/{user_id}/unsuspend": {
+            "post": {
                 "security": [
                     {
                         "JWTAuth": []
                     }
                 ],
-                "description": "Delete the collection by ID. You must have the permissions to delete it.",
+                "description": "Lift the suspension of the user by ID. You must be an admin.",
                 "consumes": [
                     "application/json"
                 ],
@@ -674,14 +898,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "collections"
+                    "admin"
                 ],
-                "summary": "Delete the collection",
+                "summary": "Unsuspend the user",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Collection ID",
-                        "name": "collection_id",
+                        "description": "User ID",
+                        "name": "user_id",
                         "in": "path",
                         "required": true
                     }
@@ -726,14 +950,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/collections/{collection_id}/films": {
+        "/auth/check": {
             "get": {
                 "security": [
                     {
                         "JWTAuth": []
                     }
                 ],
-                "description": "Retrieves a list of films from a specified collection. This includes pagination and sorting metadata.\nYou must have permissions to access this collection.",
+                "description": "Checks if the token provided in the Authorization header is still valid.",
                 "consumes": [
                     "application/json"
                 ],
@@ -741,105 +965,64 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "collectionFilms"
-                ],
-                "summary": "Get films from collection",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Collection ID",
-                        "name": "collection_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by ` + "`" + `title` + "`" + `",
-                        "name": "title",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by ` + "`" + `rating` + "`" + `, can be a specific value or a range like 'min-max'",
-                        "name": "rating",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by ` + "`" + `year` + "`" + `",
-                        "name": "year",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by ` + "`" + `user_rating` + "`" + `",
-                        "name": "user_rating",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Filter by ` + "`" + `is_viewed` + "`" + ` (true/false)",
-                        "name": "is_viewed",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Filter by ` + "`" + `is_favorite` + "`" + ` (true/false)",
-                        "name": "is_favorite",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Filter by ` + "`" + `url` + "`" + ` (true/false)",
-                        "name": "has_url",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Filter by ` + "`" + `exclude collection` + "`" + `",
-                        "name": "exclude_collection",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Specify the desired ` + "`" + `page` + "`" + `",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Specify the desired ` + "`" + `page size` + "`" + `",
-                        "name": "page_size",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Sorting by ` + "`" + `id` + "`" + `, ` + "`" + `title` + "`" + `, ` + "`" + `rating` + "`" + `, ` + "`" + `year` + "`" + `, ` + "`" + `user_rating` + "`" + `, ` + "`" + `is_viewed` + "`" + `. Use ` + "`" + `-` + "`" + ` for desc",
-                        "name": "sort",
-                        "in": "query"
-                    }
+                    "auth"
                 ],
+                "summary": "Check validity of token",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/swagger.CollectionFilmsResponse"
+                            "$ref": "#/definitions/swagger.MessageResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/swagger.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/swagger.ErrorResponse"
                         }
+                    }
+                }
+            }
+        },
+        "/auth/email/verify": {
+            "post": {
+                "description": "Confirm the email of the account using the verification token from the email.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Verify email",
+                "parameters": [
+                    {
+                        "description": "Email verification token",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swagger.VerifyEmailRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.MessageResponse"
+                        }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/swagger.ErrorResponse"
                         }
@@ -857,14 +1040,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/auth/login": {
             "post": {
-                "security": [
-                    {
-                        "JWTAuth": []
-                    }
-                ],
-                "description": "Create a new film and add it to the specified collection. You must have rights to create a film and update the collection.",
+                "description": "Log in to your account using your email and password. Returns tokens.\nIf two-factor authentication is enabled, returns a two-factor token to complete the login at ` + "`" + `/auth/login/2fa` + "`" + `.\nAfter too many failed attempts the username or client IP is temporarily locked; see the ` + "`" + `Retry-After` + "`" + ` header.",
                 "consumes": [
                     "application/json"
                 ],
@@ -872,54 +1052,41 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "collectionFilms"
+                    "auth"
                 ],
-                "summary": "Add new film and associate with collection",
+                "summary": "Log in to your account with credentials",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Collection ID",
-                        "name": "collection_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Information about the new film",
-                        "name": "film",
+                        "description": "Login information",
+                        "name": "credentials",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/swagger.FilmRequest"
+                            "$ref": "#/definitions/swagger.LoginRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/swagger.CollectionFilmResponse"
-                        }
-                    },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/swagger.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/swagger.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
                             "$ref": "#/definitions/swagger.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "429": {
+                        "description": "Too Many Requests",
                         "schema": {
                             "$ref": "#/definitions/swagger.ErrorResponse"
                         }
@@ -933,14 +1100,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/collections/{collection_id}/films/{film_id}": {
-            "get": {
-                "security": [
-                    {
-                        "JWTAuth": []
-                    }
-                ],
-                "description": "Get the film from collection by ID. You must have permissions to get this collection.",
+        "/auth/login/2fa": {
+            "post": {
+                "description": "Exchange the two-factor token from ` + "`" + `/auth/login` + "`" + `, ` + "`" + `/auth/login/telegram` + "`" + ` or the OpenID Connect callback and a TOTP or recovery code for tokens.\nThe two-factor token expires after 5 minutes and allows a limited number of attempts.",
                 "consumes": [
                     "application/json"
                 ],
@@ -948,30 +1110,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "collectionFilms"
+                    "auth"
                 ],
-                "summary": "Get film from collection by ID",
+                "summary": "Finish logging in with a second factor",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Collection ID",
-                        "name": "collection_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Film ID",
-                        "name": "film_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Two-factor token and code",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swagger.TwoFactorLoginRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/swagger.CollectionFilmResponse"
+                            "$ref": "#/definitions/swagger.AuthResponse"
                         }
                     },
                     "400": {
@@ -986,14 +1143,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/swagger.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/swagger.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
                             "$ref": "#/definitions/swagger.ErrorResponse"
                         }
@@ -1005,14 +1156,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/auth/login/telegram": {
             "post": {
-                "security": [
-                    {
-                        "JWTAuth": []
-                    }
-                ],
-                "description": "Add existing film to the collection. You must have rights to get the film and update the collection.",
+                "description": "Log in to your account using verification token from header. Returns tokens.\nThe verification token must have ` + "`" + `exp` + "`" + `, ` + "`" + `iat` + "`" + ` and a unique ` + "`" + `jti` + "`" + `, and can be used only once.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1020,52 +1168,39 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "collectionFilms"
+                    "auth"
                 ],
-                "summary": "Add existing film to collection",
+                "summary": "Log in to your account by Telegram",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Collection ID",
-                        "name": "collection_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Film ID",
-                        "name": "film_id",
-                        "in": "path",
+                        "type": "string",
+                        "description": "Verification token from Telegram",
+                        "name": "Verification",
+                        "in": "header",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/swagger.CollectionFilmResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/swagger.ErrorResponse"
+                            "$ref": "#/definitions/swagger.AuthResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/swagger.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/swagger.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
                             "$ref": "#/definitions/swagger.ErrorResponse"
                         }
@@ -1079,14 +1214,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/collections/{collection_id}/films/{films_id}": {
-            "delete": {
+        "/auth/logout": {
+            "post": {
                 "security": [
                     {
                         "JWTAuth": []
                     }
                 ],
-                "description": "Delete the film from the collection by ID. You must have the permissions to update collection.",
+                "description": "Log out of your account using your refresh token in the Authorization header.\nThe refresh token and all access tokens of its session are revoked immediately.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1094,22 +1229,57 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "collectionFilms"
+                    "auth"
                 ],
-                "summary": "Delete film from collection",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Collection ID",
-                        "name": "collection_id",
-                        "in": "path",
+                "summary": "Log out of your account",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.MessageResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/oidc/callback": {
+            "get": {
+                "description": "Exchange the authorization code from the provider for tokens. A user signing in for the first time is registered.\nBasic permissions are available to new users: creating films and collections.\nIf two-factor authentication is enabled, returns a two-factor token to complete the login at ` + "`" + `/auth/login/2fa` + "`" + `.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Finish logging in with OpenID Connect",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization code",
+                        "name": "code",
+                        "in": "query",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Film ID",
-                        "name": "film_id",
-                        "in": "path",
+                        "type": "string",
+                        "description": "State from /auth/oidc/login",
+                        "name": "state",
+                        "in": "query",
                         "required": true
                     }
                 ],
@@ -1117,7 +1287,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/swagger.MessageResponse"
+                            "$ref": "#/definitions/swagger.AuthResponse"
                         }
                     },
                     "400": {
@@ -1132,12 +1302,41 @@ const docTemplate = `{
                             "$ref": "#/definitions/swagger.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/swagger.ErrorResponse"
                         }
                     },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/oidc/login": {
+            "get": {
+                "description": "Create a login at the configured OpenID Connect provider. Redirect the user to the returned authorization URL.\nThe provider redirects back to the configured redirect URL with a ` + "`" + `code` + "`" + ` and the ` + "`" + `state` + "`" + `, which must be passed to ` + "`" + `/auth/oidc/callback` + "`" + ` within 10 minutes.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Start logging in with OpenID Connect",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.OIDCLoginResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -1153,14 +1352,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/films": {
-            "get": {
-                "security": [
-                    {
-                        "JWTAuth": []
-                    }
-                ],
-                "description": "Get a list of films by user ID from authentication token. It also returns metadata.",
+        "/auth/password/forgot": {
+            "post": {
+                "description": "Send a single-use password reset token to the email of the account. The email must be verified.\nThe response is the same whether or not an account with this email exists.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1168,86 +1362,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "films"
+                    "auth"
                 ],
-                "summary": "Get user films",
+                "summary": "Request a password reset",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Filter by ` + "`" + `title` + "`" + `",
-                        "name": "title",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by ` + "`" + `rating` + "`" + `, can be a specific value or a range like 'min-max'",
-                        "name": "rating",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by ` + "`" + `year` + "`" + `",
-                        "name": "year",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by ` + "`" + `user_rating` + "`" + `",
-                        "name": "user_rating",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Filter by ` + "`" + `is_viewed` + "`" + ` (true/false)",
-                        "name": "is_viewed",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Filter by ` + "`" + `is_favorite` + "`" + ` (true/false)",
-                        "name": "is_favorite",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Filter by ` + "`" + `url` + "`" + ` (true/false)",
-                        "name": "has_url",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Filter by ` + "`" + `exclude collection` + "`" + `",
-                        "name": "exclude_collection",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Specify the desired ` + "`" + `page` + "`" + `",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Specify the desired ` + "`" + `page size` + "`" + `",
-                        "name": "page_size",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Sorting by ` + "`" + `id` + "`" + `, ` + "`" + `title` + "`" + `, ` + "`" + `rating` + "`" + `, ` + "`" + `year` + "`" + `, ` + "`" + `user_rating` + "`" + `, ` + "`" + `is_viewed` + "`" + `. Use ` + "`" + `-` + "`" + ` for desc",
-                        "name": "sort",
-                        "in": "query"
+                        "description": "Email of the account",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ForgotPasswordRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/swagger.FilmsResponse"
+                            "$ref": "#/definitions/swagger.MessageResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/swagger.ErrorResponse"
                         }
@@ -1265,14 +1402,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/auth/password/reset": {
             "post": {
-                "security": [
-                    {
-                        "JWTAuth": []
-                    }
-                ],
-                "description": "Add a new film. You will be granted the permissions to get, update, and delete it.",
+                "description": "Set a new password using the password reset token from the email. All sessions of the user are revoked.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1280,35 +1414,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "films"
+                    "auth"
                 ],
-                "summary": "Add new film",
+                "summary": "Reset password",
                 "parameters": [
                     {
-                        "description": "Information about the new film",
-                        "name": "film",
+                        "description": "Reset token and new password",
+                        "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/swagger.FilmRequest"
+                            "$ref": "#/definitions/swagger.ResetPasswordRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/swagger.ErrorResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/swagger.ErrorResponse"
+                            "$ref": "#/definitions/swagger.MessageResponse"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/swagger.ErrorResponse"
                         }
@@ -1328,14 +1456,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/films/{film_id}": {
-            "get": {
+        "/auth/refresh": {
+            "post": {
                 "security": [
                     {
                         "JWTAuth": []
                     }
                 ],
-                "description": "Get the film by ID. You must have permissions to get this film.",
+                "description": "Refresh your access token using the refresh token in the Authorization header.\nA new refresh token is returned and the used one is revoked.\nReusing a revoked refresh token revokes every token issued from the same login.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1343,29 +1471,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "films"
-                ],
-                "summary": "Get film by ID",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Film ID",
-                        "name": "film_id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "auth"
                 ],
+                "summary": "Refresh access token",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/swagger.FilmResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/swagger.ErrorResponse"
+                            "$ref": "#/definitions/swagger.AccessTokenResponse"
                         }
                     },
                     "401": {
@@ -1374,12 +1487,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/swagger.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/swagger.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1387,14 +1494,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "security": [
-                    {
-                        "JWTAuth": []
-                    }
-                ],
-                "description": "Update the film by ID. You must have the permissions to update it.",
+            }
+        },
+        "/auth/register": {
+            "post": {
+                "description": "Register a new user using a username and password. Returns user information and tokens.\nBasic permissions are available to you: creating films and collections.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1402,24 +1506,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "films"
+                    "auth"
                 ],
-                "summary": "Update the film",
+                "summary": "Register a new user with credentials",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Film ID",
-                        "name": "film_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "New information about the film",
-                        "name": "film",
+                        "description": "Information about the new user ",
+                        "name": "credentials",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/swagger.FilmRequest"
+                            "$ref": "#/definitions/swagger.RegisterRequest"
                         }
                     }
                 ],
@@ -1430,14 +1527,64 @@ const docTemplate = `{
                             "$ref": "#/definitions/swagger.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/swagger.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/register/telegram": {
+            "post": {
+                "description": "Register a new user using verification token from header. Returns user information and tokens.\nBasic permissions are available to you: creating films and collections.\nThe verification token must have ` + "`" + `exp` + "`" + `, ` + "`" + `iat` + "`" + ` and a unique ` + "`" + `jti` + "`" + `, and can be used only once.\nIf two-factor authentication is enabled, returns a two-factor token to complete the login at ` + "`" + `/auth/login/2fa` + "`" + `.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Register a new user by Telegram",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Verification token from Telegram",
+                        "name": "Verification",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.AuthResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/swagger.ErrorResponse"
                         }
@@ -1467,14 +1614,19 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/collections": {
+            "get": {
                 "security": [
                     {
                         "JWTAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
                     }
                 ],
-                "description": "Delete the film by ID. You must have the permissions to delete it.",
+                "description": "Get a list of collections by user ID from authentication token, including the collections shared with the user. Each collection has the ` + "`" + `role` + "`" + ` of the user: owner, editor or viewer. It also returns metadata.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1482,29 +1634,58 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "films"
+                    "collections"
                 ],
-                "summary": "Delete the film",
+                "summary": "Get user collections",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by ` + "`" + `name` + "`" + `",
+                        "name": "name",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "Film ID",
-                        "name": "film_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Filter by ` + "`" + `film` + "`" + `",
+                        "name": "film",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by ` + "`" + `exclude film` + "`" + `",
+                        "name": "exclude_film",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Specify the desired ` + "`" + `page` + "`" + `",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Specify the desired ` + "`" + `page size` + "`" + `",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sorting by ` + "`" + `id` + "`" + `, ` + "`" + `name` + "`" + `, ` + "`" + `created_at, total_films` + "`" + `. Use ` + "`" + `-` + "`" + ` for desc",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Add the ` + "`" + `_permissions` + "`" + ` block with the actions you may perform to each item",
+                        "name": "include_permissions",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/swagger.MessageResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/swagger.ErrorResponse"
+                            "$ref": "#/definitions/swagger.CollectionsResponse"
                         }
                     },
                     "401": {
@@ -1513,14 +1694,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/swagger.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/swagger.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
                             "$ref": "#/definitions/swagger.ErrorResponse"
                         }
@@ -1532,11 +1707,17 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/healthcheck": {
-            "get": {
-                "description": "Check the API status. Returns status and system information.",
+            },
+            "post": {
+                "security": [
+                    {
+                        "JWTAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Add a new collection. You will own it: you can get, update, delete and share it.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1544,14 +1725,55 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "monitoring"
+                    "collections"
+                ],
+                "summary": "Add new collection",
+                "parameters": [
+                    {
+                        "description": "Information about the new collection",
+                        "name": "collection",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swagger.CollectionRequest"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Add the ` + "`" + `_permissions` + "`" + ` block with the actions you may perform",
+                        "name": "include_permissions",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Check API status",
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/rest.HealthcheckResponse"
+                            "$ref": "#/definitions/swagger.CollectionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
                         }
                     },
                     "500": {
@@ -1563,14 +1785,17 @@ const docTemplate = `{
                 }
             }
         },
-        "/user": {
+        "/collections/{collection_id}": {
             "get": {
                 "security": [
                     {
                         "JWTAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
                     }
                 ],
-                "description": "Get information about user by ID using an authentication token.",
+                "description": "Get the collection by ID. You must have permissions to get this collection.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1578,14 +1803,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "collections"
+                ],
+                "summary": "Get collection by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Collection ID",
+                        "name": "collection_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Add the ` + "`" + `_permissions` + "`" + ` block with the actions you may perform",
+                        "name": "include_permissions",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get user account",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/swagger.UserResponse"
+                            "$ref": "#/definitions/swagger.CollectionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
                         }
                     },
                     "401": {
@@ -1594,8 +1840,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/swagger.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/swagger.ErrorResponse"
                         }
@@ -1612,9 +1858,12 @@ const docTemplate = `{
                 "security": [
                     {
                         "JWTAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
                     }
                 ],
-                "description": "Update information about user by ID using an authentication token.",
+                "description": "Update the collection by ID. You must have the permissions to update it.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1622,25 +1871,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "collections"
                 ],
-                "summary": "Update user account",
+                "summary": "Update the collection",
                 "parameters": [
                     {
-                        "description": "New information about the user",
-                        "name": "data",
+                        "type": "integer",
+                        "description": "Collection ID",
+                        "name": "collection_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New information about the collection",
+                        "name": "film",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/swagger.UpdateUserRequest"
+                            "$ref": "#/definitions/swagger.CollectionRequest"
                         }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Add the ` + "`" + `_permissions` + "`" + ` block with the actions you may perform",
+                        "name": "include_permissions",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/swagger.UserResponse"
+                            "$ref": "#/definitions/swagger.FilmResponse"
                         }
                     },
                     "400": {
@@ -1655,6 +1917,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/swagger.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -1685,9 +1953,12 @@ const docTemplate = `{
                 "security": [
                     {
                         "JWTAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
                     }
                 ],
-                "description": "Delete user by ID using an authentication token.",
+                "description": "Delete the collection by ID. You must have the permissions to delete it.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1695,9 +1966,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "collections"
+                ],
+                "summary": "Delete the collection",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Collection ID",
+                        "name": "collection_id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Delete user account",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1705,18 +1985,275 @@ const docTemplate = `{
                             "$ref": "#/definitions/swagger.MessageResponse"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{collection_id}/collaborators": {
+            "get": {
+                "security": [
+                    {
+                        "JWTAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Get the users the collection is shared with and their roles. You must have the permissions to get this collection.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collaborators"
+                ],
+                "summary": "Get the collaborators of the collection",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Collection ID",
+                        "name": "collection_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.CollaboratorsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "JWTAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Invite a user by username as a viewer or an editor of the collection. Viewers can get the collection and its films; editors can also update them and add and remove films. You must own the collection.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collaborators"
+                ],
+                "summary": "Share the collection with a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Collection ID",
+                        "name": "collection_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Username and role of the user",
+                        "name": "collaborator",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swagger.CollaboratorRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.CollaboratorResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{collection_id}/collaborators/{user_id}": {
+            "put": {
+                "security": [
+                    {
+                        "JWTAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Change the role of a user the collection is shared with. You must own the collection.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collaborators"
+                ],
+                "summary": "Change the role of a collaborator",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Collection ID",
+                        "name": "collection_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New role of the user",
+                        "name": "role",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swagger.CollaboratorRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.CollaboratorResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/swagger.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/swagger.ErrorResponse"
                         }
                     },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1724,89 +2261,4446 @@ const docTemplate = `{
                         }
                     }
                 }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "JWTAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Remove the access of a user to the collection. The owner can remove any collaborator; collaborators can remove themselves to leave the collection.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collaborators"
+                ],
+                "summary": "Remove a collaborator",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Collection ID",
+                        "name": "collection_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{collection_id}/films": {
+            "get": {
+                "security": [
+                    {
+                        "JWTAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves a list of films from a specified collection. This includes pagination and sorting metadata.\nYou must have permissions to access this collection.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collectionFilms"
+                ],
+                "summary": "Get films from collection",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Collection ID",
+                        "name": "collection_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by ` + "`" + `title` + "`" + `",
+                        "name": "title",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by ` + "`" + `rating` + "`" + `, can be a specific value or a range like 'min-max'",
+                        "name": "rating",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by ` + "`" + `year` + "`" + `",
+                        "name": "year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by ` + "`" + `user_rating` + "`" + `",
+                        "name": "user_rating",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by ` + "`" + `is_viewed` + "`" + ` (true/false)",
+                        "name": "is_viewed",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by ` + "`" + `is_favorite` + "`" + ` (true/false)",
+                        "name": "is_favorite",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by ` + "`" + `url` + "`" + ` (true/false)",
+                        "name": "has_url",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by ` + "`" + `exclude collection` + "`" + `",
+                        "name": "exclude_collection",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Specify the desired ` + "`" + `page` + "`" + `",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Specify the desired ` + "`" + `page size` + "`" + `",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sorting by ` + "`" + `id` + "`" + `, ` + "`" + `title` + "`" + `, ` + "`" + `rating` + "`" + `, ` + "`" + `year` + "`" + `, ` + "`" + `user_rating` + "`" + `, ` + "`" + `is_viewed` + "`" + `. Use ` + "`" + `-` + "`" + ` for desc",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Add the ` + "`" + `_permissions` + "`" + ` block with the actions you may perform to each item",
+                        "name": "include_permissions",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.CollectionFilmsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "JWTAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Create a new film and add it to the specified collection. You must have rights to create a film and update the collection.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collectionFilms"
+                ],
+                "summary": "Add new film and associate with collection",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Collection ID",
+                        "name": "collection_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Information about the new film",
+                        "name": "film",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swagger.FilmRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.CollectionFilmResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{collection_id}/films/{film_id}": {
+            "get": {
+                "security": [
+                    {
+                        "JWTAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Get the film from collection by ID. You must have permissions to get this collection.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collectionFilms"
+                ],
+                "summary": "Get film from collection by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Collection ID",
+                        "name": "collection_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Film ID",
+                        "name": "film_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.CollectionFilmResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "JWTAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Add existing film to the collection. You must have rights to get the film and update the collection.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collectionFilms"
+                ],
+                "summary": "Add existing film to collection",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Collection ID",
+                        "name": "collection_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Film ID",
+                        "name": "film_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.CollectionFilmResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{collection_id}/films/{films_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "JWTAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Delete the film from the collection by ID. You must have the permissions to update collection.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collectionFilms"
+                ],
+                "summary": "Delete film from collection",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Collection ID",
+                        "name": "collection_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Film ID",
+                        "name": "film_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{collection_id}/share": {
+            "post": {
+                "security": [
+                    {
+                        "JWTAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Generate a public read-only link to the collection for people without an account. The token is returned only once; creating a new link revokes the previous one. You must own the collection.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shareLinks"
+                ],
+                "summary": "Create a share link for the collection",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Collection ID",
+                        "name": "collection_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ShareLinkResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "JWTAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Revoke the public link to the collection. You must own the collection.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shareLinks"
+                ],
+                "summary": "Revoke the share link of the collection",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Collection ID",
+                        "name": "collection_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/films": {
+            "get": {
+                "security": [
+                    {
+                        "JWTAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Get a list of films by user ID from authentication token, or the library of a group the user is a member of. It also returns metadata.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "films"
+                ],
+                "summary": "Get user films",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "List ` + "`" + `personal` + "`" + ` films (default) or the library of a group, as in ` + "`" + `group:1` + "`" + `",
+                        "name": "scope",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by ` + "`" + `title` + "`" + `",
+                        "name": "title",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by ` + "`" + `rating` + "`" + `, can be a specific value or a range like 'min-max'",
+                        "name": "rating",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by ` + "`" + `year` + "`" + `",
+                        "name": "year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by ` + "`" + `user_rating` + "`" + `",
+                        "name": "user_rating",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by ` + "`" + `is_viewed` + "`" + ` (true/false)",
+                        "name": "is_viewed",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by ` + "`" + `is_favorite` + "`" + ` (true/false)",
+                        "name": "is_favorite",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by ` + "`" + `url` + "`" + ` (true/false)",
+                        "name": "has_url",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by ` + "`" + `exclude collection` + "`" + `",
+                        "name": "exclude_collection",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Specify the desired ` + "`" + `page` + "`" + `",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Specify the desired ` + "`" + `page size` + "`" + `",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sorting by ` + "`" + `id` + "`" + `, ` + "`" + `title` + "`" + `, ` + "`" + `rating` + "`" + `, ` + "`" + `year` + "`" + `, ` + "`" + `user_rating` + "`" + `, ` + "`" + `is_viewed` + "`" + `. Use ` + "`" + `-` + "`" + ` for desc",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Add the ` + "`" + `_permissions` + "`" + ` block with the actions you may perform to each item",
+                        "name": "include_permissions",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.FilmsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "JWTAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Add a new film. You will own it: you can get, update, and delete it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "films"
+                ],
+                "summary": "Add new film",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Add the ` + "`" + `_permissions` + "`" + ` block with the actions you may perform",
+                        "name": "include_permissions",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Information about the new film",
+                        "name": "film",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swagger.FilmRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/films/{film_id}": {
+            "get": {
+                "security": [
+                    {
+                        "JWTAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Get the film by ID. You must have permissions to get this film.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "films"
+                ],
+                "summary": "Get film by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Film ID",
+                        "name": "film_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Add the ` + "`" + `_permissions` + "`" + ` block with the actions you may perform",
+                        "name": "include_permissions",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.FilmResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "JWTAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Update the film by ID. You must have the permissions to update it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "films"
+                ],
+                "summary": "Update the film",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Film ID",
+                        "name": "film_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Add the ` + "`" + `_permissions` + "`" + ` block with the actions you may perform",
+                        "name": "include_permissions",
+                        "in": "query"
+                    },
+                    {
+                        "description": "New information about the film",
+                        "name": "film",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swagger.FilmRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "JWTAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Delete the film by ID. You must have the permissions to delete it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "films"
+                ],
+                "summary": "Delete the film",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Film ID",
+                        "name": "film_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups": {
+            "get": {
+                "security": [
+                    {
+                        "JWTAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Get the groups the user is a member of, with the role of the user in each group.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "Get user groups",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.GroupsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "JWTAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Create a group, such as a household, to share a library of films and collections. You will be its owner.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "Create a group",
+                "parameters": [
+                    {
+                        "description": "Name of the group",
+                        "name": "group",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swagger.GroupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.GroupResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{group_id}": {
+            "get": {
+                "security": [
+                    {
+                        "JWTAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Get the group by ID with its members. You must be a member of the group.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "Get group by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Group ID",
+                        "name": "group_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.GroupMembersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "JWTAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Delete the group by ID. The films and collections of its library stay with the members who added them. You must own the group.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "Delete the group",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Group ID",
+                        "name": "group_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{group_id}/collections/{collection_id}": {
+            "post": {
+                "security": [
+                    {
+                        "JWTAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Add the collection to the library of the group, so that all members can get and update it. You must own the collection and be a member of the group.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "Add a collection to the group library",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Group ID",
+                        "name": "group_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Collection ID",
+                        "name": "collection_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "JWTAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Remove the collection from the library of the group. The collection stays with its owner. You must own the collection or the group.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "Remove a collection from the group library",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Group ID",
+                        "name": "group_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Collection ID",
+                        "name": "collection_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{group_id}/films/{film_id}": {
+            "post": {
+                "security": [
+                    {
+                        "JWTAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Add the film to the library of the group, so that all members can get and update it. You must own the film and be a member of the group.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "Add a film to the group library",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Group ID",
+                        "name": "group_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Film ID",
+                        "name": "film_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "JWTAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Remove the film from the library of the group. The film stays with its owner. You must own the film or the group.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "Remove a film from the group library",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Group ID",
+                        "name": "group_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Film ID",
+                        "name": "film_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{group_id}/members": {
+            "post": {
+                "security": [
+                    {
+                        "JWTAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Invite a user by username to the group. Members can get and update the films and collections of the group library. You must own the group.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "Add a member to the group",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Group ID",
+                        "name": "group_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Username of the user",
+                        "name": "member",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swagger.GroupMemberRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.GroupMemberResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{group_id}/members/{user_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "JWTAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Remove a user from the group; the films and collections they added leave the group library. The owner can remove any member; members can remove themselves to leave the group.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "Remove a member from the group",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Group ID",
+                        "name": "group_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/healthcheck": {
+            "get": {
+                "description": "Check the API status. Returns status and system information.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "monitoring"
+                ],
+                "summary": "Check API status",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.HealthcheckResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/shared/{token}": {
+            "get": {
+                "description": "Get the collection of a share link and its films without authentication. The owner's comments, reviews, ratings and viewing status are not included.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shareLinks"
+                ],
+                "summary": "Get a shared collection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Share token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by ` + "`" + `title` + "`" + `",
+                        "name": "title",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by ` + "`" + `rating` + "`" + `, can be a specific value or a range like 'min-max'",
+                        "name": "rating",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by ` + "`" + `year` + "`" + `",
+                        "name": "year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Specify the desired ` + "`" + `page` + "`" + `",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Specify the desired ` + "`" + `page size` + "`" + `",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sorting by ` + "`" + `id` + "`" + `, ` + "`" + `title` + "`" + `, ` + "`" + `rating` + "`" + `, ` + "`" + `year` + "`" + `, ` + "`" + `created_at` + "`" + `. Use ` + "`" + `-` + "`" + ` for desc",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.SharedCollectionResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user": {
+            "get": {
+                "security": [
+                    {
+                        "JWTAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Get information about user by ID using an authentication token.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get user account",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UserResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "JWTAuth": []
+                    }
+                ],
+                "description": "Update information about user by ID using an authentication token.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Update user account",
+                "parameters": [
+                    {
+                        "description": "New information about the user",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UpdateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "JWTAuth": []
+                    }
+                ],
+                "description": "Delete the account of the authenticated user and log out of all sessions.\nThe account is kept for a grace period (14 days by default) and restored by logging in again. After that, it is purged with all films, collections and permissions.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Delete user account",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.AccountDeletionResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/2fa/confirm": {
+            "post": {
+                "security": [
+                    {
+                        "JWTAuth": []
+                    }
+                ],
+                "description": "Enable two-factor authentication using a code from the authenticator app.\nReturns single-use recovery codes. They are shown only once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Confirm two-factor authentication",
+                "parameters": [
+                    {
+                        "description": "TOTP code",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swagger.TwoFactorCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.RecoveryCodesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/2fa/disable": {
+            "post": {
+                "security": [
+                    {
+                        "JWTAuth": []
+                    }
+                ],
+                "description": "Disable two-factor authentication using the current password and a TOTP or recovery code.\nThe secret and all recovery codes are deleted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Disable two-factor authentication",
+                "parameters": [
+                    {
+                        "description": "Password and code",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swagger.TwoFactorDisableRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/2fa/enroll": {
+            "post": {
+                "security": [
+                    {
+                        "JWTAuth": []
+                    }
+                ],
+                "description": "Generate a new TOTP secret and a provisioning URI for an authenticator app.\nTwo-factor authentication is enabled only after the secret is confirmed with a code.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Start two-factor authentication enrollment",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.TwoFactorEnrollmentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/api-keys": {
+            "get": {
+                "security": [
+                    {
+                        "JWTAuth": []
+                    }
+                ],
+                "description": "Get a list of API keys of the user that are not revoked. Secret keys are not included.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get API keys",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.APIKeysResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "JWTAuth": []
+                    }
+                ],
+                "description": "Create a personal API key for scripts and integrations. Send it in the ` + "`" + `X-API-Key` + "`" + ` header instead of a JWT token.\n` + "`" + `read` + "`" + ` keys allow only GET requests, ` + "`" + `read-write` + "`" + ` keys allow all requests. API keys cannot manage the account.\nThe key is returned only once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Create an API key",
+                "parameters": [
+                    {
+                        "description": "Information about the new API key",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swagger.APIKeyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.APIKeyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/api-keys/{api_key_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "JWTAuth": []
+                    }
+                ],
+                "description": "Revoke the API key by ID. It can no longer be used.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Revoke the API key",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "API key ID",
+                        "name": "api_key_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/audit": {
+            "get": {
+                "security": [
+                    {
+                        "JWTAuth": []
+                    }
+                ],
+                "description": "Get the audit log of the authenticated user: registration, logins and failed logins, token refreshes, logouts, account changes and permission changes.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get the security events of the user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by ` + "`" + `event` + "`" + `, e.g. ` + "`" + `user.login_failed` + "`" + `",
+                        "name": "event",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only events at or after this RFC 3339 time",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only events before this RFC 3339 time",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Specify the desired ` + "`" + `page` + "`" + `",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Specify the desired ` + "`" + `page size` + "`" + `",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.AuditEventsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/email/verify": {
+            "post": {
+                "security": [
+                    {
+                        "JWTAuth": []
+                    }
+                ],
+                "description": "Send a new email verification token to the current email of the user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Request email verification",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/export": {
+            "post": {
+                "security": [
+                    {
+                        "JWTAuth": []
+                    }
+                ],
+                "description": "Start generating a ZIP archive with the profile, login methods, all films, collections with their films, uploaded images, permissions and sessions of the user.\nThe archive is generated in the background. Check its status at ` + "`" + `/user/export/{export_id}` + "`" + ` and download it within 24 hours when it is ready.\nStarting a new export deletes the previous ones.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Export all account data",
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.AccountExportResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/export/{export_id}": {
+            "get": {
+                "security": [
+                    {
+                        "JWTAuth": []
+                    }
+                ],
+                "description": "Get the status of an export: ` + "`" + `pending` + "`" + `, ` + "`" + `ready` + "`" + ` or ` + "`" + `failed` + "`" + `. A ready export can be downloaded until ` + "`" + `expires_at` + "`" + `.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get the status of an account export",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Export ID",
+                        "name": "export_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.AccountExportResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/export/{export_id}/download": {
+            "get": {
+                "security": [
+                    {
+                        "JWTAuth": []
+                    }
+                ],
+                "description": "Download the ZIP archive of a ready export. It contains ` + "`" + `account.json` + "`" + ` and the uploaded images of the films in the ` + "`" + `images` + "`" + ` folder.",
+                "produces": [
+                    "application/zip"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Download an account export",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Export ID",
+                        "name": "export_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/identities": {
+            "get": {
+                "security": [
+                    {
+                        "JWTAuth": []
+                    }
+                ],
+                "description": "Get a list of login methods linked to the account: password, Telegram and OpenID Connect identities.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get login methods",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.IdentitiesResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/identities/password": {
+            "post": {
+                "security": [
+                    {
+                        "JWTAuth": []
+                    }
+                ],
+                "description": "Set a username and password for an account without a password, allowing login with credentials.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Link a username and password",
+                "parameters": [
+                    {
+                        "description": "Username and password",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swagger.PasswordIdentityRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/identities/telegram": {
+            "post": {
+                "security": [
+                    {
+                        "JWTAuth": []
+                    }
+                ],
+                "description": "Link the Telegram account of the verification token from header to the account, allowing login by Telegram.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Link a Telegram account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Verification token from Telegram",
+                        "name": "Verification",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.MessageResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/identities/{identity_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "JWTAuth": []
+                    }
+                ],
+                "description": "Unlink the login method by ID. Unlinking the password or Telegram identity removes it from the account.\nThe last login method cannot be unlinked.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Unlink a login method",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Identity ID",
+                        "name": "identity_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/import": {
+            "post": {
+                "security": [
+                    {
+                        "JWTAuth": []
+                    }
+                ],
+                "description": "Restore the films, collections with their films and uploaded images of an archive from ` + "`" + `/user/export` + "`" + ` into the account. The account may be empty or already have data.\nFilms and collections get new IDs, and all references are updated. The profile, login methods and sessions of the archive are not restored.\nPermissions are never taken from the archive, and the user needs the ` + "`" + `film:create` + "`" + ` and ` + "`" + `collection:create` + "`" + ` permissions to restore films and collections. The restored films and collections are owned by the user as if they were created.\nWith ` + "`" + `dry_run` + "`" + `, nothing is changed and the report lists what would be restored and the conflicts found. Blocking conflicts, such as films or collections that already exist, stop the restore unless ` + "`" + `force` + "`" + ` is set.\nThe whole restore runs in one transaction.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Restore an account archive",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Account archive (ZIP)",
+                        "name": "archive",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only check the archive and report the conflicts",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Restore despite blocking conflicts, creating duplicates",
+                        "name": "force",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.AccountImportResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.AccountImportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.AccountImportConflictResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/password": {
+            "put": {
+                "security": [
+                    {
+                        "JWTAuth": []
+                    }
+                ],
+                "description": "Change the password of the user using the current password. All sessions and access tokens of the user are revoked.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Change user password",
+                "parameters": [
+                    {
+                        "description": "Current and new password",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ChangePasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/permissions": {
+            "get": {
+                "security": [
+                    {
+                        "JWTAuth": []
+                    }
+                ],
+                "description": "Get the permission codes of the user: account-wide codes, such as ` + "`" + `film:create` + "`" + `, and access to films and collections of other users, such as ` + "`" + `film:1:read` + "`" + `. Access to own films and collections and through groups is not listed.\nWith ` + "`" + `resource` + "`" + ` and ` + "`" + `id` + "`" + `, get the actions the user may perform on that film, collection or group instead, so that clients can hide actions that would be rejected.\nWith a read-only API key, only the codes and actions for reading are reported.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get the effective permissions of the user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Type of the resource: ` + "`" + `film` + "`" + `, ` + "`" + `collection` + "`" + ` or ` + "`" + `group` + "`" + `",
+                        "name": "resource",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID of the resource",
+                        "name": "id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ResourcePermissionsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/sessions": {
+            "get": {
+                "security": [
+                    {
+                        "JWTAuth": []
+                    }
+                ],
+                "description": "Get a list of active sessions of the user with creation, expiration and last use time, user agent and client IP.\nThe session of the current access token is marked as ` + "`" + `current` + "`" + `.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get active sessions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.SessionsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "JWTAuth": []
+                    }
+                ],
+                "description": "Revoke all sessions of the user except the session of the current access token.\nTheir refresh tokens and access tokens can no longer be used.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Revoke other sessions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.MessageResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/sessions/{session_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "JWTAuth": []
+                    }
+                ],
+                "description": "Revoke the session by ID. Its refresh token and access tokens can no longer be used.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Revoke the session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "session_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.MessageResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "filters.Metadata": {
+            "type": "object",
+            "properties": {
+                "current_page": {
+                    "description": "The current page number.",
+                    "type": "integer",
+                    "example": 2
+                },
+                "first_page": {
+                    "description": "The first page number, usually 1.",
+                    "type": "integer",
+                    "example": 1
+                },
+                "last_page": {
+                    "description": "The last page number based on total records and page size.",
+                    "type": "integer",
+                    "example": 3
+                },
+                "page_size": {
+                    "description": "The number of items per page.",
+                    "type": "integer",
+                    "example": 5
+                },
+                "total_records": {
+                    "description": "The total number of records available.",
+                    "type": "integer",
+                    "example": 15
+                }
+            }
+        },
+        "models.APIKey": {
+            "type": "object",
+            "required": [
+                "name",
+                "scope"
+            ],
+            "properties": {
+                "created_at": {
+                    "description": "Timestamp when the key was created.",
+                    "type": "string",
+                    "example": "2024-09-04T13:37:24.87653+05:00"
+                },
+                "expires_at": {
+                    "description": "Timestamp when the key expires; never if empty.",
+                    "type": "string",
+                    "example": "2025-09-04T13:37:24.87653+05:00"
+                },
+                "id": {
+                    "description": "Unique identifier for the API key.",
+                    "type": "integer",
+                    "example": 1
+                },
+                "key": {
+                    "description": "Secret key; returned only when the key is created.",
+                    "type": "string",
+                    "example": "wl_3kF9x2Hk5e9rVb3LmA8sYwq0N7x2Hk5e9rVb3LmA8s"
+                },
+                "last_used_at": {
+                    "description": "Timestamp when the key was last used.",
+                    "type": "string",
+                    "example": "2024-09-05T13:37:24.87653+05:00"
+                },
+                "name": {
+                    "description": "Name of the API key.",
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1,
+                    "example": "Import script"
+                },
+                "prefix": {
+                    "description": "First characters of the key, to tell keys apart.",
+                    "type": "string",
+                    "example": "wl_3kF9"
+                },
+                "scope": {
+                    "description": "Scope of the API key: read or read-write.",
+                    "type": "string",
+                    "enum": [
+                        "read",
+                        "read-write"
+                    ],
+                    "example": "read-write"
+                }
+            }
+        },
+        "models.AccountExport": {
+            "type": "object",
+            "properties": {
+                "completed_at": {
+                    "description": "Timestamp when the archive was generated.",
+                    "type": "string",
+                    "example": "2024-09-04T13:37:26.87653+05:00"
+                },
+                "created_at": {
+                    "description": "Timestamp when the export was requested.",
+                    "type": "string",
+                    "example": "2024-09-04T13:37:24.87653+05:00"
+                },
+                "error": {
+                    "description": "Reason of a failed export.",
+                    "type": "string",
+                    "example": "failed to read films"
+                },
+                "expires_at": {
+                    "description": "Timestamp after which the archive is deleted.",
+                    "type": "string",
+                    "example": "2024-09-05T13:37:26.87653+05:00"
+                },
+                "id": {
+                    "description": "Identifier of the export.",
+                    "type": "string",
+                    "example": "5b1c7a3e-2f4d-4c4b-9a53-0f8e5d7c1a2b"
+                },
+                "size": {
+                    "description": "Size of the archive in bytes.",
+                    "type": "integer",
+                    "example": 20480
+                },
+                "status": {
+                    "description": "Status of the export: pending, ready or failed.",
+                    "type": "string",
+                    "example": "ready"
+                }
+            }
+        },
+        "models.AccountImportReport": {
+            "type": "object",
+            "properties": {
+                "collection_films": {
+                    "description": "Number of films added to collections.",
+                    "type": "integer",
+                    "example": 6
+                },
+                "collections": {
+                    "description": "Number of collections restored.",
+                    "type": "integer",
+                    "example": 2
+                },
+                "conflicts": {
+                    "description": "Conflicts found in the archive.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ImportConflict"
+                    }
+                },
+                "dry_run": {
+                    "description": "Whether the archive was only checked.",
+                    "type": "boolean",
+                    "example": true
+                },
+                "films": {
+                    "description": "Number of films restored.",
+                    "type": "integer",
+                    "example": 10
+                },
+                "images": {
+                    "description": "Number of uploaded images restored.",
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "models.AdminAction": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "Name of the action.",
+                    "type": "string",
+                    "example": "user.suspend"
+                },
+                "admin_id": {
+                    "description": "Identifier of the admin who performed the action.",
+                    "type": "integer",
+                    "example": 1
+                },
+                "created_at": {
+                    "description": "Timestamp when the action was performed.",
+                    "type": "string",
+                    "example": "2024-09-04T13:37:24.87653+05:00"
+                },
+                "details": {
+                    "description": "Additional information about the action.",
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "id": {
+                    "description": "Unique identifier for the action.",
+                    "type": "integer",
+                    "example": 1
+                },
+                "target_user_id": {
+                    "description": "Identifier of the user the action was performed on.",
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "models.AuditEvent": {
+            "type": "object",
+            "properties": {
+                "actor_id": {
+                    "description": "Identifier of the authenticated user who caused the event; omitted if anonymous.",
+                    "type": "integer",
+                    "example": 1
+                },
+                "client_ip": {
+                    "description": "IP address of the client.",
+                    "type": "string",
+                    "example": "203.0.113.7"
+                },
+                "created_at": {
+                    "description": "Timestamp when the event was recorded.",
+                    "type": "string",
+                    "example": "2024-09-04T13:37:24.87653+05:00"
+                },
+                "details": {
+                    "description": "Additional information about the event.",
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "event": {
+                    "description": "Name of the event.",
+                    "type": "string",
+                    "example": "user.login"
+                },
+                "id": {
+                    "description": "Unique identifier for the event.",
+                    "type": "integer",
+                    "example": 1
+                },
+                "request_id": {
+                    "description": "Identifier of the request, as in the X-Request-ID header.",
+                    "type": "string",
+                    "example": "8f14e45fceea167a5a36dedd4bea2543"
+                },
+                "user_agent": {
+                    "description": "User agent of the client.",
+                    "type": "string",
+                    "example": "Mozilla/5.0"
+                },
+                "user_id": {
+                    "description": "Identifier of the user the event is about; omitted if unknown.",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.AuthResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "description": "JWT Access Token used to access protected resources.",
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIs.eyJzdWIilIn0.iTNuOHMObmeRmKU"
+                },
+                "created_at": {
+                    "description": "Timestamp when the user was created.",
+                    "type": "string",
+                    "example": "2024-09-04T13:37:24.87653+05:00"
+                },
+                "deletion_scheduled_at": {
+                    "description": "Timestamp when the user deleted the account; omitted if not deleted.",
+                    "type": "string",
+                    "example": "2024-09-06T13:37:24.87653+05:00"
+                },
+                "email": {
+                    "description": "Email address of the user; must be a valid email format.",
+                    "type": "string",
+                    "maxLength": 254,
+                    "minLength": 6,
+                    "example": "john_doe@example.com"
+                },
+                "email_verified_at": {
+                    "description": "Timestamp when the email was verified; omitted if not verified.",
+                    "type": "string",
+                    "example": "2024-09-04T13:37:24.87653+05:00"
+                },
+                "id": {
+                    "description": "Unique identifier for the user.",
+                    "type": "integer",
+                    "example": 1
+                },
+                "refresh_token": {
+                    "description": "JWT Refresh Token used to obtain a new Access Token when it expires.",
+                    "type": "string",
+                    "example": "eyJhbGciOI6IkpXVCJ9.eyJzdk5EbifQ.4CfEaMw6Ur_fszI"
+                },
+                "restored": {
+                    "description": "Whether logging in restored an account scheduled for deletion.",
+                    "type": "boolean",
+                    "example": true
+                },
+                "suspended_at": {
+                    "description": "Timestamp when the account was suspended by an admin; omitted if active.",
+                    "type": "string",
+                    "example": "2024-09-05T13:37:24.87653+05:00"
+                },
+                "telegram_id": {
+                    "type": "integer",
+                    "example": 123456789
+                },
+                "username": {
+                    "description": "Username of the user; must be unique and valid.",
+                    "type": "string",
+                    "maxLength": 20,
+                    "minLength": 3,
+                    "example": "john_doe"
+                }
+            }
+        },
+        "models.Collaborator": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "Timestamp when the collection was shared with the user.",
+                    "type": "string",
+                    "example": "2024-09-04T13:37:24.87653+05:00"
+                },
+                "role": {
+                    "description": "Role of the user: editor or viewer.",
+                    "type": "string",
+                    "example": "viewer"
+                },
+                "user_id": {
+                    "description": "Identifier of the user.",
+                    "type": "integer",
+                    "example": 2
+                },
+                "username": {
+                    "description": "Username of the user.",
+                    "type": "string",
+                    "example": "jane_doe"
+                }
+            }
+        },
+        "models.Collection": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "created_at": {
+                    "description": "Timestamp when the collection was created.",
+                    "type": "string",
+                    "example": "2024-09-04T13:37:24.87653+05:00"
+                },
+                "description": {
+                    "description": "Description of the collection; optional, up to 500 characters.",
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "This is description"
+                },
+                "id": {
+                    "description": "Unique identifier for the collection.",
+                    "type": "integer",
+                    "example": 1
+                },
+                "is_favorite": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "name": {
+                    "description": "Name of the collection; required, between 3 and 100 characters.",
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3,
+                    "example": "My collection"
+                },
+                "role": {
+                    "description": "Role of the user in the collection: owner, editor or viewer; set in lists of collections.",
+                    "type": "string",
+                    "example": "owner"
+                },
+                "total_films": {
+                    "description": "Total number of films in the collection.",
+                    "type": "integer",
+                    "example": 5
+                },
+                "updated_at": {
+                    "description": "Timestamp when the collection was last updated.",
+                    "type": "string",
+                    "example": "2024-09-04T13:37:24.87653+05:00"
+                },
+                "user_id": {
+                    "description": "Identifier of the user who created the collection.",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.CollectionFilm": {
+            "type": "object",
+            "properties": {
+                "added_at": {
+                    "description": "Timestamp when the film was added to the collection.",
+                    "type": "string",
+                    "example": "2024-09-04T13:37:24.87653+05:00"
+                },
+                "collection": {
+                    "description": "Identifier of the collection.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Collection"
+                        }
+                    ]
+                },
+                "film": {
+                    "description": "Identifier of the film.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Film"
+                        }
+                    ]
+                },
+                "updated_at": {
+                    "description": "Timestamp when the association was last updated.",
+                    "type": "string",
+                    "example": "2024-09-04T13:37:24.87653+05:00"
+                }
+            }
+        },
+        "models.Film": {
+            "type": "object",
+            "required": [
+                "title"
+            ],
+            "properties": {
+                "comment": {
+                    "description": "User's comment of the film; optional, up to 500 characters.",
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "This is comment"
+                },
+                "created_at": {
+                    "description": "Timestamp when the film was added.",
+                    "type": "string",
+                    "example": "2024-09-04T13:37:24.87653+05:00"
+                },
+                "description": {
+                    "description": "Description of the film; optional, up to 1000 characters.",
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": "This is description"
+                },
+                "genre": {
+                    "description": "Genre of the film; optional.",
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Horror"
+                },
+                "id": {
+                    "description": "Unique identifier for the film.",
+                    "type": "integer",
+                    "example": 1
+                },
+                "image_url": {
+                    "description": "URL of the film's image; optional, must be a valid URL.",
+                    "type": "string",
+                    "example": "https://placeimg.com/640/480"
+                },
+                "is_favorite": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "is_viewed": {
+                    "description": "Indicates if the user has viewed the film.",
+                    "type": "boolean",
+                    "example": true
+                },
+                "rating": {
+                    "description": "Rating of the film; optional, must be between 1 and 10.",
+                    "type": "number",
+                    "maximum": 10,
+                    "minimum": 1,
+                    "example": 6.7
+                },
+                "review": {
+                    "description": "User's review of the film; optional, up to 500 characters.",
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "This is review"
+                },
+                "title": {
+                    "description": "Title of the film; required, between 3 and 100 characters.",
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3,
+                    "example": "My film"
+                },
+                "updated_at": {
+                    "description": "Timestamp when the film details were last updated.",
+                    "type": "string",
+                    "example": "2024-09-04T13:37:24.87653+05:00"
+                },
+                "url": {
+                    "description": "URL for additional film information (e.g., IMDb or trailer); optional, must be valid.",
+                    "type": "string",
+                    "example": "https://www.imdb.com/video"
+                },
+                "user_id": {
+                    "description": "Identifier of the user who added the film.",
+                    "type": "integer",
+                    "example": 1
+                },
+                "user_rating": {
+                    "description": "User's rating of the film; optional, between 1 and 10.",
+                    "type": "number",
+                    "maximum": 10,
+                    "minimum": 1,
+                    "example": 5.5
+                },
+                "year": {
+                    "description": "Release year of the film; optional, must be between 1888 and 2100.",
+                    "type": "integer",
+                    "maximum": 2100,
+                    "minimum": 1888,
+                    "example": 2001
+                }
+            }
+        },
+        "models.Group": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "created_at": {
+                    "description": "Timestamp when the group was created.",
+                    "type": "string",
+                    "example": "2024-09-04T13:37:24.87653+05:00"
+                },
+                "id": {
+                    "description": "Unique identifier for the group.",
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "description": "Name of the group; required, between 3 and 100 characters.",
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3,
+                    "example": "Living room"
+                },
+                "role": {
+                    "description": "Role of the user in the group: owner or member.",
+                    "type": "string",
+                    "example": "owner"
+                },
+                "updated_at": {
+                    "description": "Timestamp when the group was last updated.",
+                    "type": "string",
+                    "example": "2024-09-04T13:37:24.87653+05:00"
+                },
+                "user_id": {
+                    "description": "Identifier of the user who created the group.",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.GroupMember": {
+            "type": "object",
+            "properties": {
+                "joined_at": {
+                    "description": "Timestamp when the user joined the group.",
+                    "type": "string",
+                    "example": "2024-09-04T13:37:24.87653+05:00"
+                },
+                "role": {
+                    "description": "Role of the user: owner or member.",
+                    "type": "string",
+                    "example": "member"
+                },
+                "user_id": {
+                    "description": "Identifier of the user.",
+                    "type": "integer",
+                    "example": 2
+                },
+                "username": {
+                    "description": "Username of the user.",
+                    "type": "string",
+                    "example": "jane_doe"
+                }
+            }
+        },
+        "models.ImportConflict": {
+            "type": "object",
+            "properties": {
+                "blocking": {
+                    "description": "Whether the conflict stops the restore unless forced; other items are skipped.",
+                    "type": "boolean",
+                    "example": true
+                },
+                "message": {
+                    "description": "Description of the conflict.",
+                    "type": "string",
+                    "example": "film 'My film' (2001) already exists"
+                },
+                "type": {
+                    "description": "Kind of the conflict.",
+                    "type": "string",
+                    "example": "film_exists"
+                }
+            }
+        },
+        "models.ResourcePermissions": {
+            "type": "object",
+            "properties": {
+                "actions": {
+                    "description": "Allowed actions: read, update, delete and share.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "read",
+                        "update",
+                        "delete",
+                        "share"
+                    ]
+                },
+                "id": {
+                    "description": "Identifier of the resource.",
+                    "type": "integer",
+                    "example": 5
+                },
+                "resource": {
+                    "description": "Type of the resource: film, collection or group.",
+                    "type": "string",
+                    "example": "film"
+                }
+            }
+        },
+        "models.Session": {
+            "type": "object",
+            "properties": {
+                "client_ip": {
+                    "description": "IP address of the client that last used the session.",
+                    "type": "string",
+                    "example": "203.0.113.7"
+                },
+                "created_at": {
+                    "description": "Timestamp when the session was started.",
+                    "type": "string",
+                    "example": "2024-09-04T13:37:24.87653+05:00"
+                },
+                "current": {
+                    "description": "Indicates if the session is the one making the request.",
+                    "type": "boolean",
+                    "example": true
+                },
+                "expires_at": {
+                    "description": "Timestamp when the session expires unless refreshed.",
+                    "type": "string",
+                    "example": "2024-09-06T13:37:24.87653+05:00"
+                },
+                "id": {
+                    "description": "Identifier of the session.",
+                    "type": "string",
+                    "example": "5b1c7a3e-2f4d-4c4b-9a53-0f8e5d7c1a2b"
+                },
+                "last_used_at": {
+                    "description": "Timestamp when the session was last refreshed.",
+                    "type": "string",
+                    "example": "2024-09-04T13:37:24.87653+05:00"
+                },
+                "user_agent": {
+                    "description": "User agent of the client that last used the session.",
+                    "type": "string",
+                    "example": "Mozilla/5.0"
+                }
+            }
+        },
+        "models.ShareLink": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "Timestamp when the link was created.",
+                    "type": "string",
+                    "example": "2024-09-04T13:37:24.87653+05:00"
+                },
+                "token": {
+                    "description": "Unguessable token of the link.",
+                    "type": "string",
+                    "example": "fV3Q2v7ZL1mU9x2nKc0bqZ5yR8wT4jHa6sD1pE3gN7o"
+                },
+                "url": {
+                    "description": "Public URL of the collection.",
+                    "type": "string",
+                    "example": "http://localhost:8001/api/v1/shared/fV3Q2v7ZL1mU9x2nKc0bqZ5yR8wT4jHa6sD1pE3gN7o"
+                }
+            }
+        },
+        "models.SharedCollection": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "Timestamp when the collection was created.",
+                    "type": "string",
+                    "example": "2024-09-04T13:37:24.87653+05:00"
+                },
+                "description": {
+                    "description": "Description of the collection.",
+                    "type": "string",
+                    "example": "This is description"
+                },
+                "films": {
+                    "description": "Films of the collection on the requested page.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SharedFilm"
+                    }
+                },
+                "name": {
+                    "description": "Name of the collection.",
+                    "type": "string",
+                    "example": "My collection"
+                },
+                "total_films": {
+                    "description": "Total number of films in the collection.",
+                    "type": "integer",
+                    "example": 5
+                },
+                "updated_at": {
+                    "description": "Timestamp when the collection was last updated.",
+                    "type": "string",
+                    "example": "2024-09-04T13:37:24.87653+05:00"
+                }
+            }
+        },
+        "models.SharedFilm": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "description": "Description of the film.",
+                    "type": "string",
+                    "example": "This is description"
+                },
+                "genre": {
+                    "description": "Genre of the film.",
+                    "type": "string",
+                    "example": "Horror"
+                },
+                "image_url": {
+                    "description": "URL of the film's image.",
+                    "type": "string",
+                    "example": "https://placeimg.com/640/480"
+                },
+                "rating": {
+                    "description": "Rating of the film.",
+                    "type": "number",
+                    "example": 6.7
+                },
+                "title": {
+                    "description": "Title of the film.",
+                    "type": "string",
+                    "example": "My film"
+                },
+                "url": {
+                    "description": "URL for additional film information.",
+                    "type": "string",
+                    "example": "https://www.imdb.com/video"
+                },
+                "year": {
+                    "description": "Release year of the film.",
+                    "type": "integer",
+                    "example": 2001
+                }
+            }
+        },
+        "models.TwoFactorEnrollment": {
+            "type": "object",
+            "properties": {
+                "provisioning_uri": {
+                    "description": "URI for QR codes.",
+                    "type": "string",
+                    "example": "otpauth://totp/Watchlist:john_doe?secret=JBSWY3DPEHPK3PXP\u0026issuer=Watchlist"
+                },
+                "secret": {
+                    "description": "Base32-encoded shared secret.",
+                    "type": "string",
+                    "example": "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"
+                }
+            }
+        },
+        "models.User": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "Timestamp when the user was created.",
+                    "type": "string",
+                    "example": "2024-09-04T13:37:24.87653+05:00"
+                },
+                "deletion_scheduled_at": {
+                    "description": "Timestamp when the user deleted the account; omitted if not deleted.",
+                    "type": "string",
+                    "example": "2024-09-06T13:37:24.87653+05:00"
+                },
+                "email": {
+                    "description": "Email address of the user; must be a valid email format.",
+                    "type": "string",
+                    "maxLength": 254,
+                    "minLength": 6,
+                    "example": "john_doe@example.com"
+                },
+                "email_verified_at": {
+                    "description": "Timestamp when the email was verified; omitted if not verified.",
+                    "type": "string",
+                    "example": "2024-09-04T13:37:24.87653+05:00"
+                },
+                "id": {
+                    "description": "Unique identifier for the user.",
+                    "type": "integer",
+                    "example": 1
+                },
+                "suspended_at": {
+                    "description": "Timestamp when the account was suspended by an admin; omitted if active.",
+                    "type": "string",
+                    "example": "2024-09-05T13:37:24.87653+05:00"
+                },
+                "telegram_id": {
+                    "type": "integer",
+                    "example": 123456789
+                },
+                "username": {
+                    "description": "Username of the user; must be unique and valid.",
+                    "type": "string",
+                    "maxLength": 20,
+                    "minLength": 3,
+                    "example": "john_doe"
+                }
+            }
+        },
+        "models.UserIdentity": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "Timestamp when the identity was linked.",
+                    "type": "string",
+                    "example": "2024-09-04T13:37:24.87653+05:00"
+                },
+                "email": {
+                    "description": "Email reported by the OpenID Connect provider.",
+                    "type": "string",
+                    "example": "john_doe@example.com"
+                },
+                "id": {
+                    "description": "Unique identifier for the identity.",
+                    "type": "integer",
+                    "example": 1
+                },
+                "issuer": {
+                    "description": "Issuer of an OpenID Connect identity.",
+                    "type": "string",
+                    "example": "https://accounts.google.com"
+                },
+                "provider": {
+                    "description": "Login method: password, telegram or oidc.",
+                    "type": "string",
+                    "example": "telegram"
+                },
+                "subject": {
+                    "description": "Telegram ID or OpenID Connect subject.",
+                    "type": "string",
+                    "example": "123456789"
+                }
+            }
+        },
+        "rest.Dependency": {
+            "type": "object",
+            "properties": {
+                "response_time": {
+                    "type": "string",
+                    "example": "48ms"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "up"
+                }
+            }
+        },
+        "rest.HealthcheckResponse": {
+            "type": "object",
+            "properties": {
+                "dependencies": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/rest.Dependency"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "example": "operational"
+                },
+                "systemInfo": {
+                    "$ref": "#/definitions/rest.SystemInfo"
+                }
+            }
+        },
+        "rest.SystemInfo": {
+            "type": "object",
+            "properties": {
+                "environment": {
+                    "type": "string",
+                    "example": "prod"
+                },
+                "last_checked": {
+                    "type": "string",
+                    "example": "2024-09-24T00:41:20+05:00"
+                },
+                "uptime": {
+                    "type": "string",
+                    "example": "3h 26m 30s"
+                }
+            }
+        },
+        "swagger.APIKeyRequest": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string",
+                    "example": "2025-09-04T13:37:24.87653+05:00"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Import script"
+                },
+                "scope": {
+                    "type": "string",
+                    "example": "read-write"
+                }
+            }
+        },
+        "swagger.APIKeyResponse": {
+            "type": "object",
+            "properties": {
+                "api_key": {
+                    "$ref": "#/definitions/models.APIKey"
+                }
+            }
+        },
+        "swagger.APIKeysResponse": {
+            "type": "object",
+            "properties": {
+                "api_keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.APIKey"
+                    }
+                }
+            }
+        },
+        "swagger.AccessTokenResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string",
+                    "example": "eyJhbGciOI6IkpXVCJ9.eyJzdk5EbifQ.4CfEaMw6Ur_fszI"
+                },
+                "refresh_token": {
+                    "type": "string",
+                    "example": "eyJhbGciOI6IkpXVCJ9.eyJzdk5EbifQ.4CfEaMw6Ur_fszI"
+                }
+            }
+        },
+        "swagger.AccountDeletionResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "user deleted"
+                },
+                "purge_at": {
+                    "type": "string",
+                    "example": "2024-09-18T13:37:24.87653+05:00"
+                }
+            }
+        },
+        "swagger.AccountExportResponse": {
+            "type": "object",
+            "properties": {
+                "export": {
+                    "$ref": "#/definitions/models.AccountExport"
+                }
+            }
+        },
+        "swagger.AccountImportConflictResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "the archive conflicts with the account; check the conflicts or force the restore"
+                },
+                "import": {
+                    "$ref": "#/definitions/models.AccountImportReport"
+                }
+            }
+        },
+        "swagger.AccountImportResponse": {
+            "type": "object",
+            "properties": {
+                "import": {
+                    "$ref": "#/definitions/models.AccountImportReport"
+                }
+            }
+        },
+        "swagger.AdminActionsResponse": {
+            "type": "object",
+            "properties": {
+                "actions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AdminAction"
+                    }
+                },
+                "metadata": {
+                    "$ref": "#/definitions/filters.Metadata"
+                }
+            }
+        },
+        "swagger.AuditEventsResponse": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AuditEvent"
+                    }
+                },
+                "metadata": {
+                    "$ref": "#/definitions/filters.Metadata"
+                }
             }
-        }
-    },
-    "definitions": {
-        "filters.Metadata": {
+        },
+        "swagger.AuthResponse": {
             "type": "object",
             "properties": {
-                "current_page": {
-                    "description": "The current page number.",
-                    "type": "integer",
-                    "example": 2
-                },
-                "first_page": {
-                    "description": "The first page number, usually 1.",
-                    "type": "integer",
-                    "example": 1
-                },
-                "last_page": {
-                    "description": "The last page number based on total records and page size.",
-                    "type": "integer",
-                    "example": 3
-                },
-                "page_size": {
-                    "description": "The number of items per page.",
-                    "type": "integer",
-                    "example": 5
-                },
-                "total_records": {
-                    "description": "The total number of records available.",
-                    "type": "integer",
-                    "example": 15
+                "user": {
+                    "$ref": "#/definitions/models.AuthResponse"
                 }
             }
         },
-        "models.AuthResponse": {
+        "swagger.ChangePasswordRequest": {
             "type": "object",
             "properties": {
-                "access_token": {
-                    "description": "JWT Access Token used to access protected resources.",
+                "current_password": {
                     "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIs.eyJzdWIilIn0.iTNuOHMObmeRmKU"
+                    "example": "Secret1!"
                 },
-                "created_at": {
-                    "description": "Timestamp when the user was created.",
+                "new_password": {
                     "type": "string",
-                    "example": "2024-09-04T13:37:24.87653+05:00"
-                },
-                "email": {
-                    "description": "Email address of the user; must be a valid email format.",
+                    "example": "NewSecret1!"
+                }
+            }
+        },
+        "swagger.CollaboratorRequest": {
+            "type": "object",
+            "properties": {
+                "role": {
                     "type": "string",
-                    "maxLength": 254,
-                    "minLength": 6,
-                    "example": "john_doe@example.com"
-                },
-                "id": {
-                    "description": "Unique identifier for the user.",
-                    "type": "integer",
-                    "example": 1
+                    "example": "viewer"
                 },
-                "refresh_token": {
-                    "description": "JWT Refresh Token used to obtain a new Access Token when it expires.",
+                "username": {
                     "type": "string",
-                    "example": "eyJhbGciOI6IkpXVCJ9.eyJzdk5EbifQ.4CfEaMw6Ur_fszI"
+                    "example": "jane_doe"
+                }
+            }
+        },
+        "swagger.CollaboratorResponse": {
+            "type": "object",
+            "properties": {
+                "collaborator": {
+                    "$ref": "#/definitions/models.Collaborator"
+                }
+            }
+        },
+        "swagger.CollaboratorRoleRequest": {
+            "type": "object",
+            "properties": {
+                "role": {
+                    "type": "string",
+                    "example": "editor"
+                }
+            }
+        },
+        "swagger.CollaboratorsResponse": {
+            "type": "object",
+            "properties": {
+                "collaborators": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Collaborator"
+                    }
+                }
+            }
+        },
+        "swagger.CollectionFilmItems": {
+            "type": "object",
+            "properties": {
+                "collection": {
+                    "$ref": "#/definitions/models.Collection"
                 },
-                "telegram_id": {
-                    "type": "integer",
-                    "example": 123456789
+                "films": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/swagger.FilmItem"
+                    }
+                }
+            }
+        },
+        "swagger.CollectionFilmResponse": {
+            "type": "object",
+            "properties": {
+                "collection_film": {
+                    "$ref": "#/definitions/models.CollectionFilm"
+                }
+            }
+        },
+        "swagger.CollectionFilmsResponse": {
+            "type": "object",
+            "properties": {
+                "collection_films": {
+                    "$ref": "#/definitions/swagger.CollectionFilmItems"
                 },
-                "username": {
-                    "description": "Username of the user; must be unique and valid.",
-                    "type": "string",
-                    "maxLength": 20,
-                    "minLength": 3,
-                    "example": "john_doe"
+                "metadata": {
+                    "$ref": "#/definitions/filters.Metadata"
                 }
             }
         },
-        "models.Collection": {
+        "swagger.CollectionItem": {
             "type": "object",
             "required": [
                 "name"
             ],
             "properties": {
+                "_permissions": {
+                    "$ref": "#/definitions/models.ResourcePermissions"
+                },
                 "created_at": {
                     "description": "Timestamp when the collection was created.",
                     "type": "string",
@@ -1834,6 +6728,11 @@ const docTemplate = `{
                     "minLength": 3,
                     "example": "My collection"
                 },
+                "role": {
+                    "description": "Role of the user in the collection: owner, editor or viewer; set in lists of collections.",
+                    "type": "string",
+                    "example": "owner"
+                },
                 "total_films": {
                     "description": "Total number of films in the collection.",
                     "type": "integer",
@@ -1851,63 +6750,66 @@ const docTemplate = `{
                 }
             }
         },
-        "models.CollectionFilm": {
+        "swagger.CollectionRequest": {
             "type": "object",
             "properties": {
-                "added_at": {
-                    "description": "Timestamp when the film was added to the collection.",
+                "description": {
                     "type": "string",
-                    "example": "2024-09-04T13:37:24.87653+05:00"
-                },
-                "collection": {
-                    "description": "Identifier of the collection.",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/models.Collection"
-                        }
-                    ]
+                    "example": "This is description"
                 },
-                "film": {
-                    "description": "Identifier of the film.",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/models.Film"
-                        }
-                    ]
+                "is_favorite": {
+                    "type": "boolean",
+                    "example": false
                 },
-                "updated_at": {
-                    "description": "Timestamp when the association was last updated.",
+                "name": {
                     "type": "string",
-                    "example": "2024-09-04T13:37:24.87653+05:00"
+                    "example": "My collection"
                 }
             }
         },
-        "models.CollectionFilms": {
+        "swagger.CollectionResponse": {
             "type": "object",
             "properties": {
-                "collection": {
-                    "description": "Identifier of the collection.",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/models.Collection"
-                        }
-                    ]
+                "_permissions": {
+                    "$ref": "#/definitions/models.ResourcePermissions"
                 },
-                "films": {
-                    "description": "Identifier of the film.",
+                "collection": {
+                    "$ref": "#/definitions/models.Collection"
+                }
+            }
+        },
+        "swagger.CollectionsResponse": {
+            "type": "object",
+            "properties": {
+                "collections": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.Film"
+                        "$ref": "#/definitions/swagger.CollectionItem"
                     }
+                },
+                "metadata": {
+                    "$ref": "#/definitions/filters.Metadata"
                 }
             }
         },
-        "models.Film": {
+        "swagger.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "some kind of error"
+                }
+            }
+        },
+        "swagger.FilmItem": {
             "type": "object",
             "required": [
                 "title"
             ],
             "properties": {
+                "_permissions": {
+                    "$ref": "#/definitions/models.ResourcePermissions"
+                },
                 "comment": {
                     "description": "User's comment of the film; optional, up to 500 characters.",
                     "type": "string",
@@ -2001,278 +6903,361 @@ const docTemplate = `{
                 }
             }
         },
-        "models.User": {
+        "swagger.FilmRequest": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string",
+                    "example": "This is comment"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "This is description"
+                },
+                "genre": {
+                    "type": "string",
+                    "example": "Horror"
+                },
+                "image_url": {
+                    "type": "string",
+                    "example": "http://k4sper1love.kz/images/default.png"
+                },
+                "is_favorite": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "is_viewed": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "rating": {
+                    "type": "number",
+                    "example": 6.7
+                },
+                "review": {
+                    "type": "string",
+                    "example": "This is review."
+                },
+                "title": {
+                    "type": "string",
+                    "example": "My film"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://www.kino.kz/film/689/"
+                },
+                "user_rating": {
+                    "type": "number",
+                    "example": 5.5
+                },
+                "year": {
+                    "type": "integer",
+                    "example": 2001
+                }
+            }
+        },
+        "swagger.FilmResponse": {
+            "type": "object",
+            "properties": {
+                "_permissions": {
+                    "$ref": "#/definitions/models.ResourcePermissions"
+                },
+                "film": {
+                    "$ref": "#/definitions/models.Film"
+                }
+            }
+        },
+        "swagger.FilmsResponse": {
+            "type": "object",
+            "properties": {
+                "films": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/swagger.FilmItem"
+                    }
+                },
+                "metadata": {
+                    "$ref": "#/definitions/filters.Metadata"
+                }
+            }
+        },
+        "swagger.ForgotPasswordRequest": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "description": "Timestamp when the user was created.",
-                    "type": "string",
-                    "example": "2024-09-04T13:37:24.87653+05:00"
-                },
                 "email": {
-                    "description": "Email address of the user; must be a valid email format.",
                     "type": "string",
-                    "maxLength": 254,
-                    "minLength": 6,
                     "example": "john_doe@example.com"
-                },
-                "id": {
-                    "description": "Unique identifier for the user.",
-                    "type": "integer",
-                    "example": 1
-                },
-                "telegram_id": {
-                    "type": "integer",
-                    "example": 123456789
-                },
+                }
+            }
+        },
+        "swagger.GroupMemberRequest": {
+            "type": "object",
+            "properties": {
                 "username": {
-                    "description": "Username of the user; must be unique and valid.",
                     "type": "string",
-                    "maxLength": 20,
-                    "minLength": 3,
-                    "example": "john_doe"
+                    "example": "jane_doe"
                 }
             }
         },
-        "rest.Dependency": {
+        "swagger.GroupMemberResponse": {
             "type": "object",
             "properties": {
-                "response_time": {
-                    "type": "string",
-                    "example": "48ms"
-                },
-                "status": {
-                    "type": "string",
-                    "example": "up"
+                "member": {
+                    "$ref": "#/definitions/models.GroupMember"
                 }
             }
         },
-        "rest.HealthcheckResponse": {
+        "swagger.GroupMembersResponse": {
             "type": "object",
             "properties": {
-                "dependencies": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "$ref": "#/definitions/rest.Dependency"
-                    }
-                },
-                "status": {
-                    "type": "string",
-                    "example": "operational"
+                "group": {
+                    "$ref": "#/definitions/models.Group"
                 },
-                "systemInfo": {
-                    "$ref": "#/definitions/rest.SystemInfo"
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.GroupMember"
+                    }
                 }
             }
         },
-        "rest.SystemInfo": {
+        "swagger.GroupRequest": {
             "type": "object",
             "properties": {
-                "environment": {
-                    "type": "string",
-                    "example": "prod"
-                },
-                "last_checked": {
-                    "type": "string",
-                    "example": "2024-09-24T00:41:20+05:00"
-                },
-                "uptime": {
+                "name": {
                     "type": "string",
-                    "example": "3h 26m 30s"
+                    "example": "Living room"
                 }
             }
         },
-        "swagger.AccessTokenResponse": {
+        "swagger.GroupResponse": {
             "type": "object",
             "properties": {
-                "access_token": {
-                    "type": "string",
-                    "example": "eyJhbGciOI6IkpXVCJ9.eyJzdk5EbifQ.4CfEaMw6Ur_fszI"
+                "group": {
+                    "$ref": "#/definitions/models.Group"
                 }
             }
         },
-        "swagger.AuthResponse": {
+        "swagger.GroupsResponse": {
             "type": "object",
             "properties": {
-                "user": {
-                    "$ref": "#/definitions/models.AuthResponse"
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Group"
+                    }
                 }
             }
         },
-        "swagger.CollectionFilmResponse": {
+        "swagger.IdentitiesResponse": {
             "type": "object",
             "properties": {
-                "collection_film": {
-                    "$ref": "#/definitions/models.CollectionFilm"
+                "identities": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.UserIdentity"
+                    }
                 }
             }
         },
-        "swagger.CollectionFilmsResponse": {
+        "swagger.LoginRequest": {
             "type": "object",
             "properties": {
-                "collection_films": {
-                    "$ref": "#/definitions/models.CollectionFilms"
+                "password": {
+                    "type": "string",
+                    "example": "Secret1!"
                 },
-                "metadata": {
-                    "$ref": "#/definitions/filters.Metadata"
+                "username": {
+                    "type": "string",
+                    "example": "k4sper1love"
                 }
             }
         },
-        "swagger.CollectionRequest": {
+        "swagger.MessageResponse": {
             "type": "object",
             "properties": {
-                "description": {
+                "message": {
                     "type": "string",
-                    "example": "This is description"
+                    "example": "some kind of success message"
+                }
+            }
+        },
+        "swagger.OIDCLoginResponse": {
+            "type": "object",
+            "properties": {
+                "authorization_url": {
+                    "type": "string",
+                    "example": "https://accounts.example.com/authorize?client_id=watchlist\u0026state=..."
                 },
-                "is_favorite": {
-                    "type": "boolean",
-                    "example": false
+                "state": {
+                    "type": "string",
+                    "example": "q3l1F0yJb9pWkJc2m1c6dXbTqH8Nw0R4ZbQf2sYkAeI"
+                }
+            }
+        },
+        "swagger.PasswordIdentityRequest": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "example": "Secret1!"
                 },
-                "name": {
+                "username": {
                     "type": "string",
-                    "example": "My collection"
+                    "example": "k4sper1love"
                 }
             }
         },
-        "swagger.CollectionResponse": {
+        "swagger.PermissionCodesRequest": {
             "type": "object",
             "properties": {
-                "collection": {
-                    "$ref": "#/definitions/models.Collection"
+                "codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "film:create",
+                        "collection:create"
+                    ]
                 }
             }
         },
-        "swagger.CollectionsResponse": {
+        "swagger.PermissionsResponse": {
             "type": "object",
             "properties": {
-                "collections": {
+                "permissions": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.Collection"
-                    }
-                },
-                "metadata": {
-                    "$ref": "#/definitions/filters.Metadata"
+                        "type": "string"
+                    },
+                    "example": [
+                        "film:create",
+                        "collection:create",
+                        "film:1:read"
+                    ]
                 }
             }
         },
-        "swagger.ErrorResponse": {
+        "swagger.RecoveryCodesResponse": {
             "type": "object",
             "properties": {
-                "error": {
-                    "type": "string",
-                    "example": "some kind of error"
+                "recovery_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "k7m2p-x9qaz",
+                        "4hd8w-ne3rt"
+                    ]
                 }
             }
         },
-        "swagger.FilmRequest": {
+        "swagger.RegisterRequest": {
             "type": "object",
             "properties": {
-                "comment": {
-                    "type": "string",
-                    "example": "This is comment"
-                },
-                "description": {
-                    "type": "string",
-                    "example": "This is description"
-                },
-                "genre": {
-                    "type": "string",
-                    "example": "Horror"
-                },
-                "image_url": {
+                "password": {
                     "type": "string",
-                    "example": "http://k4sper1love.kz/images/default.png"
-                },
-                "is_favorite": {
-                    "type": "boolean",
-                    "example": false
-                },
-                "is_viewed": {
-                    "type": "boolean",
-                    "example": true
-                },
-                "rating": {
-                    "type": "number",
-                    "example": 6.7
+                    "example": "Secret1!"
                 },
-                "review": {
+                "username": {
                     "type": "string",
-                    "example": "This is review."
-                },
-                "title": {
+                    "example": "k4sper1love"
+                }
+            }
+        },
+        "swagger.ResetPasswordRequest": {
+            "type": "object",
+            "properties": {
+                "new_password": {
                     "type": "string",
-                    "example": "My film"
+                    "example": "NewSecret1!"
                 },
-                "url": {
+                "token": {
                     "type": "string",
-                    "example": "https://www.kino.kz/film/689/"
-                },
-                "user_rating": {
-                    "type": "number",
-                    "example": 5.5
-                },
-                "year": {
-                    "type": "integer",
-                    "example": 2001
+                    "example": "q0N7x2Hk5e9rVb3LmA8sYw"
                 }
             }
         },
-        "swagger.FilmResponse": {
+        "swagger.ResourcePermissionsResponse": {
             "type": "object",
             "properties": {
-                "film": {
-                    "$ref": "#/definitions/models.Film"
+                "resource_permissions": {
+                    "$ref": "#/definitions/models.ResourcePermissions"
                 }
             }
         },
-        "swagger.FilmsResponse": {
+        "swagger.SessionsResponse": {
             "type": "object",
             "properties": {
-                "films": {
+                "sessions": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.Film"
+                        "$ref": "#/definitions/models.Session"
                     }
+                }
+            }
+        },
+        "swagger.ShareLinkResponse": {
+            "type": "object",
+            "properties": {
+                "share_link": {
+                    "$ref": "#/definitions/models.ShareLink"
+                }
+            }
+        },
+        "swagger.SharedCollectionResponse": {
+            "type": "object",
+            "properties": {
+                "collection": {
+                    "$ref": "#/definitions/models.SharedCollection"
                 },
                 "metadata": {
                     "$ref": "#/definitions/filters.Metadata"
                 }
             }
         },
-        "swagger.LoginRequest": {
+        "swagger.TwoFactorCodeRequest": {
             "type": "object",
             "properties": {
-                "password": {
+                "code": {
                     "type": "string",
-                    "example": "Secret1!"
+                    "example": "123456"
+                }
+            }
+        },
+        "swagger.TwoFactorDisableRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "123456"
                 },
-                "username": {
+                "password": {
                     "type": "string",
-                    "example": "k4sper1love"
+                    "example": "Secret1!"
                 }
             }
         },
-        "swagger.MessageResponse": {
+        "swagger.TwoFactorEnrollmentResponse": {
             "type": "object",
             "properties": {
-                "message": {
-                    "type": "string",
-                    "example": "some kind of success message"
+                "two_factor": {
+                    "$ref": "#/definitions/models.TwoFactorEnrollment"
                 }
             }
         },
-        "swagger.RegisterRequest": {
+        "swagger.TwoFactorLoginRequest": {
             "type": "object",
             "properties": {
-                "password": {
+                "code": {
                     "type": "string",
-                    "example": "Secret1!"
+                    "example": "123456"
                 },
-                "username": {
+                "two_factor_token": {
                     "type": "string",
-                    "example": "k4sper1love"
+                    "example": "q0N7x2Hk5e9rVb3LmA8sYw"
                 }
             }
         },
@@ -2296,9 +7281,38 @@ const docTemplate = `{
                     "$ref": "#/definitions/models.User"
                 }
             }
+        },
+        "swagger.UsersResponse": {
+            "type": "object",
+            "properties": {
+                "metadata": {
+                    "$ref": "#/definitions/filters.Metadata"
+                },
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.User"
+                    }
+                }
+            }
+        },
+        "swagger.VerifyEmailRequest": {
+            "type": "object",
+            "properties": {
+                "token": {
+                    "type": "string",
+                    "example": "q0N7x2Hk5e9rVb3LmA8sYw"
+                }
+            }
         }
     },
     "securityDefinitions": {
+        "APIKeyAuth": {
+            "description": "Personal API key created at /user/api-keys. Example: 'X-API-Key: {key}'",
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
+        },
         "JWTAuth": {
             "description": "JWT Authorization header using the Bearer scheme. Example: 'Authorization: Bearer {token}'",
             "type": "apiKey",
@@ -18,6 +18,11 @@ import (
 // @name Authorization
 // @description JWT Authorization header using the Bearer scheme. Example: 'Authorization: Bearer {token}'
 
+// @securityDefinitions.apiKey APIKeyAuth
+// @in header
+// @name X-API-Key
+// @description Personal API key created at /user/api-keys. Example: 'X-API-Key: {key}'
+
 func main() {
 	if err := watchlist.Run(os.Args); err != nil {
 		slog.Error("application terminated due to an error")
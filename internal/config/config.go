@@ -9,15 +9,47 @@ import (
 	"github.com/peterbourgon/ff/v4"
 	"log/slog"
 	"os"
+	"time"
 )
 
 var (
-	Env            string // Environment (local, dev, prod).
-	Migrations     string // Path to migration files.
-	Dsn            string // PostgreSQL Data Source Name for database connection.
-	Port           int    // Port for the API server.
-	JWTSecret      string // Secret password for creating JWT tokens.
-	TelegramSecret string // Secret password for checking verification token
+	Env                  string        // Environment (local, dev, prod).
+	Migrations           string        // Path to migration files.
+	Dsn                  string        // PostgreSQL Data Source Name for database connection.
+	Port                 int           // Port for the API server.
+	JWTSecret            string        // Secret password for creating JWT tokens.
+	JWTAlgorithm         string        // Algorithm for signing JWT tokens (HS256, RS256, EdDSA).
+	JWTKeys              string        // Path to the folder with PEM keys for RS256 and EdDSA.
+	JWTSigningKey        string        // ID of the key used to sign new JWT tokens.
+	TelegramSecret       string        // Secret password for checking verification token
+	TelegramTokenMaxAge  time.Duration // Maximum age of a verification token from Telegram.
+	TrustProxy           bool          // Trust proxy headers when determining the client IP address.
+	Mailer               string        // Mailer used to deliver emails (log, smtp, memory).
+	SMTPHost             string        // Host of the SMTP server.
+	SMTPPort             int           // Port of the SMTP server.
+	SMTPUsername         string        // Username for the SMTP server.
+	SMTPPassword         string        // Password for the SMTP server.
+	MailFrom             string        // Sender address of outgoing emails.
+	LoginMaxFailures     int           // Failed logins per username before it is locked.
+	LoginMaxFailuresIP   int           // Failed logins per client IP before it is locked.
+	LoginLockout         time.Duration // Duration of the first lockout; it doubles with each further failure.
+	LoginMaxLockout      time.Duration // Maximum lockout duration.
+	RateLimiter          string        // Storage of rate limits (memory, postgres, off).
+	RateLimitAuth        int           // Requests per minute to auth endpoints.
+	RateLimitFilms       int           // Requests per minute to film endpoints.
+	RateLimitCollections int           // Requests per minute to collection endpoints.
+	RateLimitUpload      int           // Requests per minute to the upload endpoint.
+	RateLimitDefault     int           // Requests per minute to other API endpoints.
+	RateLimitIP          int           // Requests per minute from a client IP to all rate limited endpoints, checked before authentication.
+	OIDCIssuer           string        // Issuer URL of the OpenID Connect provider; login is disabled if empty.
+	OIDCClientID         string        // Client ID registered at the OpenID Connect provider.
+	OIDCClientSecret     string        // Client secret registered at the OpenID Connect provider.
+	OIDCRedirectURL      string        // Redirect URL registered at the OpenID Connect provider.
+	OIDCScopes           string        // Space-separated scopes requested in addition to "openid".
+	AdminUsername        string        // Username of the user granted the admin role at startup.
+	DeletionGracePeriod  time.Duration // Time a deleted account can be restored before it is purged.
+	PermissionCacheSize  int           // Maximum number of users whose permissions are cached; 0 disables the cache.
+	PermissionCacheTTL   time.Duration // Time the permissions of a user are cached.
 )
 
 // ParseFlags parses command-line flags and sets the corresponding global configuration variables.
@@ -29,6 +61,27 @@ var (
 //   - -m, --migrations: Path to the folder containing database migration files.
 //   - -s, --secret: The secret password for creating JWT tokens.
 //   - -t, --telegram: The secret password for checking verification token
+//   - --telegram-token-max-age: The maximum age of a verification token from Telegram (default: 2m).
+//   - --jwt-algorithm: The algorithm for signing JWT tokens (HS256, RS256, EdDSA) (default: HS256).
+//   - --jwt-keys: Path to the folder with PEM keys named <kid>.pem; all of them are accepted for verification.
+//   - --jwt-signing-key: The ID of the key used to sign new tokens (default: the last private key by name).
+//   - --trust-proxy: Trust X-Real-IP and X-Forwarded-For headers for client IP addresses.
+//   - --mailer: The mailer used to deliver emails (log, smtp, memory) (default: log).
+//   - --smtp-host, --smtp-port, --smtp-username, --smtp-password: The SMTP server settings.
+//   - --mail-from: The sender address of outgoing emails.
+//   - --login-max-failures, --login-max-failures-ip: Failed logins per username and per client IP before lockout (default: 5, 20).
+//   - --login-lockout, --login-max-lockout: The first and the maximum lockout duration (default: 1m, 1h).
+//   - --rate-limiter: The storage of rate limits (memory, postgres, off) (default: memory).
+//   - --rate-limit-auth, --rate-limit-films, --rate-limit-collections, --rate-limit-upload, --rate-limit-default:
+//     Requests per minute for each route group; 0 disables the limit (default: 20, 120, 120, 10, 120).
+//   - --rate-limit-ip: Requests per minute from a client IP to all route groups, checked before authentication;
+//     0 disables the limit (default: 600).
+//   - --oidc-issuer, --oidc-client-id, --oidc-client-secret, --oidc-redirect-url: The OpenID Connect provider and client.
+//   - --oidc-scopes: The scopes requested in addition to "openid" (default: "email profile").
+//   - --admin-username: The username of an existing user granted the admin role at startup.
+//   - --deletion-grace-period: The time a deleted account can be restored by logging in before it is purged (default: 336h).
+//   - --permission-cache-size, --permission-cache-ttl: The maximum number of users whose permissions are cached
+//     and the time they are cached; 0 disables the cache (default: 10000, 1m).
 func ParseFlags(args []string) error {
 	// Create a new flag set for the API configuration
 	flagSet := ff.NewFlagSet("API Configuration")
@@ -39,6 +92,37 @@ func ParseFlags(args []string) error {
 	flagSet.StringVar(&Migrations, 'm', "migrations", "", "Path to migration files folder. If not provided, migrations do not apply")
 	flagSet.StringVar(&JWTSecret, 's', "secret", "secretPass", "Secret password for creating JWT tokens")
 	flagSet.StringVar(&TelegramSecret, 't', "telegram", "secretPassq", "Secret password for checking verification token")
+	flagSet.DurationVar(&TelegramTokenMaxAge, 0, "telegram-token-max-age", 2*time.Minute, "Maximum age of a verification token from Telegram")
+	flagSet.StringVar(&JWTAlgorithm, 0, "jwt-algorithm", "HS256", "Algorithm for signing JWT tokens (HS256|RS256|EdDSA)")
+	flagSet.StringVar(&JWTKeys, 0, "jwt-keys", "", "Path to the folder with PEM keys named <kid>.pem for RS256 and EdDSA")
+	flagSet.StringVar(&JWTSigningKey, 0, "jwt-signing-key", "", "ID of the key used to sign new JWT tokens. If not provided, the last private key by name is used")
+	flagSet.BoolVar(&TrustProxy, 0, "trust-proxy", "Trust X-Real-IP and X-Forwarded-For headers for client IP addresses")
+	flagSet.StringVar(&Mailer, 0, "mailer", "log", "Mailer used to deliver emails (log|smtp|memory)")
+	flagSet.StringVar(&SMTPHost, 0, "smtp-host", "localhost", "SMTP server host")
+	flagSet.IntVar(&SMTPPort, 0, "smtp-port", 1025, "SMTP server port")
+	flagSet.StringVar(&SMTPUsername, 0, "smtp-username", "", "SMTP server username")
+	flagSet.StringVar(&SMTPPassword, 0, "smtp-password", "", "SMTP server password")
+	flagSet.StringVar(&MailFrom, 0, "mail-from", "Watchlist <no-reply@watchlist.local>", "Sender address of outgoing emails")
+	flagSet.IntVar(&LoginMaxFailures, 0, "login-max-failures", 5, "Failed logins per username before it is locked")
+	flagSet.IntVar(&LoginMaxFailuresIP, 0, "login-max-failures-ip", 20, "Failed logins per client IP before it is locked")
+	flagSet.DurationVar(&LoginLockout, 0, "login-lockout", time.Minute, "Duration of the first login lockout; doubles with each further failure")
+	flagSet.DurationVar(&LoginMaxLockout, 0, "login-max-lockout", time.Hour, "Maximum login lockout duration")
+	flagSet.StringVar(&RateLimiter, 0, "rate-limiter", "memory", "Storage of rate limits (memory|postgres|off)")
+	flagSet.IntVar(&RateLimitAuth, 0, "rate-limit-auth", 20, "Requests per minute to auth endpoints; 0 disables the limit")
+	flagSet.IntVar(&RateLimitFilms, 0, "rate-limit-films", 120, "Requests per minute to film endpoints; 0 disables the limit")
+	flagSet.IntVar(&RateLimitCollections, 0, "rate-limit-collections", 120, "Requests per minute to collection endpoints; 0 disables the limit")
+	flagSet.IntVar(&RateLimitUpload, 0, "rate-limit-upload", 10, "Requests per minute to the upload endpoint; 0 disables the limit")
+	flagSet.IntVar(&RateLimitDefault, 0, "rate-limit-default", 120, "Requests per minute to other API endpoints; 0 disables the limit")
+	flagSet.IntVar(&RateLimitIP, 0, "rate-limit-ip", 600, "Requests per minute from a client IP to all rate limited endpoints, checked before authentication; 0 disables the limit")
+	flagSet.StringVar(&OIDCIssuer, 0, "oidc-issuer", "", "Issuer URL of the OpenID Connect provider. If not provided, OpenID Connect login is disabled")
+	flagSet.StringVar(&OIDCClientID, 0, "oidc-client-id", "", "Client ID registered at the OpenID Connect provider")
+	flagSet.StringVar(&OIDCClientSecret, 0, "oidc-client-secret", "", "Client secret registered at the OpenID Connect provider")
+	flagSet.StringVar(&OIDCRedirectURL, 0, "oidc-redirect-url", "", "Redirect URL registered at the OpenID Connect provider")
+	flagSet.StringVar(&OIDCScopes, 0, "oidc-scopes", "email profile", "Scopes requested from the OpenID Connect provider in addition to openid")
+	flagSet.StringVar(&AdminUsername, 0, "admin-username", "", "Username of an existing user granted the admin role at startup")
+	flagSet.DurationVar(&DeletionGracePeriod, 0, "deletion-grace-period", 14*24*time.Hour, "Time a deleted account can be restored by logging in before it is purged")
+	flagSet.IntVar(&PermissionCacheSize, 0, "permission-cache-size", 10000, "Maximum number of users whose permissions are cached; 0 disables the cache")
+	flagSet.DurationVar(&PermissionCacheTTL, 0, "permission-cache-ttl", time.Minute, "Time the permissions of a user are cached")
 
 	// Load environment variables from .env file
 	if err := godotenv.Load(); err != nil {
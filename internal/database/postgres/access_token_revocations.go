@@ -0,0 +1,68 @@
+package postgres
+
+import (
+	"context"
+	"github.com/k4sper1love/watchlist-api/pkg/models"
+	"time"
+)
+
+// SaveAccessTokenRevocation stores a revocation and sets its revocation time.
+func SaveAccessTokenRevocation(r *models.AccessTokenRevocation) error {
+	query := `
+		INSERT INTO access_token_revocations (jti, session_id, user_id, expires_at)
+		VALUES (NULLIF($1, ''), NULLIF($2, '')::uuid, NULLIF($3, 0), $4)
+		RETURNING revoked_at
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	return GetDB().QueryRowContext(ctx, query, r.TokenID, r.SessionID, r.UserID, r.ExpiresAt).Scan(&r.RevokedAt)
+}
+
+// GetAccessTokenRevocations retrieves unexpired revocations made since the given time, oldest first.
+func GetAccessTokenRevocations(since time.Time) ([]*models.AccessTokenRevocation, error) {
+	query := `
+		SELECT COALESCE(jti, ''), COALESCE(session_id::text, ''), COALESCE(user_id, 0), revoked_at, expires_at
+		FROM access_token_revocations
+		WHERE revoked_at >= $1 AND expires_at > NOW()
+		ORDER BY revoked_at
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := GetDB().QueryContext(ctx, query, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var revocations []*models.AccessTokenRevocation
+	for rows.Next() {
+		var r models.AccessTokenRevocation
+
+		if err := rows.Scan(&r.TokenID, &r.SessionID, &r.UserID, &r.RevokedAt, &r.ExpiresAt); err != nil {
+			return nil, err
+		}
+
+		revocations = append(revocations, &r)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return revocations, nil
+}
+
+// DeleteExpiredAccessTokenRevocations removes revocations whose affected tokens have all expired.
+func DeleteExpiredAccessTokenRevocations() error {
+	query := `DELETE FROM access_token_revocations WHERE expires_at <= NOW()`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	_, err := GetDB().ExecContext(ctx, query)
+	return err
+}
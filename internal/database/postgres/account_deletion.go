@@ -0,0 +1,98 @@
+package postgres
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// ScheduleUserDeletion marks a user as deleted and returns the time of the deletion.
+// Deleting an already deleted user keeps the original time.
+func ScheduleUserDeletion(userID int) (time.Time, error) {
+	query := `
+		UPDATE users
+		SET deletion_scheduled_at = COALESCE(deletion_scheduled_at, NOW()), version = version + 1
+		WHERE id = $1
+		RETURNING deletion_scheduled_at
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var scheduledAt time.Time
+	if err := GetDB().QueryRowContext(ctx, query, userID).Scan(&scheduledAt); err != nil {
+		return time.Time{}, err
+	}
+
+	return scheduledAt, nil
+}
+
+// RestoreUser cancels the deletion of a user deleted after the given time.
+// It reports whether the user was restored; users that are not deleted are left unchanged.
+func RestoreUser(userID int, deletedAfter time.Time) (bool, error) {
+	query := `
+		UPDATE users
+		SET deletion_scheduled_at = NULL, version = version + 1
+		WHERE id = $1 AND deletion_scheduled_at > $2
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := GetDB().ExecContext(ctx, query, userID, deletedAfter)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+// GetUsersDeletedBefore retrieves the IDs of users deleted at or before the given time.
+func GetUsersDeletedBefore(before time.Time) ([]int, error) {
+	query := `SELECT id FROM users WHERE deletion_scheduled_at <= $1 ORDER BY deletion_scheduled_at`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := GetDB().QueryContext(ctx, query, before)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err := rows.Close(); err != nil {
+			slog.Error("failed to close rows", slog.Any("error", err))
+		}
+	}()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// IsUserDeleted checks whether a user is deleted and waiting to be purged.
+func IsUserDeleted(userID int) (bool, error) {
+	query := `SELECT deletion_scheduled_at IS NOT NULL FROM users WHERE id = $1`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var deleted bool
+	if err := GetDB().QueryRowContext(ctx, query, userID).Scan(&deleted); err != nil {
+		return false, err
+	}
+
+	return deleted, nil
+}
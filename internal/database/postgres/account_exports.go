@@ -0,0 +1,138 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"github.com/k4sper1love/watchlist-api/pkg/models"
+	"time"
+)
+
+// AddAccountExport starts a new export for a user, replacing the previous exports of the user.
+// It returns sql.ErrNoRows if an export of the user is still being generated.
+// Exports pending for over an hour are considered interrupted and replaced as well.
+func AddAccountExport(userID int) (*models.AccountExport, error) {
+	query := `
+		WITH replaced AS (
+			DELETE FROM account_exports
+			WHERE user_id = $1 AND (status <> 'pending' OR created_at < NOW() - INTERVAL '1 hour')
+			RETURNING id
+		)
+		INSERT INTO account_exports (user_id)
+		SELECT $1
+		WHERE NOT EXISTS (
+			SELECT 1 FROM account_exports
+			WHERE user_id = $1 AND status = 'pending' AND created_at >= NOW() - INTERVAL '1 hour'
+		)
+		ON CONFLICT DO NOTHING
+		RETURNING id, status, created_at
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	e := models.AccountExport{UserID: userID}
+	if err := GetDB().QueryRowContext(ctx, query, userID).Scan(&e.ID, &e.Status, &e.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
+
+// CompleteAccountExport stores the generated archive of an export until it expires.
+func CompleteAccountExport(id string, archive []byte, expiresAt time.Time) error {
+	query := `
+		UPDATE account_exports
+		SET status = 'ready', archive = $2, size = $3, completed_at = NOW(), expires_at = $4
+		WHERE id = $1 AND status = 'pending'
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	result, err := GetDB().ExecContext(ctx, query, id, archive, len(archive), expiresAt)
+	if err != nil {
+		return err
+	}
+
+	return requireAffected(result)
+}
+
+// FailAccountExport marks an export as failed with the reason.
+func FailAccountExport(id string, reason string) error {
+	query := `
+		UPDATE account_exports
+		SET status = 'failed', error = $2, completed_at = NOW()
+		WHERE id = $1 AND status = 'pending'
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := GetDB().ExecContext(ctx, query, id, reason)
+	if err != nil {
+		return err
+	}
+
+	return requireAffected(result)
+}
+
+// GetAccountExport retrieves an export of a user without its archive.
+// It returns sql.ErrNoRows if the export does not exist, belongs to another user or has expired.
+func GetAccountExport(userID int, id string) (*models.AccountExport, error) {
+	query := `
+		SELECT id, status, error, size, created_at, completed_at, expires_at
+		FROM account_exports
+		WHERE id = $1 AND user_id = $2 AND (expires_at IS NULL OR expires_at > NOW())
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	e := models.AccountExport{UserID: userID}
+	var rawError sql.NullString
+	var rawCompletedAt, rawExpiresAt sql.NullTime
+
+	if err := GetDB().QueryRowContext(ctx, query, id, userID).Scan(&e.ID, &e.Status, &rawError, &e.Size, &e.CreatedAt, &rawCompletedAt, &rawExpiresAt); err != nil {
+		return nil, err
+	}
+
+	e.Error = extractString(rawError)
+	e.CompletedAt = extractTime(rawCompletedAt)
+	e.ExpiresAt = extractTime(rawExpiresAt)
+	return &e, nil
+}
+
+// GetAccountExportArchive retrieves the archive of a ready export of a user.
+// It returns sql.ErrNoRows if the export does not exist, belongs to another user, is not ready or has expired.
+func GetAccountExportArchive(userID int, id string) ([]byte, error) {
+	query := `
+		SELECT archive
+		FROM account_exports
+		WHERE id = $1 AND user_id = $2 AND status = 'ready' AND expires_at > NOW()
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var archive []byte
+	if err := GetDB().QueryRowContext(ctx, query, id, userID).Scan(&archive); err != nil {
+		return nil, err
+	}
+
+	return archive, nil
+}
+
+// DeleteExpiredAccountExports removes the exports whose archives have expired.
+func DeleteExpiredAccountExports() (int64, error) {
+	query := `DELETE FROM account_exports WHERE expires_at <= NOW()`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := GetDB().ExecContext(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
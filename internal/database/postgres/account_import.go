@@ -0,0 +1,50 @@
+package postgres
+
+import (
+	"context"
+	"github.com/k4sper1love/watchlist-api/pkg/models"
+	"time"
+)
+
+// RestoreFilm inserts a film from an account archive with a new ID, keeping its timestamps.
+func RestoreFilm(ex Executor, f *models.Film) error {
+	query := `
+		INSERT INTO films (user_id, is_favorite, title, year, genre, description, rating, image_url, comment, is_viewed, user_rating, review, url, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+		RETURNING id
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	return ex.QueryRowContext(ctx, query, f.UserID, f.IsFavorite, f.Title, f.Year, f.Genre, f.Description, f.Rating, f.ImageURL,
+		f.Comment, f.IsViewed, f.UserRating, f.Review, f.URL, f.CreatedAt, f.UpdatedAt).Scan(&f.ID)
+}
+
+// RestoreCollection inserts a collection from an account archive with a new ID, keeping its timestamps.
+func RestoreCollection(ex Executor, c *models.Collection) error {
+	query := `
+		INSERT INTO collections (user_id, is_favorite, name, description, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	return ex.QueryRowContext(ctx, query, c.UserID, c.IsFavorite, c.Name, c.Description, c.CreatedAt, c.UpdatedAt).Scan(&c.ID)
+}
+
+// RestoreCollectionFilm adds a film to a collection from an account archive, keeping the timestamps.
+func RestoreCollectionFilm(ex Executor, l *models.CollectionFilmLink) error {
+	query := `
+		INSERT INTO collection_films (collection_id, film_id, added_at, updated_at)
+		VALUES ($1, $2, $3, $4)
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	_, err := ex.ExecContext(ctx, query, l.CollectionID, l.FilmID, l.AddedAt, l.UpdatedAt)
+	return err
+}
@@ -0,0 +1,199 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"github.com/k4sper1love/watchlist-api/pkg/models"
+	"github.com/lib/pq"
+	"log/slog"
+	"time"
+)
+
+// ownerTables maps the resource types with owners to the tables that store them.
+var ownerTables = map[string]string{
+	"film":       "films",
+	"collection": "collections",
+	"group":      "groups",
+}
+
+// memberConditions are the conditions under which members of a group may access the resource r.id as the user $2:
+// films and collections in the library of the group, and the group itself.
+var memberConditions = map[string]string{
+	"film": `EXISTS (
+		SELECT 1
+		FROM group_resources g
+		JOIN group_members m ON m.group_id = g.group_id
+		WHERE g.resource_type = 'film' AND g.resource_id = r.id AND m.user_id = $2
+	)`,
+	"collection": `EXISTS (
+		SELECT 1
+		FROM group_resources g
+		JOIN group_members m ON m.group_id = g.group_id
+		WHERE g.resource_type = 'collection' AND g.resource_id = r.id AND m.user_id = $2
+	)`,
+	"group": `EXISTS (SELECT 1 FROM group_members m WHERE m.group_id = r.id AND m.user_id = $2)`,
+}
+
+// memberActions are the actions group members may perform on the resources of the group.
+// Deleting and sharing stays with the owners.
+var memberActions = map[string]Permissions{
+	"film":       {"read", "update"},
+	"collection": {"read", "update"},
+	"group":      {"read"},
+}
+
+// resourceActions are the actions that can be performed on each resource type, in the order they are reported.
+var resourceActions = map[string][]string{
+	"film":       {"read", "update", "delete", "share"},
+	"collection": {"read", "update", "delete", "share"},
+	"group":      {"read", "update", "delete"},
+}
+
+// HasPermission checks whether the user has an account-wide permission code, such as film:create.
+// The codes of the user are cached in process.
+func HasPermission(userID int, code string) (bool, error) {
+	permissions, err := getCachedAccountPermissions(userID)
+	if err != nil {
+		return false, err
+	}
+
+	return permissions.Include(code), nil
+}
+
+// HasAccess checks whether the user may perform the action on a film, collection or group.
+// Owners may perform every action; other users need an ACL entry for the action or membership in a group the resource belongs to.
+func HasAccess(userID int, resourceType string, resourceID int, action string) (bool, error) {
+	table, ok := ownerTables[resourceType]
+	if !ok {
+		return false, nil
+	}
+
+	query := `
+		SELECT EXISTS (SELECT 1 FROM ` + table + ` WHERE id = r.id AND user_id = $2)
+		    OR EXISTS (
+				SELECT 1
+				FROM acl_entries
+				WHERE resource_type = $3 AND resource_id = r.id AND user_id = $2 AND action = $4
+			)
+	`
+
+	if memberActions[resourceType].Include(action) {
+		query += ` OR ` + memberConditions[resourceType]
+	}
+
+	query += ` FROM (SELECT $1::INTEGER AS id) AS r`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var allowed bool
+	err := GetDB().QueryRowContext(ctx, query, resourceID, userID, resourceType, action).Scan(&allowed)
+	return allowed, err
+}
+
+// GetAllowedActions retrieves the actions the user may perform on a film, collection or group with a single query.
+// It returns no actions for resources that do not exist, so that their existence is not revealed.
+func GetAllowedActions(userID int, resourceType string, resourceID int) ([]string, error) {
+	actions, err := GetAllowedActionsBatch(userID, resourceType, []int{resourceID})
+	if err != nil {
+		return nil, err
+	}
+
+	return actions[resourceID], nil
+}
+
+// GetAllowedActionsBatch retrieves the actions the user may perform on each of the films, collections or groups
+// with a single query, so that lists do not need a query per item. Every ID is in the result, and resources
+// that do not exist have no actions.
+func GetAllowedActionsBatch(userID int, resourceType string, resourceIDs []int) (map[int][]string, error) {
+	actions := make(map[int][]string, len(resourceIDs))
+	for _, id := range resourceIDs {
+		actions[id] = []string{}
+	}
+
+	table, ok := ownerTables[resourceType]
+	if !ok || len(resourceIDs) == 0 {
+		return actions, nil
+	}
+
+	query := `
+		SELECT r.id, a.action
+		FROM unnest($1::INTEGER[]) AS r (id)
+		CROSS JOIN unnest($4::TEXT[]) WITH ORDINALITY AS a (action, position)
+		WHERE EXISTS (SELECT 1 FROM ` + table + ` WHERE id = r.id AND user_id = $2)
+		   OR EXISTS (
+				SELECT 1
+				FROM acl_entries
+				WHERE resource_type = $3 AND resource_id = r.id AND user_id = $2 AND action = a.action
+			)
+		   OR (a.action = ANY($5) AND ` + memberConditions[resourceType] + `)
+		ORDER BY r.id, a.position
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := GetDB().QueryContext(ctx, query, pq.Array(resourceIDs), userID, resourceType, pq.Array(resourceActions[resourceType]), pq.Array([]string(memberActions[resourceType])))
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err := rows.Close(); err != nil {
+			slog.Error("failed to close rows", slog.Any("error", err))
+		}
+	}()
+
+	for rows.Next() {
+		var id int
+		var action string
+		if err := rows.Scan(&id, &action); err != nil {
+			return nil, err
+		}
+		actions[id] = append(actions[id], action)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return actions, nil
+}
+
+// AddACLEntry grants a user an action on a film or collection.
+// It returns sql.ErrNoRows if the resource does not exist, so that entries never outlive their resource.
+func AddACLEntry(entry *models.ACLEntry) error {
+	table, ok := ownerTables[entry.ResourceType]
+	if !ok {
+		return sql.ErrNoRows
+	}
+
+	// The no-op update returns the existing entry, so that nothing is returned only if the resource does not exist.
+	query := `
+		INSERT INTO acl_entries (resource_type, resource_id, user_id, action)
+		SELECT $1, id, $3, $4
+		FROM ` + table + `
+		WHERE id = $2
+		ON CONFLICT (resource_type, resource_id, user_id, action) DO UPDATE SET action = EXCLUDED.action
+		RETURNING created_at
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	return GetDB().QueryRowContext(ctx, query, entry.ResourceType, entry.ResourceID, entry.UserID, entry.Action).Scan(&entry.CreatedAt)
+}
+
+// DeleteACLEntry revokes an action on a film or collection from a user.
+func DeleteACLEntry(entry *models.ACLEntry) error {
+	query := `
+		DELETE FROM acl_entries
+		WHERE resource_type = $1 AND resource_id = $2 AND user_id = $3 AND action = $4
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	_, err := GetDB().ExecContext(ctx, query, entry.ResourceType, entry.ResourceID, entry.UserID, entry.Action)
+	return err
+}
@@ -0,0 +1,174 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/k4sper1love/watchlist-api/pkg/filters"
+	"github.com/k4sper1love/watchlist-api/pkg/models"
+	"log/slog"
+	"time"
+)
+
+// SearchUsers retrieves users whose username or email contains the search string, with pagination.
+func SearchUsers(search string, f filters.Filters) ([]*models.User, filters.Metadata, error) {
+	query := fmt.Sprintf(`
+		SELECT COUNT(*) OVER(), id, telegram_id, username, email, email_verified_at, suspended_at, deletion_scheduled_at, created_at, version
+		FROM users
+		WHERE ($1 = '' OR username ILIKE '%%' || $1 || '%%' OR email ILIKE '%%' || $1 || '%%')
+		ORDER BY %s %s, id
+		LIMIT $2 OFFSET $3
+	`, f.SortColumn(), f.SortDirection())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := GetDB().QueryContext(ctx, query, search, f.Limit(), f.Offset())
+	if err != nil {
+		return nil, filters.Metadata{}, err
+	}
+
+	defer func() {
+		if err := rows.Close(); err != nil {
+			slog.Error("failed to close rows", slog.Any("error", err))
+		}
+	}()
+
+	var users []*models.User
+	totalRecords := 0
+
+	for rows.Next() {
+		var u models.User
+		var rawTelegramID sql.NullInt64
+		var rawEmail sql.NullString
+		var rawEmailVerifiedAt sql.NullTime
+		var rawSuspendedAt sql.NullTime
+		var rawDeletionScheduledAt sql.NullTime
+
+		if err := rows.Scan(&totalRecords, &u.ID, &rawTelegramID, &u.Username, &rawEmail, &rawEmailVerifiedAt, &rawSuspendedAt, &rawDeletionScheduledAt, &u.CreatedAt, &u.Version); err != nil {
+			return nil, filters.Metadata{}, err
+		}
+
+		u.TelegramID = extractInt(rawTelegramID)
+		u.Email = extractString(rawEmail)
+		u.EmailVerifiedAt = extractTime(rawEmailVerifiedAt)
+		u.SuspendedAt = extractTime(rawSuspendedAt)
+		u.DeletionScheduledAt = extractTime(rawDeletionScheduledAt)
+		users = append(users, &u)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, filters.Metadata{}, err
+	}
+
+	metadata := filters.CalculateMetadata(totalRecords, f.Page, f.PageSize)
+	return users, metadata, nil
+}
+
+// SetUserSuspended suspends or unsuspends a user. Suspending an already suspended user keeps the original time.
+func SetUserSuspended(userID int, suspended bool) error {
+	query := `
+		UPDATE users
+		SET suspended_at = CASE WHEN $2 THEN COALESCE(suspended_at, NOW()) END, version = version + 1
+		WHERE id = $1
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := GetDB().ExecContext(ctx, query, userID, suspended)
+	if err != nil {
+		return err
+	}
+
+	return requireAffected(result)
+}
+
+// IsUserSuspended checks whether a user is suspended.
+func IsUserSuspended(userID int) (bool, error) {
+	query := `SELECT suspended_at IS NOT NULL FROM users WHERE id = $1`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var suspended bool
+	if err := GetDB().QueryRowContext(ctx, query, userID).Scan(&suspended); err != nil {
+		return false, err
+	}
+
+	return suspended, nil
+}
+
+// AddAdminAction records an action performed by an admin.
+func AddAdminAction(a *models.AdminAction) error {
+	query := `
+		INSERT INTO admin_actions (admin_id, action, target_user_id, details)
+		VALUES ($1, $2, NULLIF($3, 0), $4)
+		RETURNING id, created_at
+	`
+
+	details, err := json.Marshal(a.Details)
+	if err != nil {
+		return err
+	}
+	if a.Details == nil {
+		details = []byte("{}")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	return GetDB().QueryRowContext(ctx, query, a.AdminID, a.Action, a.TargetUserID, details).Scan(&a.ID, &a.CreatedAt)
+}
+
+// GetAdminActions retrieves recorded admin actions, newest first, with pagination.
+// If targetUserID is 0, actions on all users are returned.
+func GetAdminActions(targetUserID int, f filters.Filters) ([]*models.AdminAction, filters.Metadata, error) {
+	query := `
+		SELECT COUNT(*) OVER(), id, admin_id, action, COALESCE(target_user_id, 0), details, created_at
+		FROM admin_actions
+		WHERE ($1 = 0 OR target_user_id = $1)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := GetDB().QueryContext(ctx, query, targetUserID, f.Limit(), f.Offset())
+	if err != nil {
+		return nil, filters.Metadata{}, err
+	}
+
+	defer func() {
+		if err := rows.Close(); err != nil {
+			slog.Error("failed to close rows", slog.Any("error", err))
+		}
+	}()
+
+	var actions []*models.AdminAction
+	totalRecords := 0
+
+	for rows.Next() {
+		var a models.AdminAction
+		var rawDetails []byte
+
+		if err := rows.Scan(&totalRecords, &a.ID, &a.AdminID, &a.Action, &a.TargetUserID, &rawDetails, &a.CreatedAt); err != nil {
+			return nil, filters.Metadata{}, err
+		}
+
+		if err := json.Unmarshal(rawDetails, &a.Details); err != nil {
+			return nil, filters.Metadata{}, err
+		}
+
+		actions = append(actions, &a)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, filters.Metadata{}, err
+	}
+
+	metadata := filters.CalculateMetadata(totalRecords, f.Page, f.PageSize)
+	return actions, metadata, nil
+}
@@ -0,0 +1,122 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"github.com/k4sper1love/watchlist-api/pkg/models"
+	"time"
+)
+
+// AddAPIKey stores a new API key of a user. Only the hash of the key is stored.
+func AddAPIKey(k *models.APIKey) error {
+	query := `
+		INSERT INTO api_keys (user_id, name, key, prefix, scope, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	return GetDB().QueryRowContext(ctx, query, k.UserID, k.Name, hashToken(k.Key), k.Prefix, k.Scope, k.ExpiresAt).
+		Scan(&k.ID, &k.CreatedAt)
+}
+
+// GetAPIKeys retrieves the API keys of a user that are not revoked, newest first.
+func GetAPIKeys(userID int) ([]*models.APIKey, error) {
+	query := `
+		SELECT id, user_id, name, prefix, scope, expires_at, last_used_at, created_at
+		FROM api_keys
+		WHERE user_id = $1 AND revoked_at IS NULL
+		ORDER BY created_at DESC
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := GetDB().QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []*models.APIKey
+	for rows.Next() {
+		k, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// GetAPIKeyByKey retrieves an active API key by its secret value.
+// It returns sql.ErrNoRows if the key is unknown, revoked or expired, or if its owner is suspended or deleted.
+func GetAPIKeyByKey(key string) (*models.APIKey, error) {
+	query := `
+		SELECT id, user_id, name, prefix, scope, expires_at, last_used_at, created_at
+		FROM api_keys
+		WHERE key = $1 AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > NOW())
+		  AND NOT EXISTS (SELECT 1 FROM users WHERE users.id = api_keys.user_id AND (users.suspended_at IS NOT NULL OR users.deletion_scheduled_at IS NOT NULL))
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	return scanAPIKey(GetDB().QueryRowContext(ctx, query, hashToken(key)))
+}
+
+// TouchAPIKey records the use of an API key. The time is updated at most once a minute to limit writes.
+func TouchAPIKey(id int) error {
+	query := `
+		UPDATE api_keys SET last_used_at = NOW()
+		WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < NOW() - INTERVAL '1 minute')
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	_, err := GetDB().ExecContext(ctx, query, id)
+	return err
+}
+
+// RevokeAPIKey revokes an API key of a user.
+// It returns sql.ErrNoRows if the user has no active API key with the given ID.
+func RevokeAPIKey(userID, id int) error {
+	query := `UPDATE api_keys SET revoked_at = NOW() WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := GetDB().ExecContext(ctx, query, id, userID)
+	if err != nil {
+		return err
+	}
+
+	return requireAffected(result)
+}
+
+// rowScanner is implemented by sql.Row and sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanAPIKey scans an API key selected without its secret value.
+func scanAPIKey(row rowScanner) (*models.APIKey, error) {
+	var k models.APIKey
+	var rawExpiresAt, rawLastUsedAt sql.NullTime
+
+	if err := row.Scan(&k.ID, &k.UserID, &k.Name, &k.Prefix, &k.Scope, &rawExpiresAt, &rawLastUsedAt, &k.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	k.ExpiresAt = extractTime(rawExpiresAt)
+	k.LastUsedAt = extractTime(rawLastUsedAt)
+	return &k, nil
+}
@@ -0,0 +1,93 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/k4sper1love/watchlist-api/pkg/filters"
+	"github.com/k4sper1love/watchlist-api/pkg/models"
+	"log/slog"
+	"time"
+)
+
+// AddAuditEvent appends an event to the audit log.
+func AddAuditEvent(e *models.AuditEvent) error {
+	query := `
+		INSERT INTO audit_events (event, user_id, actor_id, client_ip, user_agent, request_id, details)
+		VALUES ($1, NULLIF($2, 0), NULLIF($3, 0), $4, $5, $6, $7)
+		RETURNING id, created_at
+	`
+
+	details := []byte("{}")
+	if e.Details != nil {
+		var err error
+		if details, err = json.Marshal(e.Details); err != nil {
+			return err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	return GetDB().QueryRowContext(ctx, query, e.Event, e.UserID, e.ActorID, e.ClientIP, e.UserAgent, e.RequestID, details).
+		Scan(&e.ID, &e.CreatedAt)
+}
+
+// GetAuditEvents retrieves audit events matching the input, newest first, with pagination.
+func GetAuditEvents(input *models.AuditEventsQueryInput) ([]*models.AuditEvent, filters.Metadata, error) {
+	query := `
+		SELECT COUNT(*) OVER(), id, event, COALESCE(user_id, 0), COALESCE(actor_id, 0),
+		       client_ip, user_agent, request_id, details, created_at
+		FROM audit_events
+		WHERE ($1 = 0 OR user_id = $1)
+		  AND ($2 = 0 OR actor_id = $2)
+		  AND ($3 = '' OR event = $3)
+		  AND ($4 = '' OR client_ip = $4)
+		  AND ($5 = '' OR request_id = $5)
+		  AND ($6::timestamptz IS NULL OR created_at >= $6)
+		  AND ($7::timestamptz IS NULL OR created_at < $7)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $8 OFFSET $9
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := GetDB().QueryContext(ctx, query, input.UserID, input.ActorID, input.Event, input.ClientIP, input.RequestID,
+		input.From, input.To, input.Filters.Limit(), input.Filters.Offset())
+	if err != nil {
+		return nil, filters.Metadata{}, err
+	}
+
+	defer func() {
+		if err := rows.Close(); err != nil {
+			slog.Error("failed to close rows", slog.Any("error", err))
+		}
+	}()
+
+	var events []*models.AuditEvent
+	totalRecords := 0
+
+	for rows.Next() {
+		var e models.AuditEvent
+		var rawDetails []byte
+
+		err := rows.Scan(&totalRecords, &e.ID, &e.Event, &e.UserID, &e.ActorID,
+			&e.ClientIP, &e.UserAgent, &e.RequestID, &rawDetails, &e.CreatedAt)
+		if err != nil {
+			return nil, filters.Metadata{}, err
+		}
+
+		if err := json.Unmarshal(rawDetails, &e.Details); err != nil {
+			return nil, filters.Metadata{}, err
+		}
+
+		events = append(events, &e)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, filters.Metadata{}, err
+	}
+
+	metadata := filters.CalculateMetadata(totalRecords, input.Filters.Page, input.Filters.PageSize)
+	return events, metadata, nil
+}
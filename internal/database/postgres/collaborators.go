@@ -0,0 +1,141 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"github.com/k4sper1love/watchlist-api/pkg/models"
+	"github.com/lib/pq"
+	"log/slog"
+	"time"
+)
+
+// collaboratorRole is the SQL expression for the role of a collaborator derived from their ACL entries of a collection.
+const collaboratorRole = `CASE WHEN bool_or(a.action = 'update') THEN 'editor' ELSE 'viewer' END`
+
+// GetCollectionCollaborators retrieves the users a collection is shared with.
+func GetCollectionCollaborators(collectionID int) ([]*models.Collaborator, error) {
+	query := `
+		SELECT u.id, u.username, ` + collaboratorRole + `, MIN(a.created_at)
+		FROM acl_entries a
+		JOIN users u ON u.id = a.user_id
+		WHERE a.resource_type = 'collection' AND a.resource_id = $1
+		GROUP BY u.id
+		HAVING bool_or(a.action = 'read')
+		ORDER BY MIN(a.created_at), u.id
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := GetDB().QueryContext(ctx, query, collectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err := rows.Close(); err != nil {
+			slog.Error("failed to close rows", slog.Any("error", err))
+		}
+	}()
+
+	collaborators := []*models.Collaborator{}
+	for rows.Next() {
+		var c models.Collaborator
+		if err := rows.Scan(&c.UserID, &c.Username, &c.Role, &c.CreatedAt); err != nil {
+			return nil, err
+		}
+		collaborators = append(collaborators, &c)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return collaborators, nil
+}
+
+// GetCollectionCollaborator retrieves a user a collection is shared with.
+// It returns sql.ErrNoRows if the collection is not shared with the user.
+func GetCollectionCollaborator(collectionID, userID int) (*models.Collaborator, error) {
+	query := `
+		SELECT u.id, u.username, ` + collaboratorRole + `, MIN(a.created_at)
+		FROM acl_entries a
+		JOIN users u ON u.id = a.user_id
+		WHERE a.resource_type = 'collection' AND a.resource_id = $1 AND a.user_id = $2
+		GROUP BY u.id
+		HAVING bool_or(a.action = 'read')
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var c models.Collaborator
+	if err := GetDB().QueryRowContext(ctx, query, collectionID, userID).Scan(&c.UserID, &c.Username, &c.Role, &c.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// SetCollectionCollaborator replaces the actions a user may perform on a collection they do not own.
+// The entries are replaced in a transaction, and the date the collection was shared is kept.
+func SetCollectionCollaborator(collectionID, userID int, actions []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var sharedAt sql.NullTime
+	query := `
+		WITH deleted AS (
+			DELETE FROM acl_entries
+			WHERE resource_type = 'collection' AND resource_id = $1 AND user_id = $2
+			RETURNING created_at
+		)
+		SELECT MIN(created_at) FROM deleted
+	`
+
+	if err := tx.QueryRowContext(ctx, query, collectionID, userID).Scan(&sharedAt); err != nil {
+		return err
+	}
+
+	query = `
+		INSERT INTO acl_entries (resource_type, resource_id, user_id, action, created_at)
+		SELECT 'collection', c.id, $2, action, COALESCE($4, NOW())
+		FROM collections c, unnest($3::TEXT[]) AS action
+		WHERE c.id = $1
+	`
+
+	result, err := tx.ExecContext(ctx, query, collectionID, userID, pq.Array(actions), sharedAt)
+	if err != nil {
+		return err
+	}
+
+	if err := requireAffected(result); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// DeleteCollectionCollaborator removes the access of a user to a collection they do not own.
+func DeleteCollectionCollaborator(collectionID, userID int) error {
+	query := `
+		DELETE FROM acl_entries
+		WHERE resource_type = 'collection' AND resource_id = $1 AND user_id = $2
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := GetDB().ExecContext(ctx, query, collectionID, userID)
+	if err != nil {
+		return err
+	}
+
+	return requireAffected(result)
+}
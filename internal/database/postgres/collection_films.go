@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/k4sper1love/watchlist-api/pkg/filters"
 	"github.com/k4sper1love/watchlist-api/pkg/models"
+	"log/slog"
 	"time"
 )
 
@@ -143,6 +144,42 @@ func DeleteCollectionFilm(c *models.CollectionFilm) error {
 	return err
 }
 
+// GetUserCollectionFilmLinks retrieves the films in all collections of a user by their identifiers.
+func GetUserCollectionFilmLinks(userID int) ([]models.CollectionFilmLink, error) {
+	query := `
+		SELECT cf.collection_id, cf.film_id, cf.added_at, cf.updated_at
+		FROM collection_films cf
+		JOIN collections c ON c.id = cf.collection_id
+		WHERE c.user_id = $1
+		ORDER BY cf.collection_id, cf.added_at
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := GetDB().QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err := rows.Close(); err != nil {
+			slog.Error("failed to close rows", slog.Any("error", err))
+		}
+	}()
+
+	var links []models.CollectionFilmLink
+	for rows.Next() {
+		var l models.CollectionFilmLink
+		if err := rows.Scan(&l.CollectionID, &l.FilmID, &l.AddedAt, &l.UpdatedAt); err != nil {
+			return nil, err
+		}
+		links = append(links, l)
+	}
+
+	return links, rows.Err()
+}
+
 func buildCollectionFilmsQuery(collectionID int, input *models.FilmsQueryInput) (string, []interface{}) {
 	query := `
         SELECT COUNT(*) OVER(), f.*
@@ -44,13 +44,26 @@ func GetCollection(collectionID int) (*models.Collection, error) {
 }
 
 // GetCollections retrieves collections for a user with optional filtering and pagination.
-func GetCollections(userID int, name string, filmID int, excludeFilmID int, f filters.Filters) ([]*models.Collection, filters.Metadata, error) {
+// With includeShared, the collections shared with the user are included as well. Each collection has the role of the user in it.
+func GetCollections(userID int, name string, filmID int, excludeFilmID int, includeShared bool, f filters.Filters) ([]*models.Collection, filters.Metadata, error) {
 	query := fmt.Sprintf(
 		`
-          SELECT COUNT(*) OVER(), c.id, c.user_id, c.is_favorite, c.name, c.description, COUNT(cf.film_id) AS total_films, c.created_at, c.updated_at
+          SELECT COUNT(*) OVER(), c.id, c.user_id, c.is_favorite, c.name, c.description, COUNT(cf.film_id) AS total_films,
+                 CASE
+                     WHEN c.user_id = $1 THEN 'owner'
+                     WHEN EXISTS (
+                         SELECT 1 FROM acl_entries a
+                         WHERE a.resource_type = 'collection' AND a.resource_id = c.id AND a.user_id = $1 AND a.action = 'update'
+                     ) THEN 'editor'
+                     ELSE 'viewer'
+                 END AS role,
+                 c.created_at, c.updated_at
           FROM collections c
           LEFT JOIN collection_films cf ON c.id = cf.collection_id
-          WHERE c.user_id = $1
+          WHERE (c.user_id = $1 OR ($7 AND EXISTS (
+                SELECT 1 FROM acl_entries a
+                WHERE a.resource_type = 'collection' AND a.resource_id = c.id AND a.user_id = $1 AND a.action = 'read'
+            )))
         	AND (LOWER(c.name) ILIKE '%%' || LOWER($2) || '%%' OR $2 = '') 
             AND (cf.film_id = $3 OR $3 = -1)
             AND c.id NOT IN (
@@ -67,7 +80,7 @@ func GetCollections(userID int, name string, filmID int, excludeFilmID int, f fi
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
-	rows, err := GetDB().QueryContext(ctx, query, userID, name, filmID, excludeFilmID, f.Limit(), f.Offset())
+	rows, err := GetDB().QueryContext(ctx, query, userID, name, filmID, excludeFilmID, f.Limit(), f.Offset(), includeShared)
 	if err != nil {
 		return nil, filters.Metadata{}, err
 	}
@@ -83,7 +96,7 @@ func GetCollections(userID int, name string, filmID int, excludeFilmID int, f fi
 
 	for rows.Next() {
 		var c models.Collection
-		if err := rows.Scan(&totalRecords, &c.ID, &c.UserID, &c.IsFavorite, &c.Name, &c.Description, &c.TotalFilms, &c.CreatedAt, &c.UpdatedAt); err != nil {
+		if err := rows.Scan(&totalRecords, &c.ID, &c.UserID, &c.IsFavorite, &c.Name, &c.Description, &c.TotalFilms, &c.Role, &c.CreatedAt, &c.UpdatedAt); err != nil {
 			return nil, filters.Metadata{}, err
 		}
 		collections = append(collections, &c)
@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"context"
+	"time"
+)
+
+// SaveEmailVerificationToken stores a token that verifies the given email of a user.
+// Previously issued tokens of the user that have not been used are invalidated.
+func SaveEmailVerificationToken(verificationToken string, userID int, email string, expiresAt time.Time) error {
+	invalidateQuery := `
+		UPDATE email_verification_tokens SET expires_at = NOW()
+		WHERE user_id = $1 AND used_at IS NULL AND expires_at > NOW()
+	`
+
+	insertQuery := `INSERT INTO email_verification_tokens(token, user_id, email, expires_at) VALUES ($1, $2, $3, $4)`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := GetDB().BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, invalidateQuery, userID); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, insertQuery, hashToken(verificationToken), userID, email, expiresAt); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// VerifyEmail consumes an email verification token and marks the email of its user as verified.
+// It returns sql.ErrNoRows if the token is unknown, expired, already used,
+// or was issued for an email the user no longer has.
+func VerifyEmail(verificationToken string) error {
+	consumeQuery := `
+		UPDATE email_verification_tokens SET used_at = NOW()
+		WHERE token = $1 AND used_at IS NULL AND expires_at > NOW()
+		RETURNING user_id, email
+	`
+
+	verifyQuery := `
+		UPDATE users SET email_verified_at = NOW()
+		WHERE id = $1 AND email = $2
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := GetDB().BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var userID int
+	var email string
+	if err := tx.QueryRowContext(ctx, consumeQuery, hashToken(verificationToken)).Scan(&userID, &email); err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, verifyQuery, userID, email)
+	if err != nil {
+		return err
+	}
+
+	if err := requireAffected(result); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
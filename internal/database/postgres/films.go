@@ -34,7 +34,7 @@ func GetFilm(id int) (*models.Film, error) {
 	return &f, nil
 }
 
-// GetFilms retrieves films for a specific user based on filters and pagination.
+// GetFilms retrieves films for a specific user or group based on filters and pagination.
 func GetFilms(userID int, input *models.FilmsQueryInput) ([]models.Film, filters.Metadata, error) {
 	query, args := buildFilmsQuery(userID, input)
 
@@ -93,11 +93,18 @@ func DeleteFilm(id int) error {
 }
 
 // buildFilmsQuery constructs the SQL query and arguments for retrieving films.
+// The films of the user are retrieved, or the library of the group if the input has a group ID.
 func buildFilmsQuery(userID int, input *models.FilmsQueryInput) (string, []interface{}) {
+	owner, ownerID := "f.user_id = $1", userID
+	if input.GroupID > 0 {
+		owner = "f.id IN (SELECT resource_id FROM group_resources WHERE resource_type = 'film' AND group_id = $1)"
+		ownerID = input.GroupID
+	}
+
 	query := `
         SELECT COUNT(*) OVER(), f.*
         FROM films f
-        WHERE f.user_id = $1
+        WHERE ` + owner + `
           AND (LOWER(f.title) ILIKE '%%' || LOWER($2) || '%%' OR $2 = '') 
           AND f.id NOT IN (
               SELECT cf.film_id
@@ -106,7 +113,7 @@ func buildFilmsQuery(userID int, input *models.FilmsQueryInput) (string, []inter
           )
     `
 
-	args := []interface{}{userID, input.Title, input.ExcludeCollection}
+	args := []interface{}{ownerID, input.Title, input.ExcludeCollection}
 
 	return addFilmsFiltersToQuery(query, args, input)
 }
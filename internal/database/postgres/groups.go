@@ -0,0 +1,243 @@
+package postgres
+
+import (
+	"context"
+	"github.com/k4sper1love/watchlist-api/pkg/models"
+	"log/slog"
+	"time"
+)
+
+// AddGroup inserts a new group and adds its creator as the owner.
+func AddGroup(g *models.Group) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO groups (user_id, name)
+		VALUES ($1, $2)
+		RETURNING id, created_at, updated_at
+	`
+
+	if err := tx.QueryRowContext(ctx, query, g.UserID, g.Name).Scan(&g.ID, &g.CreatedAt, &g.UpdatedAt); err != nil {
+		return err
+	}
+
+	query = `INSERT INTO group_members (group_id, user_id, role) VALUES ($1, $2, 'owner')`
+
+	if _, err := tx.ExecContext(ctx, query, g.ID, g.UserID); err != nil {
+		return err
+	}
+
+	g.Role = models.GroupRoleOwner
+	return tx.Commit()
+}
+
+// GetGroup retrieves a group by its ID.
+func GetGroup(groupID int) (*models.Group, error) {
+	query := `SELECT id, user_id, name, created_at, updated_at FROM groups WHERE id = $1`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var g models.Group
+	if err := GetDB().QueryRowContext(ctx, query, groupID).Scan(&g.ID, &g.UserID, &g.Name, &g.CreatedAt, &g.UpdatedAt); err != nil {
+		return nil, err
+	}
+
+	return &g, nil
+}
+
+// GetUserGroups retrieves the groups a user is a member of, with the role of the user in each group.
+func GetUserGroups(userID int) ([]*models.Group, error) {
+	query := `
+		SELECT g.id, g.user_id, g.name, m.role, g.created_at, g.updated_at
+		FROM groups g
+		JOIN group_members m ON m.group_id = g.id
+		WHERE m.user_id = $1
+		ORDER BY g.created_at, g.id
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := GetDB().QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err := rows.Close(); err != nil {
+			slog.Error("failed to close rows", slog.Any("error", err))
+		}
+	}()
+
+	groups := []*models.Group{}
+	for rows.Next() {
+		var g models.Group
+		if err := rows.Scan(&g.ID, &g.UserID, &g.Name, &g.Role, &g.CreatedAt, &g.UpdatedAt); err != nil {
+			return nil, err
+		}
+		groups = append(groups, &g)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+// DeleteGroup removes a group with its members. The films and collections of its library stay with the users who added them.
+func DeleteGroup(groupID int) error {
+	query := `DELETE FROM groups WHERE id = $1`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := GetDB().ExecContext(ctx, query, groupID)
+	if err != nil {
+		return err
+	}
+
+	return requireAffected(result)
+}
+
+// GetGroupMembers retrieves the members of a group.
+func GetGroupMembers(groupID int) ([]*models.GroupMember, error) {
+	query := `
+		SELECT u.id, u.username, m.role, m.joined_at
+		FROM group_members m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.group_id = $1
+		ORDER BY m.joined_at, u.id
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := GetDB().QueryContext(ctx, query, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err := rows.Close(); err != nil {
+			slog.Error("failed to close rows", slog.Any("error", err))
+		}
+	}()
+
+	members := []*models.GroupMember{}
+	for rows.Next() {
+		var m models.GroupMember
+		if err := rows.Scan(&m.UserID, &m.Username, &m.Role, &m.JoinedAt); err != nil {
+			return nil, err
+		}
+		members = append(members, &m)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+// AddGroupMember adds a user to a group as a member.
+func AddGroupMember(groupID, userID int) (*models.GroupMember, error) {
+	query := `
+		WITH member AS (
+			INSERT INTO group_members (group_id, user_id)
+			VALUES ($1, $2)
+			RETURNING user_id, role, joined_at
+		)
+		SELECT member.user_id, users.username, member.role, member.joined_at
+		FROM member
+		JOIN users ON users.id = member.user_id
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var m models.GroupMember
+	if err := GetDB().QueryRowContext(ctx, query, groupID, userID).Scan(&m.UserID, &m.Username, &m.Role, &m.JoinedAt); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// DeleteGroupMember removes a user from a group. The films and collections the user added leave the library of the group.
+func DeleteGroupMember(groupID, userID int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `DELETE FROM group_members WHERE group_id = $1 AND user_id = $2`
+
+	result, err := tx.ExecContext(ctx, query, groupID, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := requireAffected(result); err != nil {
+		return err
+	}
+
+	query = `
+		DELETE FROM group_resources g
+		WHERE g.group_id = $1
+		  AND (
+			(g.resource_type = 'film' AND EXISTS (SELECT 1 FROM films f WHERE f.id = g.resource_id AND f.user_id = $2))
+			OR (g.resource_type = 'collection' AND EXISTS (SELECT 1 FROM collections c WHERE c.id = g.resource_id AND c.user_id = $2))
+		  )
+	`
+
+	if _, err := tx.ExecContext(ctx, query, groupID, userID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// AddGroupResource adds a film or collection to the library of a group. A resource can be in the library of one group only.
+func AddGroupResource(groupID int, resourceType string, resourceID int) error {
+	query := `
+		INSERT INTO group_resources (resource_type, resource_id, group_id)
+		VALUES ($1, $2, $3)
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	_, err := GetDB().ExecContext(ctx, query, resourceType, resourceID, groupID)
+	return err
+}
+
+// DeleteGroupResource removes a film or collection from the library of a group.
+func DeleteGroupResource(groupID int, resourceType string, resourceID int) error {
+	query := `
+		DELETE FROM group_resources
+		WHERE resource_type = $1 AND resource_id = $2 AND group_id = $3
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := GetDB().ExecContext(ctx, query, resourceType, resourceID, groupID)
+	if err != nil {
+		return err
+	}
+
+	return requireAffected(result)
+}
@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"database/sql"
 	"fmt"
 	"strconv"
 	"strings"
@@ -59,3 +60,17 @@ func parseRangeOrExactInt(value string) (int, int, int, error) {
 	}
 	return year, year, 0, nil
 }
+
+// requireAffected returns sql.ErrNoRows if the statement did not affect any rows.
+func requireAffected(result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
@@ -0,0 +1,183 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"github.com/k4sper1love/watchlist-api/pkg/models"
+	"log/slog"
+	"strconv"
+	"time"
+)
+
+// GetUserIdentities retrieves the login methods linked to a user.
+func GetUserIdentities(userID int) ([]*models.UserIdentity, error) {
+	query := `
+		SELECT id, user_id, provider, issuer, subject, email, created_at
+		FROM user_identities
+		WHERE user_id = $1
+		ORDER BY id
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := GetDB().QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err := rows.Close(); err != nil {
+			slog.Error("failed to close rows", slog.Any("error", err))
+		}
+	}()
+
+	var identities []*models.UserIdentity
+	for rows.Next() {
+		var i models.UserIdentity
+		var rawEmail sql.NullString
+
+		if err := rows.Scan(&i.ID, &i.UserID, &i.Provider, &i.Issuer, &i.Subject, &rawEmail, &i.CreatedAt); err != nil {
+			return nil, err
+		}
+
+		i.Email = extractString(rawEmail)
+		identities = append(identities, &i)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return identities, nil
+}
+
+// LinkTelegramIdentity sets the Telegram ID of a user without one and adds its Telegram identity.
+// It returns sql.ErrNoRows if the user already has a Telegram ID.
+func LinkTelegramIdentity(userID, telegramID int) error {
+	query := `UPDATE users SET telegram_id = $2, version = version + 1 WHERE id = $1 AND telegram_id IS NULL`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := GetDB().BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, query, userID, telegramID)
+	if err != nil {
+		return err
+	}
+
+	if err := requireAffected(result); err != nil {
+		return err
+	}
+
+	if err := insertUserIdentity(ctx, tx, telegramIdentity(userID, telegramID)); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// LinkPasswordIdentity sets the username and hashed password of a user without a password and adds its password identity.
+// It returns sql.ErrNoRows if the user already has a password.
+func LinkPasswordIdentity(userID int, username, hashedPassword string) error {
+	query := `UPDATE users SET username = $2, password = $3, version = version + 1 WHERE id = $1 AND password IS NULL`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := GetDB().BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, query, userID, username, hashedPassword)
+	if err != nil {
+		return err
+	}
+
+	if err := requireAffected(result); err != nil {
+		return err
+	}
+
+	if err := insertUserIdentity(ctx, tx, &models.UserIdentity{UserID: userID, Provider: models.IdentityProviderPassword}); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// DeleteUserIdentity removes a login method of a user, clearing the password or Telegram ID it stands for.
+// The user row is locked so that concurrent requests cannot remove the last identity;
+// it returns sql.ErrNoRows if the identity does not exist or is the only one left.
+func DeleteUserIdentity(userID, identityID int) (*models.UserIdentity, error) {
+	lockQuery := `SELECT id FROM users WHERE id = $1 FOR UPDATE`
+
+	deleteQuery := `
+		DELETE FROM user_identities
+		WHERE id = $2 AND user_id = $1
+		  AND (SELECT count(*) FROM user_identities WHERE user_id = $1) > 1
+		RETURNING id, user_id, provider, issuer, subject, created_at
+	`
+
+	passwordQuery := `UPDATE users SET password = NULL, version = version + 1 WHERE id = $1`
+	telegramQuery := `UPDATE users SET telegram_id = NULL, version = version + 1 WHERE id = $1`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := GetDB().BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var id int
+	if err := tx.QueryRowContext(ctx, lockQuery, userID).Scan(&id); err != nil {
+		return nil, err
+	}
+
+	var i models.UserIdentity
+	err = tx.QueryRowContext(ctx, deleteQuery, userID, identityID).
+		Scan(&i.ID, &i.UserID, &i.Provider, &i.Issuer, &i.Subject, &i.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	switch i.Provider {
+	case models.IdentityProviderPassword:
+		_, err = tx.ExecContext(ctx, passwordQuery, userID)
+	case models.IdentityProviderTelegram:
+		_, err = tx.ExecContext(ctx, telegramQuery, userID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return &i, nil
+}
+
+// insertUserIdentity adds a login method of a user within a transaction.
+func insertUserIdentity(ctx context.Context, tx *sql.Tx, i *models.UserIdentity) error {
+	query := `
+		INSERT INTO user_identities (user_id, provider, issuer, subject, email)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''))
+	`
+
+	_, err := tx.ExecContext(ctx, query, i.UserID, i.Provider, i.Issuer, i.Subject, i.Email)
+	return err
+}
+
+// telegramIdentity returns the identity of a Telegram account linked to a user.
+func telegramIdentity(userID, telegramID int) *models.UserIdentity {
+	return &models.UserIdentity{UserID: userID, Provider: models.IdentityProviderTelegram, Subject: strconv.Itoa(telegramID)}
+}
@@ -0,0 +1,101 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// Scopes of failed login tracking.
+const (
+	LoginScopeUsername = "username"
+	LoginScopeIP       = "ip"
+)
+
+// GetLoginLockout returns the time until which a key is locked.
+// It returns the zero time if the key is not locked.
+func GetLoginLockout(scope, key string) (time.Time, error) {
+	query := `
+		SELECT locked_until FROM login_failures
+		WHERE scope = $1 AND key = $2 AND locked_until > NOW()
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var lockedUntil time.Time
+
+	err := GetDB().QueryRowContext(ctx, query, scope, key).Scan(&lockedUntil)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return time.Time{}, err
+	}
+
+	return lockedUntil, nil
+}
+
+// RecordLoginFailure increments the number of failed logins of a key and returns the new number.
+// Failures older than the window are forgotten.
+func RecordLoginFailure(scope, key string, window time.Duration) (int, error) {
+	query := `
+		INSERT INTO login_failures (scope, key, failures, last_failure_at)
+		VALUES ($1, $2, 1, NOW())
+		ON CONFLICT (scope, key) DO UPDATE
+		SET failures = CASE
+		        WHEN login_failures.last_failure_at < NOW() - make_interval(secs => $3) THEN 1
+		        ELSE login_failures.failures + 1
+		    END,
+		    last_failure_at = NOW()
+		RETURNING failures
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var failures int
+	if err := GetDB().QueryRowContext(ctx, query, scope, key, window.Seconds()).Scan(&failures); err != nil {
+		return 0, err
+	}
+
+	return failures, nil
+}
+
+// LockLogin locks a key until the given time and records the lockout event.
+func LockLogin(scope, key string, failures int, lockedUntil time.Time) error {
+	updateQuery := `UPDATE login_failures SET locked_until = $3 WHERE scope = $1 AND key = $2`
+
+	insertQuery := `
+		INSERT INTO login_lockouts (scope, key, failures, locked_until)
+		VALUES ($1, $2, $3, $4)
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := GetDB().BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, updateQuery, scope, key, lockedUntil); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, insertQuery, scope, key, failures, lockedUntil); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ResetLoginFailures forgets the failed logins of a key.
+func ResetLoginFailures(scope, key string) error {
+	query := `DELETE FROM login_failures WHERE scope = $1 AND key = $2`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	_, err := GetDB().ExecContext(ctx, query, scope, key)
+	return err
+}
@@ -0,0 +1,106 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"github.com/k4sper1love/watchlist-api/pkg/models"
+	"time"
+)
+
+// SaveOIDCState stores the state of a started OpenID Connect login with its nonce and PKCE verifier.
+// Expired states of abandoned logins are deleted at the same time.
+func SaveOIDCState(state, nonce, codeVerifier string, expiresAt time.Time) error {
+	deleteQuery := `DELETE FROM oidc_states WHERE expires_at <= NOW()`
+
+	insertQuery := `
+		INSERT INTO oidc_states (state, nonce, code_verifier, expires_at)
+		VALUES ($1, $2, $3, $4)
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	if _, err := GetDB().ExecContext(ctx, deleteQuery); err != nil {
+		return err
+	}
+
+	_, err := GetDB().ExecContext(ctx, insertQuery, hashToken(state), nonce, codeVerifier, expiresAt)
+	return err
+}
+
+// ConsumeOIDCState deletes the state of a login and returns its nonce and PKCE verifier.
+// It returns sql.ErrNoRows if the state is unknown, expired or already used.
+func ConsumeOIDCState(state string) (string, string, error) {
+	query := `
+		DELETE FROM oidc_states
+		WHERE state = $1 AND expires_at > NOW()
+		RETURNING nonce, code_verifier
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var nonce, codeVerifier string
+	if err := GetDB().QueryRowContext(ctx, query, hashToken(state)).Scan(&nonce, &codeVerifier); err != nil {
+		return "", "", err
+	}
+
+	return nonce, codeVerifier, nil
+}
+
+// GetOIDCIdentityUserID retrieves the ID of the user linked to an OpenID Connect identity.
+func GetOIDCIdentityUserID(issuer, subject string) (int, error) {
+	query := `SELECT user_id FROM user_identities WHERE provider = $1 AND issuer = $2 AND subject = $3`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var userID int
+	if err := GetDB().QueryRowContext(ctx, query, models.IdentityProviderOIDC, issuer, subject).Scan(&userID); err != nil {
+		return 0, err
+	}
+
+	return userID, nil
+}
+
+// AddUserByOIDCIdentity inserts a new user with a username and an optional verified email,
+// and links the OpenID Connect identity to it.
+func AddUserByOIDCIdentity(c *models.Credentials, issuer, subject string) (*models.User, error) {
+	userQuery := `
+		INSERT INTO users (username, email, email_verified_at)
+		VALUES ($1, NULLIF($2::text, ''), CASE WHEN $2::text = '' THEN NULL ELSE NOW() END)
+		RETURNING id, username, email, email_verified_at, created_at, version
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := GetDB().BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var u models.User
+	var rawEmail sql.NullString
+	var rawEmailVerifiedAt sql.NullTime
+
+	err = tx.QueryRowContext(ctx, userQuery, c.Username, c.Email).
+		Scan(&u.ID, &u.Username, &rawEmail, &rawEmailVerifiedAt, &u.CreatedAt, &u.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	identity := &models.UserIdentity{UserID: u.ID, Provider: models.IdentityProviderOIDC, Issuer: issuer, Subject: subject, Email: c.Email}
+	if err := insertUserIdentity(ctx, tx, identity); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	u.Email = extractString(rawEmail)
+	u.EmailVerifiedAt = extractTime(rawEmailVerifiedAt)
+	return &u, nil
+}
@@ -0,0 +1,56 @@
+package postgres
+
+import (
+	"context"
+	"time"
+)
+
+// SavePasswordResetToken stores a password reset token for a user.
+// Previously issued tokens of the user that have not been used are invalidated.
+func SavePasswordResetToken(resetToken string, userID int, expiresAt time.Time) error {
+	invalidateQuery := `
+		UPDATE password_reset_tokens SET expires_at = NOW()
+		WHERE user_id = $1 AND used_at IS NULL AND expires_at > NOW()
+	`
+
+	insertQuery := `INSERT INTO password_reset_tokens(token, user_id, expires_at) VALUES ($1, $2, $3)`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := GetDB().BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, invalidateQuery, userID); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, insertQuery, hashToken(resetToken), userID, expiresAt); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ConsumePasswordResetToken marks a password reset token as used and returns the ID of its user.
+// It returns sql.ErrNoRows if the token is unknown, expired or already used.
+func ConsumePasswordResetToken(resetToken string) (int, error) {
+	query := `
+		UPDATE password_reset_tokens SET used_at = NOW()
+		WHERE token = $1 AND used_at IS NULL AND expires_at > NOW()
+		RETURNING user_id
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var userID int
+	if err := GetDB().QueryRowContext(ctx, query, hashToken(resetToken)).Scan(&userID); err != nil {
+		return 0, err
+	}
+
+	return userID, nil
+}
@@ -35,24 +35,51 @@ func AddPermission(code string) error {
 	return err
 }
 
-// AddUserPermissions adds multiple permissions for a specific user.
+// AddUserPermissions adds multiple permissions for a specific user. Permissions the user already has are skipped.
 func AddUserPermissions(userID int, codes ...string) error {
+	err := AddUserPermissionsWith(GetDB(), userID, codes...)
+	InvalidateUserPermissions(userID)
+	return err
+}
+
+// AddUserPermissionsWith adds multiple permissions for a specific user using the executor, such as a transaction.
+// It does not invalidate the permission cache; callers must call InvalidateUserPermissions once the permissions
+// are committed, so that the state before the commit is not cached again.
+func AddUserPermissionsWith(ex Executor, userID int, codes ...string) error {
 	query := `
 		INSERT INTO user_permissions (user_id, permissions_id)
 		SELECT $1, permissions.id
 		FROM permissions
 		WHERE permissions.code = ANY($2)
+		ON CONFLICT DO NOTHING
 	`
 
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
-	_, err := GetDB().ExecContext(ctx, query, userID, pq.Array(codes))
+	_, err := ex.ExecContext(ctx, query, userID, pq.Array(codes))
 	return err
 }
 
-// GetUserPermissions retrieves all permission codes for a specific user.
+// GetUserPermissions retrieves all permission codes for a specific user: the account-wide codes
+// and the ACL entries of films and collections the user does not own, as codes such as film:1:read.
 func GetUserPermissions(userID int) (Permissions, error) {
+	query := `
+		SELECT permissions.code 
+		FROM permissions
+		JOIN user_permissions ON user_permissions.permissions_id = permissions.id
+		WHERE user_permissions.user_id = $1
+		UNION ALL
+		SELECT resource_type || ':' || resource_id || ':' || action
+		FROM acl_entries
+		WHERE user_id = $1
+	`
+
+	return queryPermissions(query, userID)
+}
+
+// getAccountPermissions retrieves the account-wide permission codes for a specific user.
+func getAccountPermissions(userID int) (Permissions, error) {
 	query := `
 		SELECT permissions.code 
 		FROM permissions
@@ -60,6 +87,11 @@ func GetUserPermissions(userID int) (Permissions, error) {
 		WHERE user_permissions.user_id = $1
 	`
 
+	return queryPermissions(query, userID)
+}
+
+// queryPermissions retrieves the permission codes returned by the query for a specific user.
+func queryPermissions(query string, userID int) (Permissions, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
@@ -90,6 +122,24 @@ func GetUserPermissions(userID int) (Permissions, error) {
 	return permissions, nil
 }
 
+// DeleteUserPermissions removes permission codes from a specific user. The codes stay available to other users.
+func DeleteUserPermissions(userID int, codes ...string) error {
+	query := `
+		DELETE FROM user_permissions
+		USING permissions
+		WHERE user_permissions.permissions_id = permissions.id
+		  AND user_permissions.user_id = $1
+		  AND permissions.code = ANY($2)
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	_, err := GetDB().ExecContext(ctx, query, userID, pq.Array(codes))
+	InvalidateUserPermissions(userID)
+	return err
+}
+
 // DeletePermissions deletes permission codes.
 func DeletePermissions(codes ...string) error {
 	query := `DELETE FROM permissions WHERE code = ANY($1)`
@@ -98,5 +148,6 @@ func DeletePermissions(codes ...string) error {
 	defer cancel()
 
 	_, err := GetDB().ExecContext(ctx, query, pq.Array(codes))
+	permissionsCache.invalidateAll()
 	return err
 }
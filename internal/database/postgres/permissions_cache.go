@@ -0,0 +1,138 @@
+package postgres
+
+import (
+	"container/list"
+	"github.com/k4sper1love/watchlist-api/internal/config"
+	"github.com/k4sper1love/watchlist-api/pkg/metrics"
+	"sync"
+	"time"
+)
+
+// permissionCache is a bounded in-process cache of the account-wide permission codes of users.
+// Entries expire after config.PermissionCacheTTL, and the least recently used entry is evicted
+// when more than config.PermissionCacheSize users are cached.
+//
+// Changes made by other instances of the API are only seen after the entry expires.
+type permissionCache struct {
+	mu         sync.Mutex
+	entries    map[int]*list.Element
+	order      *list.List // Most recently used entries are at the front.
+	generation uint64     // Incremented by every invalidation.
+}
+
+// permissionCacheEntry is the cached permission codes of a user.
+type permissionCacheEntry struct {
+	userID    int
+	codes     Permissions
+	expiresAt time.Time
+}
+
+var permissionsCache = &permissionCache{
+	entries: make(map[int]*list.Element),
+	order:   list.New(),
+}
+
+// permissionCacheEnabled reports whether the permission cache is configured.
+func permissionCacheEnabled() bool {
+	return config.PermissionCacheSize > 0 && config.PermissionCacheTTL > 0
+}
+
+// get returns the cached codes of the user and whether they were found.
+// It also returns the current generation, which must be passed to set when the codes are loaded after a miss.
+func (c *permissionCache) get(userID int) (Permissions, uint64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[userID]
+	if !ok {
+		return nil, c.generation, false
+	}
+
+	entry := elem.Value.(*permissionCacheEntry)
+	if time.Now().After(entry.expiresAt) {
+		c.remove(elem)
+		return nil, c.generation, false
+	}
+
+	c.order.MoveToFront(elem)
+	return entry.codes, c.generation, true
+}
+
+// set caches the codes of the user. The codes are discarded if the cache was invalidated since the generation
+// was read, because they may have been loaded before the change that caused the invalidation.
+func (c *permissionCache) set(userID int, codes Permissions, generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if generation != c.generation {
+		return
+	}
+
+	if elem, ok := c.entries[userID]; ok {
+		c.remove(elem)
+	}
+
+	entry := &permissionCacheEntry{userID: userID, codes: codes, expiresAt: time.Now().Add(config.PermissionCacheTTL)}
+	c.entries[userID] = c.order.PushFront(entry)
+
+	for c.order.Len() > config.PermissionCacheSize {
+		c.remove(c.order.Back())
+	}
+}
+
+// invalidate removes the cached codes of the users.
+func (c *permissionCache) invalidate(userIDs ...int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	for _, userID := range userIDs {
+		if elem, ok := c.entries[userID]; ok {
+			c.remove(elem)
+		}
+	}
+}
+
+// invalidateAll removes the cached codes of all users.
+func (c *permissionCache) invalidateAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	c.entries = make(map[int]*list.Element)
+	c.order.Init()
+}
+
+// remove deletes the entry from the cache. The caller must hold the lock.
+func (c *permissionCache) remove(elem *list.Element) {
+	c.order.Remove(elem)
+	delete(c.entries, elem.Value.(*permissionCacheEntry).userID)
+}
+
+// getCachedAccountPermissions retrieves the account-wide permission codes of the user through the cache.
+func getCachedAccountPermissions(userID int) (Permissions, error) {
+	if !permissionCacheEnabled() {
+		return getAccountPermissions(userID)
+	}
+
+	codes, generation, ok := permissionsCache.get(userID)
+	if ok {
+		metrics.IncPermissionCacheHit()
+		return codes, nil
+	}
+	metrics.IncPermissionCacheMiss()
+
+	codes, err := getAccountPermissions(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	permissionsCache.set(userID, codes, generation)
+	return codes, nil
+}
+
+// InvalidateUserPermissions removes the cached permission codes of the users.
+// It must be called after permissions changed in a transaction are committed.
+func InvalidateUserPermissions(userIDs ...int) {
+	permissionsCache.invalidate(userIDs...)
+}
@@ -6,6 +6,7 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"github.com/golang-migrate/migrate/v4"
@@ -18,6 +19,13 @@ import (
 
 var db *sql.DB
 
+// Executor runs queries on the database or inside a transaction; it is implemented by *sql.DB and *sql.Tx.
+type Executor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
 // OpenDB opens a PostgreSQL database connection and applies any pending migrations.
 // It initializes a connection using the DSN from `config.Dsn`, verifies the connection with a ping,
 // and applies migrations if a path is specified in `config.Migrations`.
@@ -78,3 +86,8 @@ func PingDB() error {
 func GetDB() *sql.DB {
 	return db
 }
+
+// BeginTx starts a transaction for changes that must be applied together.
+func BeginTx(ctx context.Context) (*sql.Tx, error) {
+	return db.BeginTx(ctx, nil)
+}
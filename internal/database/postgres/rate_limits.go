@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"context"
+	"github.com/k4sper1love/watchlist-api/pkg/ratelimit"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// rateLimitSweepInterval is how often full buckets are deleted from the database.
+const rateLimitSweepInterval = 5 * time.Minute
+
+// RateLimiter keeps token buckets in the database, so that limits are shared between application instances.
+type RateLimiter struct {
+	mu        sync.Mutex
+	lastSweep time.Time
+}
+
+// NewRateLimiter creates a rate limiter backed by the database.
+func NewRateLimiter() *RateLimiter {
+	return &RateLimiter{lastSweep: time.Now()}
+}
+
+// Allow takes a token from the bucket of the key.
+// The bucket row is locked for the duration of the update, and elapsed time is measured by the database clock.
+func (l *RateLimiter) Allow(key string, limit ratelimit.Limit) (ratelimit.Result, error) {
+	insertQuery := `
+		INSERT INTO rate_limits (key, tokens)
+		VALUES ($1, $2)
+		ON CONFLICT (key) DO NOTHING
+	`
+
+	selectQuery := `
+		SELECT tokens, EXTRACT(EPOCH FROM NOW() - updated_at)
+		FROM rate_limits WHERE key = $1
+		FOR UPDATE
+	`
+
+	updateQuery := `
+		UPDATE rate_limits
+		SET tokens = $2, updated_at = NOW(), full_at = NOW() + make_interval(secs => $3)
+		WHERE key = $1
+	`
+
+	l.sweep()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := GetDB().BeginTx(ctx, nil)
+	if err != nil {
+		return ratelimit.Result{}, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, insertQuery, key, limit.Requests); err != nil {
+		return ratelimit.Result{}, err
+	}
+
+	var tokens, elapsed float64
+	if err := tx.QueryRowContext(ctx, selectQuery, key).Scan(&tokens, &elapsed); err != nil {
+		return ratelimit.Result{}, err
+	}
+
+	tokens, result := ratelimit.Take(tokens, time.Duration(elapsed*float64(time.Second)), limit)
+
+	if _, err := tx.ExecContext(ctx, updateQuery, key, tokens, result.Reset.Seconds()); err != nil {
+		return ratelimit.Result{}, err
+	}
+
+	return result, tx.Commit()
+}
+
+// sweep deletes buckets that have been refilled, at most once per interval across all requests of this instance.
+func (l *RateLimiter) sweep() {
+	l.mu.Lock()
+	if time.Since(l.lastSweep) < rateLimitSweepInterval {
+		l.mu.Unlock()
+		return
+	}
+	l.lastSweep = time.Now()
+	l.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	if _, err := GetDB().ExecContext(ctx, `DELETE FROM rate_limits WHERE full_at < NOW()`); err != nil {
+		slog.Error("failed to delete full rate limit buckets", slog.Any("error", err))
+	}
+}
@@ -0,0 +1,51 @@
+package postgres
+
+import (
+	"context"
+	"time"
+)
+
+// SaveCollectionShareLink saves the share token of a collection, replacing the previous one.
+// It returns the time the link was created.
+func SaveCollectionShareLink(collectionID int, token string) (time.Time, error) {
+	query := `
+		INSERT INTO collection_share_links (collection_id, token_hash)
+		VALUES ($1, $2)
+		ON CONFLICT (collection_id) DO UPDATE SET token_hash = EXCLUDED.token_hash, created_at = NOW()
+		RETURNING created_at
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var createdAt time.Time
+	err := GetDB().QueryRowContext(ctx, query, collectionID, hashToken(token)).Scan(&createdAt)
+	return createdAt, err
+}
+
+// GetCollectionIDByShareToken retrieves the ID of the collection a share token belongs to.
+func GetCollectionIDByShareToken(token string) (int, error) {
+	query := `SELECT collection_id FROM collection_share_links WHERE token_hash = $1`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var collectionID int
+	err := GetDB().QueryRowContext(ctx, query, hashToken(token)).Scan(&collectionID)
+	return collectionID, err
+}
+
+// DeleteCollectionShareLink revokes the share link of a collection.
+func DeleteCollectionShareLink(collectionID int) error {
+	query := `DELETE FROM collection_share_links WHERE collection_id = $1`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := GetDB().ExecContext(ctx, query, collectionID)
+	if err != nil {
+		return err
+	}
+
+	return requireAffected(result)
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"github.com/k4sper1love/watchlist-api/pkg/models"
+	"log/slog"
 	"time"
 )
 
@@ -14,16 +16,20 @@ func hashToken(token string) string {
 }
 
 // SaveRefreshToken stores a refresh token, its associated user ID, and its expiration time in the database.
-func SaveRefreshToken(refreshToken string, userID int, expiresAt time.Time) error {
+// The token starts a new token family with s.ID as the session ID.
+func SaveRefreshToken(refreshToken string, userID int, expiresAt time.Time, s *models.Session) error {
 	hashedToken := hashToken(refreshToken)
 
-	query := `INSERT INTO refresh_tokens(token, user_id, expires_at) values ($1, $2, $3)`
+	query := `
+		INSERT INTO refresh_tokens(token, user_id, expires_at, family_id, user_agent, client_ip)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING created_at, last_used_at, expires_at
+	`
 
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
-	_, err := GetDB().ExecContext(ctx, query, hashedToken, userID, expiresAt)
-	return err
+	return GetDB().QueryRowContext(ctx, query, hashedToken, userID, expiresAt, s.ID, s.UserAgent, s.ClientIP).Scan(&s.CreatedAt, &s.LastUsedAt, &s.ExpiresAt)
 }
 
 // RevokeRefreshToken marks a refresh token as revoked in the database.
@@ -72,3 +78,184 @@ func GetIdFromRefreshToken(refreshToken string) (int, error) {
 
 	return userID, nil
 }
+
+// RotateRefreshToken revokes an active refresh token and stores its replacement in the same token family.
+// The session keeps its ID and creation time; s.ID must be the session ID of the old token.
+// It returns sql.ErrNoRows if the old token is revoked, expired, unknown or belongs to another session.
+func RotateRefreshToken(oldRefreshToken, newRefreshToken string, expiresAt time.Time, s *models.Session) error {
+	revokeQuery := `
+		UPDATE refresh_tokens
+		SET revoked = TRUE
+		WHERE token = $1 AND family_id::text = $2 AND revoked = FALSE AND expires_at > NOW()
+		RETURNING user_id, created_at
+	`
+
+	insertQuery := `
+		INSERT INTO refresh_tokens(token, user_id, expires_at, family_id, created_at, user_agent, client_ip)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING last_used_at, expires_at
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := GetDB().BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var userID int
+	if err := tx.QueryRowContext(ctx, revokeQuery, hashToken(oldRefreshToken), s.ID).Scan(&userID, &s.CreatedAt); err != nil {
+		return err
+	}
+
+	err = tx.QueryRowContext(ctx, insertQuery, hashToken(newRefreshToken), userID, expiresAt, s.ID, s.CreatedAt, s.UserAgent, s.ClientIP).Scan(&s.LastUsedAt, &s.ExpiresAt)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetRefreshTokenFamily retrieves the family ID of a refresh token, which is the ID of its session.
+func GetRefreshTokenFamily(refreshToken string) (string, error) {
+	query := `SELECT family_id FROM refresh_tokens WHERE token = $1`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var familyID string
+	if err := GetDB().QueryRowContext(ctx, query, hashToken(refreshToken)).Scan(&familyID); err != nil {
+		return "", err
+	}
+
+	return familyID, nil
+}
+
+// RevokeRefreshTokenFamily revokes every refresh token that shares a family with the given token.
+func RevokeRefreshTokenFamily(refreshToken string) error {
+	hashedToken := hashToken(refreshToken)
+
+	query := `
+		UPDATE refresh_tokens SET revoked = TRUE
+		WHERE family_id IN (SELECT family_id FROM refresh_tokens WHERE token = $1)
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	_, err := GetDB().ExecContext(ctx, query, hashedToken)
+	return err
+}
+
+// GetSessions retrieves the active sessions of a user, most recently used first.
+func GetSessions(userID int) ([]*models.Session, error) {
+	query := `
+		SELECT family_id, COALESCE(user_agent, ''), COALESCE(client_ip, ''), created_at, last_used_at, expires_at
+		FROM refresh_tokens
+		WHERE user_id = $1 AND revoked = FALSE AND expires_at > NOW()
+		ORDER BY last_used_at DESC
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := GetDB().QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err := rows.Close(); err != nil {
+			slog.Error("failed to close rows", slog.Any("error", err))
+		}
+	}()
+
+	var sessions []*models.Session
+	for rows.Next() {
+		var s models.Session
+		if err := rows.Scan(&s.ID, &s.UserAgent, &s.ClientIP, &s.CreatedAt, &s.LastUsedAt, &s.ExpiresAt); err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, &s)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// RevokeSession revokes every refresh token of a user's session.
+// It returns sql.ErrNoRows if the user has no active session with the given ID.
+func RevokeSession(userID int, sessionID string) error {
+	query := `
+		UPDATE refresh_tokens SET revoked = TRUE
+		WHERE user_id = $1 AND family_id = $2 AND revoked = FALSE AND expires_at > NOW()
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := GetDB().ExecContext(ctx, query, userID, sessionID)
+	if err != nil {
+		return err
+	}
+
+	return requireAffected(result)
+}
+
+// RevokeOtherSessions revokes every refresh token of a user except those of the given session.
+// It returns the IDs of the revoked sessions.
+func RevokeOtherSessions(userID int, currentSessionID string) ([]string, error) {
+	query := `
+		WITH revoked AS (
+			UPDATE refresh_tokens SET revoked = TRUE
+			WHERE user_id = $1 AND family_id::text <> $2 AND revoked = FALSE
+			RETURNING family_id
+		)
+		SELECT DISTINCT family_id::text FROM revoked
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := GetDB().QueryContext(ctx, query, userID, currentSessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err := rows.Close(); err != nil {
+			slog.Error("failed to close rows", slog.Any("error", err))
+		}
+	}()
+
+	var sessionIDs []string
+	for rows.Next() {
+		var sessionID string
+		if err := rows.Scan(&sessionID); err != nil {
+			return nil, err
+		}
+		sessionIDs = append(sessionIDs, sessionID)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return sessionIDs, nil
+}
+
+// RevokeUserRefreshTokens revokes every refresh token of a user.
+func RevokeUserRefreshTokens(userID int) error {
+	query := `UPDATE refresh_tokens SET revoked = TRUE WHERE user_id = $1 AND revoked = FALSE`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	_, err := GetDB().ExecContext(ctx, query, userID)
+	return err
+}
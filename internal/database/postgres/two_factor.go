@@ -0,0 +1,210 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"github.com/k4sper1love/watchlist-api/pkg/models"
+	"time"
+)
+
+// SaveTOTPSecret stores a new TOTP secret for a user whose two-factor authentication is not enabled yet.
+// It returns sql.ErrNoRows if two-factor authentication is already enabled.
+func SaveTOTPSecret(userID int, secret string) error {
+	query := `
+		INSERT INTO user_totp (user_id, secret)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE
+		SET secret = EXCLUDED.secret, last_used_step = 0, created_at = NOW()
+		WHERE user_totp.enabled_at IS NULL
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := GetDB().ExecContext(ctx, query, userID, secret)
+	if err != nil {
+		return err
+	}
+
+	return requireAffected(result)
+}
+
+// GetTOTP retrieves the TOTP settings of a user.
+func GetTOTP(userID int) (*models.TOTP, error) {
+	query := `SELECT user_id, secret, enabled_at, last_used_step FROM user_totp WHERE user_id = $1`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var t models.TOTP
+	var rawEnabledAt sql.NullTime
+
+	if err := GetDB().QueryRowContext(ctx, query, userID).Scan(&t.UserID, &t.Secret, &rawEnabledAt, &t.LastUsedStep); err != nil {
+		return nil, err
+	}
+
+	t.EnabledAt = extractTime(rawEnabledAt)
+	return &t, nil
+}
+
+// IsTOTPEnabled checks if a user has two-factor authentication enabled.
+func IsTOTPEnabled(userID int) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM user_totp WHERE user_id = $1 AND enabled_at IS NOT NULL)`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var enabled bool
+	if err := GetDB().QueryRowContext(ctx, query, userID).Scan(&enabled); err != nil {
+		return false, err
+	}
+
+	return enabled, nil
+}
+
+// EnableTOTP enables two-factor authentication of a user and replaces the recovery codes.
+// The TOTP step used for confirmation is recorded so that the same code cannot be used again.
+func EnableTOTP(userID int, step int64, recoveryCodes []string) error {
+	enableQuery := `
+		UPDATE user_totp SET enabled_at = NOW(), last_used_step = $2
+		WHERE user_id = $1 AND enabled_at IS NULL
+	`
+
+	deleteCodesQuery := `DELETE FROM totp_recovery_codes WHERE user_id = $1`
+
+	insertCodeQuery := `INSERT INTO totp_recovery_codes (user_id, code) VALUES ($1, $2)`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := GetDB().BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, enableQuery, userID, step)
+	if err != nil {
+		return err
+	}
+
+	if err := requireAffected(result); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, deleteCodesQuery, userID); err != nil {
+		return err
+	}
+
+	for _, code := range recoveryCodes {
+		if _, err := tx.ExecContext(ctx, insertCodeQuery, userID, hashToken(code)); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// UseTOTPStep records a TOTP step as used.
+// It returns sql.ErrNoRows if the step is not newer than the last used one, which prevents code replay.
+func UseTOTPStep(userID int, step int64) error {
+	query := `UPDATE user_totp SET last_used_step = $2 WHERE user_id = $1 AND last_used_step < $2`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := GetDB().ExecContext(ctx, query, userID, step)
+	if err != nil {
+		return err
+	}
+
+	return requireAffected(result)
+}
+
+// UseRecoveryCode marks an unused recovery code of a user as used.
+// It returns sql.ErrNoRows if the code is unknown or already used.
+func UseRecoveryCode(userID int, code string) error {
+	query := `
+		UPDATE totp_recovery_codes SET used_at = NOW()
+		WHERE user_id = $1 AND code = $2 AND used_at IS NULL
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := GetDB().ExecContext(ctx, query, userID, hashToken(code))
+	if err != nil {
+		return err
+	}
+
+	return requireAffected(result)
+}
+
+// DeleteTOTP disables two-factor authentication of a user and removes the recovery codes.
+func DeleteTOTP(userID int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := GetDB().BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM totp_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM user_totp WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// SaveTwoFactorChallenge stores a token that allows a user to finish logging in with a second factor.
+func SaveTwoFactorChallenge(challengeToken string, userID int, expiresAt time.Time) error {
+	query := `INSERT INTO two_factor_challenges (token, user_id, expires_at) VALUES ($1, $2, $3)`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	_, err := GetDB().ExecContext(ctx, query, hashToken(challengeToken), userID, expiresAt)
+	return err
+}
+
+// RecordTwoFactorAttempt counts an attempt to answer a two-factor challenge and returns the ID of its user.
+// It returns sql.ErrNoRows if the challenge is unknown, expired, completed or out of attempts.
+func RecordTwoFactorAttempt(challengeToken string, maxAttempts int) (int, error) {
+	query := `
+		UPDATE two_factor_challenges SET attempts = attempts + 1
+		WHERE token = $1 AND used_at IS NULL AND expires_at > NOW() AND attempts < $2
+		RETURNING user_id
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var userID int
+	if err := GetDB().QueryRowContext(ctx, query, hashToken(challengeToken), maxAttempts).Scan(&userID); err != nil {
+		return 0, err
+	}
+
+	return userID, nil
+}
+
+// CompleteTwoFactorChallenge marks a two-factor challenge as used.
+// It returns sql.ErrNoRows if the challenge has already been completed.
+func CompleteTwoFactorChallenge(challengeToken string) error {
+	query := `UPDATE two_factor_challenges SET used_at = NOW() WHERE token = $1 AND used_at IS NULL`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := GetDB().ExecContext(ctx, query, hashToken(challengeToken))
+	if err != nil {
+		return err
+	}
+
+	return requireAffected(result)
+}
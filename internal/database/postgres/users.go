@@ -8,7 +8,7 @@ import (
 	"time"
 )
 
-// AddUserWithCredentials inserts a new user with a username and password into the database.
+// AddUserWithCredentials inserts a new user with a username and password into the database, with its password identity.
 func AddUserWithCredentials(c *models.Credentials) (*models.User, error) {
 	query := `
 		INSERT INTO users (username, email, password)
@@ -18,21 +18,35 @@ func AddUserWithCredentials(c *models.Credentials) (*models.User, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
+	tx, err := GetDB().BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
 	var u models.User
 	var rawTelegramID sql.NullInt64
 	var rawEmail sql.NullString
 
-	err := GetDB().QueryRowContext(ctx, query, c.Username, c.Email, c.Password).Scan(&u.ID, &rawTelegramID, &u.Username, &rawEmail, &u.CreatedAt, &u.Version)
+	err = tx.QueryRowContext(ctx, query, c.Username, c.Email, c.Password).Scan(&u.ID, &rawTelegramID, &u.Username, &rawEmail, &u.CreatedAt, &u.Version)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := insertUserIdentity(ctx, tx, &models.UserIdentity{UserID: u.ID, Provider: models.IdentityProviderPassword}); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
 	u.TelegramID = extractInt(rawTelegramID)
 	u.Email = extractString(rawEmail)
 	return &u, nil
 }
 
-// AddUserByTelegramID inserts a new user with telegram_id and username into the database.
+// AddUserByTelegramID inserts a new user with telegram_id and username into the database, with its Telegram identity.
 func AddUserByTelegramID(c *models.Credentials) (*models.User, error) {
 	query := `
 		INSERT INTO users (telegram_id, username)
@@ -42,21 +56,39 @@ func AddUserByTelegramID(c *models.Credentials) (*models.User, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
+	tx, err := GetDB().BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
 	var u models.User
 	var rawTelegramID sql.NullInt64
 
-	err := GetDB().QueryRowContext(ctx, query, c.TelegramID, c.Username).Scan(&u.ID, &rawTelegramID, &u.Username, &u.CreatedAt, &u.Version)
+	err = tx.QueryRowContext(ctx, query, c.TelegramID, c.Username).Scan(&u.ID, &rawTelegramID, &u.Username, &u.CreatedAt, &u.Version)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := insertUserIdentity(ctx, tx, telegramIdentity(u.ID, c.TelegramID)); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
 	u.TelegramID = extractInt(rawTelegramID)
 	return &u, nil
 }
 
 // GetUserById retrieves a user by their ID.
 func GetUserById(id int) (*models.User, error) {
-	query := `SELECT * FROM users WHERE id = $1`
+	query := `
+		SELECT id, telegram_id, username, email, password, email_verified_at, suspended_at, deletion_scheduled_at, created_at, version
+		FROM users
+		WHERE id = $1
+	`
 
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
@@ -65,20 +97,30 @@ func GetUserById(id int) (*models.User, error) {
 	var rawTelegramID sql.NullInt64
 	var rawEmail sql.NullString
 	var rawPassword sql.NullString
+	var rawEmailVerifiedAt sql.NullTime
+	var rawSuspendedAt sql.NullTime
+	var rawDeletionScheduledAt sql.NullTime
 
-	if err := GetDB().QueryRowContext(ctx, query, id).Scan(&u.ID, &rawTelegramID, &u.Username, &rawEmail, &rawPassword, &u.CreatedAt, &u.Version); err != nil {
+	if err := GetDB().QueryRowContext(ctx, query, id).Scan(&u.ID, &rawTelegramID, &u.Username, &rawEmail, &rawPassword, &rawEmailVerifiedAt, &rawSuspendedAt, &rawDeletionScheduledAt, &u.CreatedAt, &u.Version); err != nil {
 		return nil, err
 	}
 
 	u.TelegramID = extractInt(rawTelegramID)
 	u.Email = extractString(rawEmail)
 	u.Password = extractString(rawPassword)
+	u.EmailVerifiedAt = extractTime(rawEmailVerifiedAt)
+	u.SuspendedAt = extractTime(rawSuspendedAt)
+	u.DeletionScheduledAt = extractTime(rawDeletionScheduledAt)
 	return &u, nil
 }
 
 // GetUserByUsername retrieves a user by their username.
 func GetUserByUsername(username string) (*models.User, error) {
-	query := `SELECT * FROM users WHERE username = $1`
+	query := `
+		SELECT id, telegram_id, username, email, password, email_verified_at, created_at, version
+		FROM users
+		WHERE username = $1
+	`
 
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
@@ -87,20 +129,26 @@ func GetUserByUsername(username string) (*models.User, error) {
 	var rawTelegramID sql.NullInt64
 	var rawEmail sql.NullString
 	var rawPassword sql.NullString
+	var rawEmailVerifiedAt sql.NullTime
 
-	if err := GetDB().QueryRowContext(ctx, query, username).Scan(&u.ID, &rawTelegramID, &u.Username, &rawEmail, &rawPassword, &u.CreatedAt, &u.Version); err != nil {
+	if err := GetDB().QueryRowContext(ctx, query, username).Scan(&u.ID, &rawTelegramID, &u.Username, &rawEmail, &rawPassword, &rawEmailVerifiedAt, &u.CreatedAt, &u.Version); err != nil {
 		return nil, err
 	}
 
 	u.TelegramID = extractInt(rawTelegramID)
 	u.Email = extractString(rawEmail)
 	u.Password = extractString(rawPassword)
+	u.EmailVerifiedAt = extractTime(rawEmailVerifiedAt)
 	return &u, nil
 }
 
 // GetUserByTelegramID retrieves a user by their telegram ID.
 func GetUserByTelegramID(telegramID int) (*models.User, error) {
-	query := `SELECT * FROM users WHERE telegram_id = $1`
+	query := `
+		SELECT id, telegram_id, username, email, password, email_verified_at, created_at, version
+		FROM users
+		WHERE telegram_id = $1
+	`
 
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
@@ -109,20 +157,22 @@ func GetUserByTelegramID(telegramID int) (*models.User, error) {
 	var rawTelegramID sql.NullInt64
 	var rawEmail sql.NullString
 	var rawPassword sql.NullString
+	var rawEmailVerifiedAt sql.NullTime
 
-	if err := GetDB().QueryRowContext(ctx, query, telegramID).Scan(&u.ID, &rawTelegramID, &u.Username, &rawEmail, &rawPassword, &u.CreatedAt, &u.Version); err != nil {
+	if err := GetDB().QueryRowContext(ctx, query, telegramID).Scan(&u.ID, &rawTelegramID, &u.Username, &rawEmail, &rawPassword, &rawEmailVerifiedAt, &u.CreatedAt, &u.Version); err != nil {
 		return nil, err
 	}
 
 	u.TelegramID = extractInt(rawTelegramID)
 	u.Email = extractString(rawEmail)
 	u.Password = extractString(rawPassword)
+	u.EmailVerifiedAt = extractTime(rawEmailVerifiedAt)
 	return &u, nil
 }
 
 // GetUsers retrieves all users from the database.
 func GetUsers() ([]*models.User, error) {
-	query := `SELECT * FROM users`
+	query := `SELECT id, telegram_id, username, email, email_verified_at, created_at, version FROM users`
 
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
@@ -143,13 +193,61 @@ func GetUsers() ([]*models.User, error) {
 		var u models.User
 		var rawTelegramID sql.NullInt64
 		var rawEmail sql.NullString
+		var rawEmailVerifiedAt sql.NullTime
+
+		if err := rows.Scan(&u.ID, &rawTelegramID, &u.Username, &rawEmail, &rawEmailVerifiedAt, &u.CreatedAt, &u.Version); err != nil {
+			return nil, err
+		}
+
+		u.TelegramID = extractInt(rawTelegramID)
+		u.Email = extractString(rawEmail)
+		u.EmailVerifiedAt = extractTime(rawEmailVerifiedAt)
+		users = append(users, &u)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// GetCredentialUsersByEmail retrieves users with a password that have the given verified email.
+func GetCredentialUsersByEmail(email string) ([]*models.User, error) {
+	query := `
+		SELECT id, telegram_id, username, email, email_verified_at, created_at, version
+		FROM users
+		WHERE email = $1 AND password IS NOT NULL AND email_verified_at IS NOT NULL
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := GetDB().QueryContext(ctx, query, email)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err := rows.Close(); err != nil {
+			slog.Error("failed to close rows", slog.Any("error", err))
+		}
+	}()
+
+	var users []*models.User
+	for rows.Next() {
+		var u models.User
+		var rawTelegramID sql.NullInt64
+		var rawEmail sql.NullString
+		var rawEmailVerifiedAt sql.NullTime
 
-		if err := rows.Scan(&u.ID, &rawTelegramID, &u.Username, &rawEmail, &u.CreatedAt, &u.Version); err != nil {
+		if err := rows.Scan(&u.ID, &rawTelegramID, &u.Username, &rawEmail, &rawEmailVerifiedAt, &u.CreatedAt, &u.Version); err != nil {
 			return nil, err
 		}
 
 		u.TelegramID = extractInt(rawTelegramID)
 		u.Email = extractString(rawEmail)
+		u.EmailVerifiedAt = extractTime(rawEmailVerifiedAt)
 		users = append(users, &u)
 	}
 
@@ -161,12 +259,14 @@ func GetUsers() ([]*models.User, error) {
 }
 
 // UpdateUser updates a user's details based on their ID and version.
+// Changing the email resets its verification.
 func UpdateUser(u *models.User) error {
 	query := `
 		UPDATE users 
-		SET username = $3, email = $4, version = version + 1
+		SET username = $3, email = $4, version = version + 1,
+		    email_verified_at = CASE WHEN email IS DISTINCT FROM $4 THEN NULL ELSE email_verified_at END
 		WHERE id = $1 AND version = $2
-		RETURNING id, telegram_id, username, email, created_at
+		RETURNING id, telegram_id, username, email, email_verified_at, created_at
 	`
 
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
@@ -174,17 +274,30 @@ func UpdateUser(u *models.User) error {
 
 	var rawTelegramID sql.NullInt64
 	var rawEmail sql.NullString
+	var rawEmailVerifiedAt sql.NullTime
 
-	err := GetDB().QueryRowContext(ctx, query, u.ID, u.Version, u.Username, u.Email).Scan(&u.ID, &rawTelegramID, &u.Username, &rawEmail, &u.CreatedAt)
+	err := GetDB().QueryRowContext(ctx, query, u.ID, u.Version, u.Username, u.Email).Scan(&u.ID, &rawTelegramID, &u.Username, &rawEmail, &rawEmailVerifiedAt, &u.CreatedAt)
 	if err != nil {
 		return err
 	}
 
 	u.TelegramID = extractInt(rawTelegramID)
 	u.Email = extractString(rawEmail)
+	u.EmailVerifiedAt = extractTime(rawEmailVerifiedAt)
 	return nil
 }
 
+// UpdateUserPassword replaces the hashed password of a user.
+func UpdateUserPassword(id int, hashedPassword string) error {
+	query := `UPDATE users SET password = $2, version = version + 1 WHERE id = $1`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	_, err := GetDB().ExecContext(ctx, query, id, hashedPassword)
+	return err
+}
+
 // DeleteUser removes a user from the database by their ID.
 func DeleteUser(id int) error {
 	query := `DELETE FROM users WHERE id = $1`
@@ -193,6 +306,7 @@ func DeleteUser(id int) error {
 	defer cancel()
 
 	_, err := GetDB().ExecContext(ctx, query, id)
+	InvalidateUserPermissions(id)
 	return err
 }
 
@@ -228,3 +342,12 @@ func extractString(str sql.NullString) string {
 	}
 	return ""
 }
+
+// extractTime converts the value of sql.NullTime to *time.Time.
+// If the value is valid, it returns a pointer to it; otherwise, it returns nil.
+func extractTime(t sql.NullTime) *time.Time {
+	if t.Valid {
+		return &t.Time
+	}
+	return nil
+}
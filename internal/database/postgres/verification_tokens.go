@@ -0,0 +1,33 @@
+package postgres
+
+import (
+	"context"
+	"time"
+)
+
+// UseVerificationToken records the use of a Telegram verification token by its ID.
+// Records of expired tokens are deleted at the same time.
+// It returns sql.ErrNoRows if the token has already been used.
+func UseVerificationToken(jti string, telegramID int, expiresAt time.Time) error {
+	deleteQuery := `DELETE FROM verification_token_uses WHERE expires_at <= NOW()`
+
+	insertQuery := `
+		INSERT INTO verification_token_uses (jti, telegram_id, expires_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (jti) DO NOTHING
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	if _, err := GetDB().ExecContext(ctx, deleteQuery); err != nil {
+		return err
+	}
+
+	result, err := GetDB().ExecContext(ctx, insertQuery, jti, telegramID, expiresAt)
+	if err != nil {
+		return err
+	}
+
+	return requireAffected(result)
+}
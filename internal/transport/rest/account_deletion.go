@@ -0,0 +1,106 @@
+package rest
+
+import (
+	"database/sql"
+	"github.com/k4sper1love/watchlist-api/internal/config"
+	"github.com/k4sper1love/watchlist-api/internal/database/postgres"
+	"github.com/k4sper1love/watchlist-api/pkg/models"
+	"log/slog"
+	"time"
+)
+
+// accountPurgeInterval is how often accounts whose grace period has passed are purged.
+const accountPurgeInterval = time.Hour
+
+// deleteAccount schedules the account of the user for deletion and logs the user out everywhere.
+// It returns the time after which the account is purged.
+func deleteAccount(userID int) (time.Time, error) {
+	deletedAt, err := postgres.ScheduleUserDeletion(userID)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if err := forceLogout(userID); err != nil {
+		return time.Time{}, err
+	}
+
+	return deletedAt.Add(config.DeletionGracePeriod), nil
+}
+
+// restoreDeletedAccount cancels the deletion of the account if it was deleted within the grace period,
+// reporting whether it was restored. An account whose grace period has passed is treated as gone.
+func restoreDeletedAccount(user *models.User) (bool, error) {
+	restored, err := postgres.RestoreUser(user.ID, time.Now().Add(-config.DeletionGracePeriod))
+	if err != nil {
+		return false, err
+	}
+
+	if restored {
+		user.DeletionScheduledAt = nil
+		return true, nil
+	}
+
+	deleted, err := postgres.IsUserDeleted(user.ID)
+	if err != nil {
+		return false, err
+	}
+
+	if deleted {
+		return false, sql.ErrNoRows
+	}
+
+	return false, nil
+}
+
+// startAccountPurge purges accounts whose grace period has passed and expired account exports
+// now and then periodically in the background.
+func startAccountPurge() {
+	go func() {
+		ticker := time.NewTicker(accountPurgeInterval)
+		defer ticker.Stop()
+
+		for {
+			purgeDeletedAccounts()
+			purgeExpiredAccountExports()
+			<-ticker.C
+		}
+	}()
+}
+
+// purgeDeletedAccounts permanently deletes the accounts deleted longer than the grace period ago,
+// together with their films, collections and permissions.
+func purgeDeletedAccounts() {
+	ids, err := postgres.GetUsersDeletedBefore(time.Now().Add(-config.DeletionGracePeriod))
+	if err != nil {
+		slog.Error("failed to get deleted accounts", slog.Any("error", err))
+		return
+	}
+
+	for _, id := range ids {
+		if err := postgres.DeleteUser(id); err != nil {
+			slog.Error("failed to purge account", slog.Any("error", err), slog.Int("user_id", id))
+			continue
+		}
+
+		if err := postgres.AddAuditEvent(&models.AuditEvent{Event: auditEventUserPurge, UserID: id}); err != nil {
+			slog.Error("failed to record audit event", slog.Any("error", err), slog.String("event", auditEventUserPurge))
+		}
+	}
+
+	if len(ids) > 0 {
+		slog.Info("purged deleted accounts", slog.Int("count", len(ids)))
+	}
+}
+
+// purgeExpiredAccountExports deletes the account archives that can no longer be downloaded.
+func purgeExpiredAccountExports() {
+	count, err := postgres.DeleteExpiredAccountExports()
+	if err != nil {
+		slog.Error("failed to delete expired account exports", slog.Any("error", err))
+		return
+	}
+
+	if count > 0 {
+		slog.Info("deleted expired account exports", slog.Int64("count", count))
+	}
+}
@@ -0,0 +1,195 @@
+package rest
+
+import (
+	"archive/zip"
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"github.com/k4sper1love/watchlist-api/internal/database/postgres"
+	"github.com/k4sper1love/watchlist-api/pkg/filters"
+	"github.com/k4sper1love/watchlist-api/pkg/models"
+	"io"
+	"log/slog"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// accountExportExpiration is the time a generated account archive can be downloaded.
+const accountExportExpiration = 24 * time.Hour
+
+// accountArchiveFile is the name of the file with the account data in an archive.
+const accountArchiveFile = "account.json"
+
+// startAccountExport starts generating an archive of all data of the user in the background.
+func startAccountExport(userID int) (*models.AccountExport, error) {
+	export, err := postgres.AddAccountExport(userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errExportInProgress
+		}
+		return nil, err
+	}
+
+	go generateAccountExport(export.ID, userID)
+
+	return export, nil
+}
+
+// generateAccountExport builds the archive of an export and stores it, or marks the export as failed.
+func generateAccountExport(exportID string, userID int) {
+	archive, err := buildAccountArchive(userID)
+	if err != nil {
+		slog.Error("failed to generate account export", slog.Any("error", err), slog.String("export_id", exportID))
+
+		if err := postgres.FailAccountExport(exportID, "failed to generate the archive"); err != nil {
+			slog.Error("failed to mark account export as failed", slog.Any("error", err), slog.String("export_id", exportID))
+		}
+		return
+	}
+
+	if err := postgres.CompleteAccountExport(exportID, archive, time.Now().Add(accountExportExpiration)); err != nil {
+		slog.Error("failed to store account export", slog.Any("error", err), slog.String("export_id", exportID))
+	}
+}
+
+// buildAccountArchive creates a ZIP archive with the data of the user in account.json
+// and the uploaded images of the films in the images folder.
+func buildAccountArchive(userID int) ([]byte, error) {
+	data, err := collectAccountData(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+
+	for imageURL, name := range data.Images {
+		if err := addFileToZip(zw, "images/"+name, filepath.Join("static/images", name)); err != nil {
+			slog.Warn("failed to add image to account export", slog.Any("error", err), slog.String("image_url", imageURL))
+			delete(data.Images, imageURL)
+		}
+	}
+
+	w, err := zw.Create(accountArchiveFile)
+	if err != nil {
+		return nil, err
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "\t")
+	if err := encoder.Encode(data); err != nil {
+		return nil, err
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// collectAccountData retrieves all data of the user: profile, login methods, films, collections
+// with their films, permissions and sessions.
+func collectAccountData(userID int) (*models.AccountArchive, error) {
+	user, err := postgres.GetUserById(userID)
+	if err != nil {
+		return nil, err
+	}
+	user.Password = "" // Never export the password hash.
+
+	identities, err := postgres.GetUserIdentities(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	films, _, err := postgres.GetFilms(userID, &models.FilmsQueryInput{Filters: filters.Unpaged("id"), ExcludeCollection: -1})
+	if err != nil {
+		return nil, err
+	}
+
+	collections, _, err := postgres.GetCollections(userID, "", -1, -1, false, filters.Unpaged("id"))
+	if err != nil {
+		return nil, err
+	}
+
+	links, err := postgres.GetUserCollectionFilmLinks(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions, err := postgres.GetUserPermissions(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := postgres.GetSessions(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	images := make(map[string]string)
+	for _, film := range films {
+		if name, ok := uploadedImageName(film.ImageURL); ok {
+			images[film.ImageURL] = name
+		}
+	}
+
+	return &models.AccountArchive{
+		Version:         models.AccountArchiveVersion,
+		ExportedAt:      time.Now(),
+		User:            user,
+		Identities:      identities,
+		Films:           films,
+		Collections:     collections,
+		CollectionFilms: links,
+		Permissions:     permissions,
+		Sessions:        sessions,
+		Images:          images,
+	}, nil
+}
+
+// uploadedImageName returns the file name of an image uploaded to this server, if the URL refers to one.
+// The default image is not an upload of the user.
+func uploadedImageName(imageURL string) (string, bool) {
+	if imageURL == "" {
+		return "", false
+	}
+
+	u, err := url.Parse(imageURL)
+	if err != nil || !strings.HasPrefix(u.Path, "/images/") {
+		return "", false
+	}
+
+	name := path.Base(u.Path)
+	if name == "default.png" || name != filepath.Base(name) {
+		return "", false
+	}
+
+	if _, err := os.Stat(filepath.Join("static/images", name)); err != nil {
+		return "", false
+	}
+
+	return name, true
+}
+
+// addFileToZip copies a file into the archive under the given name.
+func addFileToZip(zw *zip.Writer, name, filePath string) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	w, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(w, file)
+	return err
+}
@@ -0,0 +1,112 @@
+package rest
+
+import (
+	"fmt"
+	"github.com/gorilla/mux"
+	"github.com/k4sper1love/watchlist-api/internal/database/postgres"
+	"github.com/k4sper1love/watchlist-api/pkg/metrics"
+	"github.com/k4sper1love/watchlist-api/pkg/models"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+// StartAccountExport godoc
+// @Summary Export all account data
+// @Description Start generating a ZIP archive with the profile, login methods, all films, collections with their films, uploaded images, permissions and sessions of the user.
+// @Description The archive is generated in the background. Check its status at `/user/export/{export_id}` and download it within 24 hours when it is ready.
+// @Description Starting a new export deletes the previous ones.
+// @Tags user
+// @Accept json
+// @Produce json
+// @Success 202 {object} swagger.AccountExportResponse
+// @Failure 401 {object} swagger.ErrorResponse
+// @Failure 409 {object} swagger.ErrorResponse
+// @Failure 500 {object} swagger.ErrorResponse
+// @Security JWTAuth
+// @Router /user/export [post]
+func startAccountExportHandler(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(int)
+
+	export, err := startAccountExport(userID)
+	if err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	recordAuditEvent(r, auditEventUserExport, userID, map[string]any{"export_id": export.ID})
+
+	writeJSON(w, r, http.StatusAccepted, envelope{"export": export})
+}
+
+// GetAccountExport godoc
+// @Summary Get the status of an account export
+// @Description Get the status of an export: `pending`, `ready` or `failed`. A ready export can be downloaded until `expires_at`.
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param export_id path string true "Export ID"
+// @Success 200 {object} swagger.AccountExportResponse
+// @Failure 401 {object} swagger.ErrorResponse
+// @Failure 404 {object} swagger.ErrorResponse
+// @Failure 500 {object} swagger.ErrorResponse
+// @Security JWTAuth
+// @Router /user/export/{export_id} [get]
+func getAccountExportHandler(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(int)
+
+	export, err := postgres.GetAccountExport(userID, mux.Vars(r)["exportID"])
+	if err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, envelope{"export": export})
+}
+
+// DownloadAccountExport godoc
+// @Summary Download an account export
+// @Description Download the ZIP archive of a ready export. It contains `account.json` and the uploaded images of the films in the `images` folder.
+// @Tags user
+// @Produce application/zip
+// @Param export_id path string true "Export ID"
+// @Success 200 {file} file
+// @Failure 401 {object} swagger.ErrorResponse
+// @Failure 404 {object} swagger.ErrorResponse
+// @Failure 409 {object} swagger.ErrorResponse
+// @Failure 500 {object} swagger.ErrorResponse
+// @Security JWTAuth
+// @Router /user/export/{export_id}/download [get]
+func downloadAccountExportHandler(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(int)
+	exportID := mux.Vars(r)["exportID"]
+
+	export, err := postgres.GetAccountExport(userID, exportID)
+	if err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	if export.Status != models.AccountExportReady {
+		handleDBError(w, r, errExportNotReady)
+		return
+	}
+
+	archive, err := postgres.GetAccountExportArchive(userID, exportID)
+	if err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	filename := fmt.Sprintf("watchlist-export-%s.zip", export.CompletedAt.Format("2006-01-02"))
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.Header().Set("Content-Length", strconv.Itoa(len(archive)))
+	metrics.IncStatusCount(http.StatusOK)
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := w.Write(archive); err != nil {
+		slog.Error("failed to write account export", slog.Any("error", err), slog.String("export_id", exportID))
+	}
+}
@@ -0,0 +1,432 @@
+package rest
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/k4sper1love/watchlist-api/internal/database/postgres"
+	"github.com/k4sper1love/watchlist-api/pkg/filters"
+	"github.com/k4sper1love/watchlist-api/pkg/models"
+	"github.com/k4sper1love/watchlist-api/pkg/validator"
+	"io"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	maxAccountArchiveSize = 100 << 20 // Maximum size of an uploaded account archive.
+	maxAccountDataSize    = 50 << 20  // Maximum size of the account.json file in an archive.
+	maxArchiveImageSize   = 10 << 20  // Maximum size of an image in an archive, as for uploads.
+)
+
+// accountImportTimeout limits the transaction that restores an account archive.
+const accountImportTimeout = time.Minute
+
+// Kinds of conflicts found when restoring an account archive.
+const (
+	importConflictFilmExists        = "film_exists"        // A film with the same title and year exists.
+	importConflictCollectionExists  = "collection_exists"  // A collection with the same name exists.
+	importConflictInvalidFilm       = "invalid_film"       // A film of the archive is invalid.
+	importConflictInvalidCollection = "invalid_collection" // A collection of the archive is invalid.
+	importConflictMissingReference  = "missing_reference"  // A film in a collection is not in the archive.
+	importConflictPermissionSkipped = "permission_skipped" // A permission code is not restored.
+	importConflictImageMissing      = "image_missing"      // An image of a film is not in the archive.
+)
+
+// accountImport is an account archive checked against the account it is restored into.
+type accountImport struct {
+	archive     *models.AccountArchive
+	images      map[string][]byte // Image files of the archive by name.
+	films       []models.Film
+	collections []*models.Collection
+	links       []models.CollectionFilmLink
+	report      *models.AccountImportReport
+}
+
+// blocked reports whether the import has conflicts that stop it unless forced.
+func (i *accountImport) blocked() bool {
+	for _, c := range i.report.Conflicts {
+		if c.Blocking {
+			return true
+		}
+	}
+	return false
+}
+
+// addConflict adds a conflict to the report of the import.
+func (i *accountImport) addConflict(conflictType string, blocking bool, format string, args ...any) {
+	i.report.Conflicts = append(i.report.Conflicts, models.ImportConflict{
+		Type:     conflictType,
+		Message:  fmt.Sprintf(format, args...),
+		Blocking: blocking,
+	})
+}
+
+// readAccountArchive reads the account data and the images from a ZIP archive created by an export.
+func readAccountArchive(data []byte) (*models.AccountArchive, map[string][]byte, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", errInvalidArchive, err)
+	}
+
+	var archive *models.AccountArchive
+	images := make(map[string][]byte)
+
+	for _, file := range zr.File {
+		switch {
+		case file.Name == accountArchiveFile:
+			content, err := readZipFile(file, maxAccountDataSize)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			archive = &models.AccountArchive{}
+			if err := json.Unmarshal(content, archive); err != nil {
+				return nil, nil, fmt.Errorf("%w: %s: %v", errInvalidArchive, accountArchiveFile, err)
+			}
+		case strings.HasPrefix(file.Name, "images/"):
+			name := path.Base(file.Name)
+			if file.Name != "images/"+name || name == "." || strings.HasPrefix(name, ".") {
+				continue
+			}
+
+			content, err := readZipFile(file, maxArchiveImageSize)
+			if err != nil {
+				return nil, nil, err
+			}
+			images[name] = content
+		}
+	}
+
+	if archive == nil {
+		return nil, nil, fmt.Errorf("%w: %s is missing", errInvalidArchive, accountArchiveFile)
+	}
+
+	if archive.Version != models.AccountArchiveVersion {
+		return nil, nil, fmt.Errorf("%w: unsupported version %d", errInvalidArchive, archive.Version)
+	}
+
+	return archive, images, nil
+}
+
+// readZipFile reads a file of an archive, refusing files larger than the limit.
+func readZipFile(file *zip.File, limit int64) ([]byte, error) {
+	rc, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", errInvalidArchive, file.Name, err)
+	}
+	defer rc.Close()
+
+	content, err := io.ReadAll(io.LimitReader(rc, limit+1))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", errInvalidArchive, file.Name, err)
+	}
+
+	if int64(len(content)) > limit {
+		return nil, fmt.Errorf("%w: %s is too large", errInvalidArchive, file.Name)
+	}
+
+	return content, nil
+}
+
+// planAccountImport checks an archive against the account of the user and reports what restoring it creates
+// and the conflicts found, without changing anything.
+func planAccountImport(userID int, archive *models.AccountArchive, images map[string][]byte) (*accountImport, error) {
+	imp := &accountImport{
+		archive: archive,
+		images:  images,
+		report:  &models.AccountImportReport{Conflicts: []models.ImportConflict{}},
+	}
+
+	existingFilms, _, err := postgres.GetFilms(userID, &models.FilmsQueryInput{Filters: filters.Unpaged("id"), ExcludeCollection: -1})
+	if err != nil {
+		return nil, err
+	}
+
+	existingCollections, _, err := postgres.GetCollections(userID, "", -1, -1, false, filters.Unpaged("id"))
+	if err != nil {
+		return nil, err
+	}
+
+	filmKeys := make(map[string]bool)
+	for _, f := range existingFilms {
+		filmKeys[filmKey(&f)] = true
+	}
+
+	collectionNames := make(map[string]bool)
+	for _, c := range existingCollections {
+		collectionNames[strings.ToLower(c.Name)] = true
+	}
+
+	// Invalid films and collections are never restored, so they only count as seen, not as restored.
+	seenFilms, filmIDs := make(map[int]bool), make(map[int]bool)
+	restoredImages := make(map[string]bool)
+	for _, film := range archive.Films {
+		if seenFilms[film.ID] {
+			imp.addConflict(importConflictInvalidFilm, true, "film ID %d is used more than once", film.ID)
+			continue
+		}
+		seenFilms[film.ID] = true
+
+		if errs := validator.ValidateStruct(&film); errs != nil {
+			imp.addConflict(importConflictInvalidFilm, true, "film %q is invalid: %v", film.Title, errs)
+			continue
+		}
+
+		if filmKeys[filmKey(&film)] {
+			imp.addConflict(importConflictFilmExists, true, "film %q (%d) already exists", film.Title, film.Year)
+		}
+
+		if name, ok := archive.Images[film.ImageURL]; ok {
+			if _, found := images[name]; found {
+				restoredImages[name] = true
+			} else {
+				imp.addConflict(importConflictImageMissing, false, "image %s of film %q is not in the archive; the default image is used", name, film.Title)
+			}
+		}
+
+		filmIDs[film.ID] = true
+		imp.films = append(imp.films, film)
+	}
+
+	seenCollections, collectionIDs := make(map[int]bool), make(map[int]bool)
+	for _, collection := range archive.Collections {
+		if collection == nil {
+			continue
+		}
+
+		if seenCollections[collection.ID] {
+			imp.addConflict(importConflictInvalidCollection, true, "collection ID %d is used more than once", collection.ID)
+			continue
+		}
+		seenCollections[collection.ID] = true
+
+		if errs := validator.ValidateStruct(collection); errs != nil {
+			imp.addConflict(importConflictInvalidCollection, true, "collection %q is invalid: %v", collection.Name, errs)
+			continue
+		}
+
+		if collectionNames[strings.ToLower(collection.Name)] {
+			imp.addConflict(importConflictCollectionExists, true, "collection %q already exists", collection.Name)
+		}
+
+		collectionIDs[collection.ID] = true
+		imp.collections = append(imp.collections, collection)
+	}
+
+	seenLinks := make(map[[2]int]bool)
+	for _, link := range archive.CollectionFilms {
+		if !filmIDs[link.FilmID] || !collectionIDs[link.CollectionID] {
+			imp.addConflict(importConflictMissingReference, false, "film %d in collection %d is not in the archive and is skipped", link.FilmID, link.CollectionID)
+			continue
+		}
+
+		key := [2]int{link.CollectionID, link.FilmID}
+		if seenLinks[key] {
+			continue
+		}
+		seenLinks[key] = true
+
+		imp.links = append(imp.links, link)
+	}
+
+	// Permission codes are never granted from an archive, which the user can edit; only admins grant them.
+	for _, code := range archive.Permissions {
+		if isRestoredObjectPermission(code, "film", filmIDs) || isRestoredObjectPermission(code, "collection", collectionIDs) {
+			continue // Implied by owning the restored object.
+		}
+		imp.addConflict(importConflictPermissionSkipped, false, "permission %s is not restored", code)
+	}
+
+	imp.report.Images = len(restoredImages)
+	imp.report.Films = len(imp.films)
+	imp.report.Collections = len(imp.collections)
+	imp.report.CollectionFilms = len(imp.links)
+
+	return imp, nil
+}
+
+// filmKey identifies films that are considered the same: equal titles, ignoring case, and years.
+func filmKey(f *models.Film) string {
+	return fmt.Sprintf("%s\x00%d", strings.ToLower(f.Title), f.Year)
+}
+
+// isRestoredObjectPermission reports whether a permission code refers to an object of the archive,
+// such as "film:12:read" for the film with ID 12.
+func isRestoredObjectPermission(code, objectType string, ids map[int]bool) bool {
+	parts := strings.Split(code, ":")
+	if len(parts) != 3 || parts[0] != objectType {
+		return false
+	}
+
+	id, err := strconv.Atoi(parts[1])
+	return err == nil && ids[id]
+}
+
+// checkImportPermissions checks that the user may create the films and collections of the import,
+// as when creating them one by one.
+func checkImportPermissions(userID int, imp *accountImport) error {
+	required := map[string]bool{
+		"film:create":       len(imp.films) > 0,
+		"collection:create": len(imp.collections) > 0,
+	}
+
+	for code, needed := range required {
+		if !needed {
+			continue
+		}
+
+		ok, err := postgres.HasPermission(userID, code)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errImportNotPermitted
+		}
+	}
+
+	return nil
+}
+
+// restoreAccount restores the planned import into the account of the user in a single transaction.
+// Films and collections get new IDs, and the films in collections refer to them.
+// Images are saved as new uploads and the films refer to their new URLs.
+func restoreAccount(userID int, imp *accountImport, host string) error {
+	imageURLs, savedFiles, err := saveArchiveImages(imp, host)
+	if err != nil {
+		return err
+	}
+
+	if err := restoreAccountData(userID, imp, imageURLs, host); err != nil {
+		// The images are not referenced by any film after a rollback.
+		for _, file := range savedFiles {
+			if err := os.Remove(file); err != nil {
+				slog.Warn("failed to remove image of failed import", slog.Any("error", err), slog.String("file", file))
+			}
+		}
+		return err
+	}
+
+	return nil
+}
+
+// restoreAccountData inserts the films, collections and films in collections of the import.
+func restoreAccountData(userID int, imp *accountImport, imageURLs map[string]string, host string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), accountImportTimeout)
+	defer cancel()
+
+	tx, err := postgres.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	filmIDs := make(map[int]int)
+	collectionIDs := make(map[int]int)
+
+	for _, film := range imp.films {
+		oldID := film.ID
+		film.UserID = userID
+		film.ImageURL = restoredImageURL(imp, film.ImageURL, imageURLs, host)
+		setDefaultTimes(&film.CreatedAt, &film.UpdatedAt, now)
+
+		if err := postgres.RestoreFilm(tx, &film); err != nil {
+			return err
+		}
+		filmIDs[oldID] = film.ID
+	}
+
+	for _, archived := range imp.collections {
+		collection := *archived
+		collection.UserID = userID
+		setDefaultTimes(&collection.CreatedAt, &collection.UpdatedAt, now)
+
+		if err := postgres.RestoreCollection(tx, &collection); err != nil {
+			return err
+		}
+		collectionIDs[archived.ID] = collection.ID
+	}
+
+	for _, link := range imp.links {
+		link.CollectionID = collectionIDs[link.CollectionID]
+		link.FilmID = filmIDs[link.FilmID]
+		setDefaultTimes(&link.AddedAt, &link.UpdatedAt, now)
+
+		if err := postgres.RestoreCollectionFilm(tx, &link); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// saveArchiveImages saves the images of the films as new uploads. It returns the new URLs by the archived
+// image URLs and the paths of the saved files.
+func saveArchiveImages(imp *accountImport, host string) (map[string]string, []string, error) {
+	imageURLs := make(map[string]string)
+	var savedFiles []string
+
+	for _, film := range imp.films {
+		name, ok := imp.archive.Images[film.ImageURL]
+		if !ok {
+			continue
+		}
+
+		if _, done := imageURLs[film.ImageURL]; done {
+			continue
+		}
+
+		content, found := imp.images[name]
+		if !found {
+			continue
+		}
+
+		filename := fmt.Sprintf("%d_%s%s", time.Now().UnixNano(), generateString(5), filepath.Ext(name))
+		filePath := filepath.Join("static/images", filename)
+
+		if err := os.WriteFile(filePath, content, 0644); err != nil {
+			for _, file := range savedFiles {
+				os.Remove(file)
+			}
+			return nil, nil, fmt.Errorf("error saving image: %v", err)
+		}
+
+		savedFiles = append(savedFiles, filePath)
+		imageURLs[film.ImageURL] = fmt.Sprintf("http://%s/images/%s", host, filename)
+	}
+
+	return imageURLs, savedFiles, nil
+}
+
+// restoredImageURL returns the image URL of a restored film: the URL of the saved image for an uploaded image,
+// the default image if the uploaded image was not in the archive or the film had none, and the URL itself otherwise.
+func restoredImageURL(imp *accountImport, imageURL string, imageURLs map[string]string, host string) string {
+	if url, ok := imageURLs[imageURL]; ok {
+		return url
+	}
+
+	if _, uploaded := imp.archive.Images[imageURL]; uploaded || imageURL == "" {
+		return fmt.Sprintf("http://%s/images/default.png", host)
+	}
+
+	return imageURL
+}
+
+// setDefaultTimes sets missing timestamps of an archived object to the given time.
+func setDefaultTimes(createdAt, updatedAt *time.Time, now time.Time) {
+	if createdAt.IsZero() {
+		*createdAt = now
+	}
+
+	if updatedAt.IsZero() {
+		*updatedAt = *createdAt
+	}
+}
@@ -0,0 +1,97 @@
+package rest
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ImportAccount godoc
+// @Summary Restore an account archive
+// @Description Restore the films, collections with their films and uploaded images of an archive from `/user/export` into the account. The account may be empty or already have data.
+// @Description Films and collections get new IDs, and all references are updated. The profile, login methods and sessions of the archive are not restored.
+// @Description Permissions are never taken from the archive, and the user needs the `film:create` and `collection:create` permissions to restore films and collections. The restored films and collections are owned by the user as if they were created.
+// @Description With `dry_run`, nothing is changed and the report lists what would be restored and the conflicts found. Blocking conflicts, such as films or collections that already exist, stop the restore unless `force` is set.
+// @Description The whole restore runs in one transaction.
+// @Tags user
+// @Accept multipart/form-data
+// @Produce json
+// @Param archive formData file true "Account archive (ZIP)"
+// @Param dry_run query bool false "Only check the archive and report the conflicts"
+// @Param force query bool false "Restore despite blocking conflicts, creating duplicates"
+// @Success 200 {object} swagger.AccountImportResponse
+// @Success 201 {object} swagger.AccountImportResponse
+// @Failure 400 {object} swagger.ErrorResponse
+// @Failure 401 {object} swagger.ErrorResponse
+// @Failure 403 {object} swagger.ErrorResponse
+// @Failure 409 {object} swagger.AccountImportConflictResponse
+// @Failure 500 {object} swagger.ErrorResponse
+// @Security JWTAuth
+// @Router /user/import [post]
+func importAccountHandler(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(int)
+
+	qs := r.URL.Query()
+	dryRun := parseQueryBool(qs, "dry_run", false)
+	force := parseQueryBool(qs, "force", false)
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxAccountArchiveSize)
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		badRequestResponse(w, r, fmt.Errorf("error parsing the form: %v", err))
+		return
+	}
+
+	archiveFile, _, err := r.FormFile("archive")
+	if err != nil {
+		badRequestResponse(w, r, fmt.Errorf("error receiving the file: %v", err))
+		return
+	}
+	defer archiveFile.Close()
+
+	data, err := io.ReadAll(archiveFile)
+	if err != nil {
+		badRequestResponse(w, r, fmt.Errorf("error reading the file: %v", err))
+		return
+	}
+
+	archive, images, err := readAccountArchive(data)
+	if err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	imp, err := planAccountImport(userID, archive, images)
+	if err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	imp.report.DryRun = dryRun
+	if dryRun {
+		writeJSON(w, r, http.StatusOK, envelope{"import": imp.report})
+		return
+	}
+
+	if err := checkImportPermissions(userID, imp); err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	if imp.blocked() && !force {
+		writeJSON(w, r, http.StatusConflict, envelope{"error": errImportConflicts.Error(), "import": imp.report})
+		return
+	}
+
+	if err := restoreAccount(userID, imp, r.Host); err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	recordAuditEvent(r, auditEventUserImport, userID, map[string]any{
+		"films":       imp.report.Films,
+		"collections": imp.report.Collections,
+		"forced":      force && imp.blocked(),
+	})
+
+	writeJSON(w, r, http.StatusCreated, envelope{"import": imp.report})
+}
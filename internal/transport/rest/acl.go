@@ -0,0 +1,213 @@
+package rest
+
+import (
+	"github.com/k4sper1love/watchlist-api/internal/database/postgres"
+	"github.com/k4sper1love/watchlist-api/pkg/models"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// aclActions are the actions on films and collections that can be granted to users who do not own them.
+// Other actions, such as sharing a film or collection, are allowed only to owners.
+var aclActions = []string{"read", "update", "delete"}
+
+// accessCheck describes a permission required for a request: either an action on a film or collection,
+// or an account-wide permission code.
+type accessCheck struct {
+	resourceType string
+	resourceID   string
+	action       string
+	code         string
+}
+
+// objectAccess requires an action on a film or collection, identified by a route parameter.
+func objectAccess(resourceType, resourceID, action string) accessCheck {
+	return accessCheck{resourceType: resourceType, resourceID: resourceID, action: action}
+}
+
+// codeAccess requires an account-wide permission code.
+func codeAccess(code string) accessCheck {
+	return accessCheck{code: code}
+}
+
+// allowed checks whether the user passes the check with a single query.
+func (c accessCheck) allowed(userID int) (bool, error) {
+	if c.code != "" {
+		return postgres.HasPermission(userID, c.code)
+	}
+
+	id, err := strconv.Atoi(c.resourceID)
+	if err != nil {
+		return false, nil
+	}
+
+	return postgres.HasAccess(userID, c.resourceType, id, c.action)
+}
+
+// parseObjectPermission parses a permission code of a film or collection, such as film:1:read, into an ACL entry.
+// It returns false for account-wide codes, such as film:create.
+func parseObjectPermission(userID int, code string) (*models.ACLEntry, bool) {
+	parts := strings.Split(code, ":")
+	if len(parts) != 3 || (parts[0] != "film" && parts[0] != "collection") {
+		return nil, false
+	}
+
+	id, err := strconv.Atoi(parts[1])
+	if err != nil || id < 1 || !postgres.Permissions(aclActions).Include(parts[2]) {
+		return nil, false
+	}
+
+	return &models.ACLEntry{ResourceType: parts[0], ResourceID: id, UserID: userID, Action: parts[2]}, true
+}
+
+// addPermissionsBlock adds the actions the user may perform on a resource to the response data as the _permissions block
+// if the request asks for it with include_permissions=true.
+func addPermissionsBlock(r *http.Request, data envelope, resourceType string, resourceID int) error {
+	if !parseQueryBool(r.URL.Query(), "include_permissions", false) {
+		return nil
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	actions, err := postgres.GetAllowedActions(userID, resourceType, resourceID)
+	if err != nil {
+		return err
+	}
+
+	data["_permissions"] = models.ResourcePermissions{Actions: scopeActions(r, actions)}
+	return nil
+}
+
+// getPermissionsBlocks retrieves the _permissions blocks of the resources in a list with a single query
+// if the request asks for them with include_permissions=true. It returns nil otherwise.
+func getPermissionsBlocks(r *http.Request, resourceType string, resourceIDs []int) (map[int]*models.ResourcePermissions, error) {
+	if !parseQueryBool(r.URL.Query(), "include_permissions", false) {
+		return nil, nil
+	}
+
+	userID := r.Context().Value("userID").(int)
+
+	actions, err := postgres.GetAllowedActionsBatch(userID, resourceType, resourceIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks := make(map[int]*models.ResourcePermissions, len(actions))
+	for id, allowed := range actions {
+		blocks[id] = &models.ResourcePermissions{Actions: scopeActions(r, allowed)}
+	}
+
+	return blocks, nil
+}
+
+// scopeActions drops the actions that the API key of the request cannot perform, even if the user may.
+// Read-only keys can only read. Requests authenticated with a token keep all actions.
+func scopeActions(r *http.Request, actions []string) []string {
+	scope, ok := r.Context().Value("apiKeyScope").(string)
+	if !ok || scope == models.APIKeyScopeReadWrite {
+		return actions
+	}
+
+	allowed := []string{}
+	for _, action := range actions {
+		if action == "read" {
+			allowed = append(allowed, action)
+		}
+	}
+
+	return allowed
+}
+
+// scopePermissions drops the permission codes that the API key of the request cannot use, as scopeActions does.
+func scopePermissions(r *http.Request, permissions postgres.Permissions) postgres.Permissions {
+	scope, ok := r.Context().Value("apiKeyScope").(string)
+	if !ok || scope == models.APIKeyScopeReadWrite {
+		return permissions
+	}
+
+	allowed := postgres.Permissions{}
+	for _, code := range permissions {
+		if strings.HasSuffix(code, ":read") {
+			allowed = append(allowed, code)
+		}
+	}
+
+	return allowed
+}
+
+// filmWithPermissions is a film in a list with the actions the user may perform on it.
+type filmWithPermissions struct {
+	models.Film
+	Permissions *models.ResourcePermissions `json:"_permissions"`
+}
+
+// collectionWithPermissions is a collection in a list with the actions the user may perform on it.
+type collectionWithPermissions struct {
+	*models.Collection
+	Permissions *models.ResourcePermissions `json:"_permissions"`
+}
+
+// addFilmsPermissions adds the _permissions blocks to the films if the request asks for them.
+// It returns the films unchanged otherwise.
+func addFilmsPermissions(r *http.Request, films []models.Film) (any, error) {
+	ids := make([]int, len(films))
+	for i, film := range films {
+		ids[i] = film.ID
+	}
+
+	blocks, err := getPermissionsBlocks(r, "film", ids)
+	if err != nil || blocks == nil {
+		return films, err
+	}
+
+	result := make([]filmWithPermissions, len(films))
+	for i, film := range films {
+		result[i] = filmWithPermissions{Film: film, Permissions: blocks[film.ID]}
+	}
+
+	return result, nil
+}
+
+// addCollectionsPermissions adds the _permissions blocks to the collections if the request asks for them.
+// It returns the collections unchanged otherwise.
+func addCollectionsPermissions(r *http.Request, collections []*models.Collection) (any, error) {
+	ids := make([]int, len(collections))
+	for i, collection := range collections {
+		ids[i] = collection.ID
+	}
+
+	blocks, err := getPermissionsBlocks(r, "collection", ids)
+	if err != nil || blocks == nil {
+		return collections, err
+	}
+
+	result := make([]collectionWithPermissions, len(collections))
+	for i, collection := range collections {
+		result[i] = collectionWithPermissions{Collection: collection, Permissions: blocks[collection.ID]}
+	}
+
+	return result, nil
+}
+
+// parseResourceQuery parses the resource and id query parameters of a film, collection or group.
+func parseResourceQuery(qs url.Values) (string, int, map[string]string) {
+	errs := make(map[string]string)
+
+	resource := parseQueryString(qs, "resource", "")
+	if resource != "film" && resource != "collection" && resource != "group" {
+		errs["resource"] = "must be one of: film collection group"
+	}
+
+	id := parseQueryInt(qs, "id", 0)
+	if id < 1 {
+		errs["id"] = "must be a positive integer"
+	}
+
+	if len(errs) > 0 {
+		return "", 0, errs
+	}
+
+	return resource, id, nil
+}
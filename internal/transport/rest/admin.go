@@ -0,0 +1,145 @@
+package rest
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/k4sper1love/watchlist-api/internal/config"
+	"github.com/k4sper1love/watchlist-api/internal/database/postgres"
+	"github.com/k4sper1love/watchlist-api/pkg/models"
+	"log/slog"
+)
+
+// adminPermission is the global permission code of the admin role.
+const adminPermission = "admin:*"
+
+// Names of recorded admin actions.
+const (
+	adminActionListUsers         = "users.list"
+	adminActionViewUser          = "user.view"
+	adminActionViewFilms         = "user.films.view"
+	adminActionViewCollections   = "user.collections.view"
+	adminActionViewPermissions   = "user.permissions.view"
+	adminActionSuspend           = "user.suspend"
+	adminActionUnsuspend         = "user.unsuspend"
+	adminActionLogout            = "user.logout"
+	adminActionGrantPermissions  = "user.permissions.grant"
+	adminActionRevokePermissions = "user.permissions.revoke"
+)
+
+// initAdmin grants the admin role to the user configured by username, so the first admin does not have to be created in the database.
+func initAdmin() error {
+	if config.AdminUsername == "" {
+		return nil
+	}
+
+	user, err := postgres.GetUserByUsername(config.AdminUsername)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			slog.Warn("admin user not found", slog.String("username", config.AdminUsername))
+			return nil
+		}
+		return err
+	}
+
+	if err := postgres.AddUserPermissions(user.ID, adminPermission); err != nil {
+		return err
+	}
+
+	slog.Info("admin role granted", slog.String("username", user.Username), slog.Int("user_id", user.ID))
+	return nil
+}
+
+// recordAdminAction records an action performed by an admin. A failure is logged and does not fail the request.
+func recordAdminAction(adminID int, action string, targetUserID int, details map[string]any) {
+	adminAction := &models.AdminAction{
+		AdminID:      adminID,
+		Action:       action,
+		TargetUserID: targetUserID,
+		Details:      details,
+	}
+
+	if err := postgres.AddAdminAction(adminAction); err != nil {
+		slog.Error("failed to record admin action", slog.Any("error", err), slog.Int("admin_id", adminID), slog.String("action", action))
+	}
+}
+
+// suspendUser suspends a user and logs them out. A suspended user cannot log in or use API keys.
+func suspendUser(adminID, userID int) error {
+	if adminID == userID {
+		return errAdminSelfAction
+	}
+
+	if err := postgres.SetUserSuspended(userID, true); err != nil {
+		return err
+	}
+
+	return forceLogout(userID)
+}
+
+// forceLogout revokes all refresh and access tokens of a user.
+func forceLogout(userID int) error {
+	if err := postgres.RevokeUserRefreshTokens(userID); err != nil {
+		return err
+	}
+
+	return revokeUserAccessTokens(userID)
+}
+
+// grantPermissions grants permission codes to a user, creating codes that do not exist yet.
+// Codes of films and collections, such as film:1:read, are granted as ACL entries.
+func grantPermissions(userID int, codes []string) error {
+	if _, err := postgres.GetUserById(userID); err != nil {
+		return err
+	}
+
+	var accountCodes []string
+	for _, code := range codes {
+		if entry, ok := parseObjectPermission(userID, code); ok {
+			if err := postgres.AddACLEntry(entry); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := postgres.AddPermission(code); err != nil {
+			return err
+		}
+		accountCodes = append(accountCodes, code)
+	}
+
+	if len(accountCodes) == 0 {
+		return nil
+	}
+
+	return postgres.AddUserPermissions(userID, accountCodes...)
+}
+
+// revokePermissions revokes permission codes from a user. Admins cannot revoke their own admin role.
+// Codes of films and collections revoke ACL entries; access of owners cannot be revoked.
+func revokePermissions(adminID, userID int, codes []string) error {
+	if adminID == userID && postgres.Permissions(codes).Include(adminPermission) {
+		return errAdminSelfAction
+	}
+
+	if _, err := postgres.GetUserById(userID); err != nil {
+		return err
+	}
+
+	var accountCodes []string
+	for _, code := range codes {
+		if entry, ok := parseObjectPermission(userID, code); ok {
+			if err := postgres.DeleteACLEntry(entry); err != nil {
+				return err
+			}
+			continue
+		}
+
+		accountCodes = append(accountCodes, code)
+	}
+
+	if len(accountCodes) == 0 {
+		return nil
+	}
+
+	return postgres.DeleteUserPermissions(userID, accountCodes...)
+}
@@ -0,0 +1,514 @@
+package rest
+
+import (
+	"github.com/k4sper1love/watchlist-api/internal/database/postgres"
+	"github.com/k4sper1love/watchlist-api/pkg/filters"
+	"github.com/k4sper1love/watchlist-api/pkg/models"
+	"github.com/k4sper1love/watchlist-api/pkg/validator"
+	"net/http"
+)
+
+// usersQueryInput holds the parameters for searching users.
+type usersQueryInput struct {
+	Search string
+	filters.Filters
+}
+
+// adminActionsQueryInput holds the parameters for querying admin actions.
+type adminActionsQueryInput struct {
+	UserID int
+	filters.Filters
+}
+
+// GetAdminUsers godoc
+// @Summary Search users
+// @Description Get a list of users, optionally searched by username or email. You must be an admin.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param q query string false "Search by `username` or `email`"
+// @Param page query int false "Specify the desired `page`"
+// @Param page_size query int false "Specify the desired `page size`"
+// @Param sort query string false "Sorting by `id`, `username`, `created_at`. Use `-` for desc"
+// @Success 200 {object} swagger.UsersResponse
+// @Failure 401 {object} swagger.ErrorResponse
+// @Failure 403 {object} swagger.ErrorResponse
+// @Failure 422 {object} swagger.ErrorResponse
+// @Failure 500 {object} swagger.ErrorResponse
+// @Security JWTAuth
+// @Router /admin/users [get]
+func getAdminUsersHandler(w http.ResponseWriter, r *http.Request) {
+	adminID := r.Context().Value("userID").(int)
+
+	input, errs, err := parseAndValidateUsersFilters(r)
+	if err != nil {
+		serverErrorResponse(w, r, err)
+		return
+	}
+	if errs != nil {
+		failedValidationResponse(w, r, errs)
+		return
+	}
+
+	users, metadata, err := postgres.SearchUsers(input.Search, input.Filters)
+	if err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	recordAdminAction(adminID, adminActionListUsers, 0, map[string]any{"q": input.Search})
+
+	writeJSON(w, r, http.StatusOK, envelope{"users": users, "metadata": metadata})
+}
+
+// GetAdminUser godoc
+// @Summary Get user by ID
+// @Description Get the user by ID, including whether the account is suspended. You must be an admin.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param user_id path int true "User ID"
+// @Success 200 {object} swagger.UserResponse
+// @Failure 400 {object} swagger.ErrorResponse
+// @Failure 401 {object} swagger.ErrorResponse
+// @Failure 403 {object} swagger.ErrorResponse
+// @Failure 404 {object} swagger.ErrorResponse
+// @Failure 500 {object} swagger.ErrorResponse
+// @Security JWTAuth
+// @Router /admin/users/{user_id} [get]
+func getAdminUserHandler(w http.ResponseWriter, r *http.Request) {
+	adminID := r.Context().Value("userID").(int)
+
+	userID, err := parseIDParam(r, "userID")
+	if err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	user, err := postgres.GetUserById(userID)
+	if err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	user.Password = "" // Clear the password before returning.
+
+	recordAdminAction(adminID, adminActionViewUser, userID, nil)
+
+	writeJSON(w, r, http.StatusOK, envelope{"user": user})
+}
+
+// GetAdminUserFilms godoc
+// @Summary Get films of the user
+// @Description Get a list of films of the user with the same filters as `/films`. You must be an admin.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param user_id path int true "User ID"
+// @Param title query string false "Filter by `title`"
+// @Param page query int false "Specify the desired `page`"
+// @Param page_size query int false "Specify the desired `page size`"
+// @Param sort query string false "Sorting by `id`, `title`, `rating`, `year`, `user_rating`, `is_viewed`. Use `-` for desc"
+// @Success 200 {object} swagger.FilmsResponse
+// @Failure 400 {object} swagger.ErrorResponse
+// @Failure 401 {object} swagger.ErrorResponse
+// @Failure 403 {object} swagger.ErrorResponse
+// @Failure 422 {object} swagger.ErrorResponse
+// @Failure 500 {object} swagger.ErrorResponse
+// @Security JWTAuth
+// @Router /admin/users/{user_id}/films [get]
+func getAdminUserFilmsHandler(w http.ResponseWriter, r *http.Request) {
+	adminID := r.Context().Value("userID").(int)
+
+	userID, err := parseIDParam(r, "userID")
+	if err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	input, errs, err := parseAndValidateFilmsFilters(r)
+	if err != nil {
+		serverErrorResponse(w, r, err)
+		return
+	}
+	if errs != nil {
+		failedValidationResponse(w, r, errs)
+		return
+	}
+
+	films, metadata, err := postgres.GetFilms(userID, input)
+	if err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	recordAdminAction(adminID, adminActionViewFilms, userID, nil)
+
+	writeJSON(w, r, http.StatusOK, envelope{"films": films, "metadata": metadata})
+}
+
+// GetAdminUserCollections godoc
+// @Summary Get collections of the user
+// @Description Get a list of collections of the user with the same filters as `/collections`. You must be an admin.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param user_id path int true "User ID"
+// @Param name query string false "Filter by `name`"
+// @Param page query int false "Specify the desired `page`"
+// @Param page_size query int false "Specify the desired `page size`"
+// @Param sort query string false "Sorting by `id`, `name`, `created_at, total_films`. Use `-` for desc"
+// @Success 200 {object} swagger.CollectionsResponse
+// @Failure 400 {object} swagger.ErrorResponse
+// @Failure 401 {object} swagger.ErrorResponse
+// @Failure 403 {object} swagger.ErrorResponse
+// @Failure 422 {object} swagger.ErrorResponse
+// @Failure 500 {object} swagger.ErrorResponse
+// @Security JWTAuth
+// @Router /admin/users/{user_id}/collections [get]
+func getAdminUserCollectionsHandler(w http.ResponseWriter, r *http.Request) {
+	adminID := r.Context().Value("userID").(int)
+
+	userID, err := parseIDParam(r, "userID")
+	if err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	input, errs, err := parseAndValidateCollectionsFilters(r)
+	if err != nil {
+		serverErrorResponse(w, r, err)
+		return
+	}
+	if errs != nil {
+		failedValidationResponse(w, r, errs)
+		return
+	}
+
+	collections, metadata, err := postgres.GetCollections(userID, input.Name, input.Film, input.ExcludeFilm, false, input.Filters)
+	if err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	recordAdminAction(adminID, adminActionViewCollections, userID, nil)
+
+	writeJSON(w, r, http.StatusOK, envelope{"collections": collections, "metadata": metadata})
+}
+
+// SuspendUser godoc
+// @Summary Suspend the user
+// @Description Suspend the user by ID and revoke all its tokens. A suspended user cannot log in or use API keys. You must be an admin.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param user_id path int true "User ID"
+// @Success 200 {object} swagger.MessageResponse
+// @Failure 400 {object} swagger.ErrorResponse
+// @Failure 401 {object} swagger.ErrorResponse
+// @Failure 403 {object} swagger.ErrorResponse
+// @Failure 404 {object} swagger.ErrorResponse
+// @Failure 500 {object} swagger.ErrorResponse
+// @Security JWTAuth
+// @Router /admin/users/{user_id}/suspend [post]
+func suspendUserHandler(w http.ResponseWriter, r *http.Request) {
+	adminID := r.Context().Value("userID").(int)
+
+	userID, err := parseIDParam(r, "userID")
+	if err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	if err := suspendUser(adminID, userID); err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	recordAdminAction(adminID, adminActionSuspend, userID, nil)
+
+	writeJSON(w, r, http.StatusOK, envelope{"message": "user suspended"})
+}
+
+// UnsuspendUser godoc
+// @Summary Unsuspend the user
+// @Description Lift the suspension of the user by ID. You must be an admin.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param user_id path int true "User ID"
+// @Success 200 {object} swagger.MessageResponse
+// @Failure 400 {object} swagger.ErrorResponse
+// @Failure 401 {object} swagger.ErrorResponse
+// @Failure 403 {object} swagger.ErrorResponse
+// @Failure 404 {object} swagger.ErrorResponse
+// @Failure 500 {object} swagger.ErrorResponse
+// @Security JWTAuth
+// @Router /admin/users/{user_id}/unsuspend [post]
+func unsuspendUserHandler(w http.ResponseWriter, r *http.Request) {
+	adminID := r.Context().Value("userID").(int)
+
+	userID, err := parseIDParam(r, "userID")
+	if err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	if err := postgres.SetUserSuspended(userID, false); err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	recordAdminAction(adminID, adminActionUnsuspend, userID, nil)
+
+	writeJSON(w, r, http.StatusOK, envelope{"message": "user unsuspended"})
+}
+
+// LogoutUser godoc
+// @Summary Log the user out
+// @Description Revoke all refresh and access tokens of the user by ID. You must be an admin.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param user_id path int true "User ID"
+// @Success 200 {object} swagger.MessageResponse
+// @Failure 400 {object} swagger.ErrorResponse
+// @Failure 401 {object} swagger.ErrorResponse
+// @Failure 403 {object} swagger.ErrorResponse
+// @Failure 404 {object} swagger.ErrorResponse
+// @Failure 500 {object} swagger.ErrorResponse
+// @Security JWTAuth
+// @Router /admin/users/{user_id}/logout [post]
+func logoutUserHandler(w http.ResponseWriter, r *http.Request) {
+	adminID := r.Context().Value("userID").(int)
+
+	userID, err := parseIDParam(r, "userID")
+	if err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	if _, err := postgres.GetUserById(userID); err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	if err := forceLogout(userID); err != nil {
+		serverErrorResponse(w, r, err)
+		return
+	}
+
+	recordAdminAction(adminID, adminActionLogout, userID, nil)
+
+	writeJSON(w, r, http.StatusOK, envelope{"message": "user logged out"})
+}
+
+// GetUserPermissions godoc
+// @Summary Get permissions of the user
+// @Description Get the permission codes of the user by ID. You must be an admin.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param user_id path int true "User ID"
+// @Success 200 {object} swagger.PermissionsResponse
+// @Failure 400 {object} swagger.ErrorResponse
+// @Failure 401 {object} swagger.ErrorResponse
+// @Failure 403 {object} swagger.ErrorResponse
+// @Failure 404 {object} swagger.ErrorResponse
+// @Failure 500 {object} swagger.ErrorResponse
+// @Security JWTAuth
+// @Router /admin/users/{user_id}/permissions [get]
+func getUserPermissionsHandler(w http.ResponseWriter, r *http.Request) {
+	adminID := r.Context().Value("userID").(int)
+
+	userID, err := parseIDParam(r, "userID")
+	if err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	if _, err := postgres.GetUserById(userID); err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	permissions, err := postgres.GetUserPermissions(userID)
+	if err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	recordAdminAction(adminID, adminActionViewPermissions, userID, nil)
+
+	writeJSON(w, r, http.StatusOK, envelope{"permissions": permissions})
+}
+
+// GrantUserPermissions godoc
+// @Summary Grant permissions to the user
+// @Description Grant permission codes, such as `film:create` or `admin:*`, to the user by ID. Codes of films and collections, such as `film:1:read`, give access to an object the user does not own; the object must exist. You must be an admin.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param user_id path int true "User ID"
+// @Param data body swagger.PermissionCodesRequest true "Permission codes"
+// @Success 200 {object} swagger.MessageResponse
+// @Failure 400 {object} swagger.ErrorResponse
+// @Failure 401 {object} swagger.ErrorResponse
+// @Failure 403 {object} swagger.ErrorResponse
+// @Failure 404 {object} swagger.ErrorResponse
+// @Failure 422 {object} swagger.ErrorResponse
+// @Failure 500 {object} swagger.ErrorResponse
+// @Security JWTAuth
+// @Router /admin/users/{user_id}/permissions [post]
+func grantUserPermissionsHandler(w http.ResponseWriter, r *http.Request) {
+	adminID := r.Context().Value("userID").(int)
+
+	userID, input, ok := parsePermissionCodesRequest(w, r)
+	if !ok {
+		return
+	}
+
+	if err := grantPermissions(userID, input.Codes); err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	recordAdminAction(adminID, adminActionGrantPermissions, userID, map[string]any{"codes": input.Codes})
+	recordAuditEvent(r, auditEventPermissionsGrant, userID, map[string]any{"codes": input.Codes})
+
+	writeJSON(w, r, http.StatusOK, envelope{"message": "permissions granted"})
+}
+
+// RevokeUserPermissions godoc
+// @Summary Revoke permissions from the user
+// @Description Revoke permission codes from the user by ID. Other users keep them. The access of owners to their films and collections cannot be revoked. You must be an admin.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param user_id path int true "User ID"
+// @Param data body swagger.PermissionCodesRequest true "Permission codes"
+// @Success 200 {object} swagger.MessageResponse
+// @Failure 400 {object} swagger.ErrorResponse
+// @Failure 401 {object} swagger.ErrorResponse
+// @Failure 403 {object} swagger.ErrorResponse
+// @Failure 404 {object} swagger.ErrorResponse
+// @Failure 422 {object} swagger.ErrorResponse
+// @Failure 500 {object} swagger.ErrorResponse
+// @Security JWTAuth
+// @Router /admin/users/{user_id}/permissions [delete]
+func revokeUserPermissionsHandler(w http.ResponseWriter, r *http.Request) {
+	adminID := r.Context().Value("userID").(int)
+
+	userID, input, ok := parsePermissionCodesRequest(w, r)
+	if !ok {
+		return
+	}
+
+	if err := revokePermissions(adminID, userID, input.Codes); err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	recordAdminAction(adminID, adminActionRevokePermissions, userID, map[string]any{"codes": input.Codes})
+	recordAuditEvent(r, auditEventPermissionsRevoke, userID, map[string]any{"codes": input.Codes})
+
+	writeJSON(w, r, http.StatusOK, envelope{"message": "permissions revoked"})
+}
+
+// GetAdminActions godoc
+// @Summary Get admin actions
+// @Description Get a list of recorded admin actions, newest first. You must be an admin.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param user_id query int false "Filter by the `user` the action was performed on"
+// @Param page query int false "Specify the desired `page`"
+// @Param page_size query int false "Specify the desired `page size`"
+// @Success 200 {object} swagger.AdminActionsResponse
+// @Failure 401 {object} swagger.ErrorResponse
+// @Failure 403 {object} swagger.ErrorResponse
+// @Failure 422 {object} swagger.ErrorResponse
+// @Failure 500 {object} swagger.ErrorResponse
+// @Security JWTAuth
+// @Router /admin/actions [get]
+func getAdminActionsHandler(w http.ResponseWriter, r *http.Request) {
+	input, errs, err := parseAndValidateAdminActionsFilters(r)
+	if err != nil {
+		serverErrorResponse(w, r, err)
+		return
+	}
+	if errs != nil {
+		failedValidationResponse(w, r, errs)
+		return
+	}
+
+	actions, metadata, err := postgres.GetAdminActions(input.UserID, input.Filters)
+	if err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, envelope{"actions": actions, "metadata": metadata})
+}
+
+// parsePermissionCodesRequest parses the user ID and the permission codes of a request, writing an error response if they are invalid.
+func parsePermissionCodesRequest(w http.ResponseWriter, r *http.Request) (int, *models.PermissionCodes, bool) {
+	userID, err := parseIDParam(r, "userID")
+	if err != nil {
+		badRequestResponse(w, r, err)
+		return 0, nil, false
+	}
+
+	var input models.PermissionCodes
+
+	if err := parseRequestBody(r, &input); err != nil {
+		badRequestResponse(w, r, err)
+		return 0, nil, false
+	}
+
+	if errs := validator.ValidateStruct(&input); errs != nil {
+		failedValidationResponse(w, r, errs)
+		return 0, nil, false
+	}
+
+	return userID, &input, true
+}
+
+// parseAndValidateUsersFilters parses and validates the query parameters for searching users.
+func parseAndValidateUsersFilters(r *http.Request) (*usersQueryInput, map[string]string, error) {
+	input := usersQueryInput{}
+	qs := r.URL.Query()
+
+	input.Search = parseQueryString(qs, "q", "")
+
+	input.Filters.Page = parseQueryInt(qs, "page", 1)
+	input.Filters.PageSize = parseQueryInt(qs, "page_size", 20)
+	input.Filters.Sort = parseQueryString(qs, "sort", "id")
+
+	// Define safe sortable fields.
+	input.Filters.SortSafeList = []string{
+		"id", "username", "created_at",
+		"-id", "-username", "-created_at",
+	}
+
+	errs, err := filters.ValidateFilters(input.Filters)
+
+	return &input, errs, err
+}
+
+// parseAndValidateAdminActionsFilters parses and validates the query parameters for querying admin actions.
+func parseAndValidateAdminActionsFilters(r *http.Request) (*adminActionsQueryInput, map[string]string, error) {
+	input := adminActionsQueryInput{}
+	qs := r.URL.Query()
+
+	input.UserID = parseQueryInt(qs, "user_id", 0)
+
+	input.Filters.Page = parseQueryInt(qs, "page", 1)
+	input.Filters.PageSize = parseQueryInt(qs, "page_size", 20)
+	input.Filters.Sort = "-created_at"
+	input.Filters.SortSafeList = []string{"-created_at"}
+
+	errs, err := filters.ValidateFilters(input.Filters)
+
+	return &input, errs, err
+}
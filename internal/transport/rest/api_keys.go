@@ -0,0 +1,77 @@
+package rest
+
+import (
+	"github.com/k4sper1love/watchlist-api/internal/database/postgres"
+	"github.com/k4sper1love/watchlist-api/pkg/models"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// API key settings
+const (
+	apiKeyHeader       = "X-API-Key" // Header carrying an API key.
+	apiKeyPrefix       = "wl_"       // Prefix that makes API keys easy to recognize, for example by secret scanners.
+	apiKeyPrefixLength = 7           // Number of leading characters stored in plain text to tell keys apart.
+	accountPath        = "/api/v1/user"
+	adminPath          = "/api/v1/admin"
+)
+
+// createAPIKey generates a new API key for a user. The secret key is returned only here.
+func createAPIKey(userID int, input *models.APIKey) (*models.APIKey, error) {
+	if input.ExpiresAt != nil && !input.ExpiresAt.After(time.Now()) {
+		return nil, errInvalidAPIKeyExpiry
+	}
+
+	secret, err := generateOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+
+	key := &models.APIKey{
+		UserID:    userID,
+		Name:      input.Name,
+		Scope:     input.Scope,
+		ExpiresAt: input.ExpiresAt,
+		Key:       apiKeyPrefix + secret,
+	}
+	key.Prefix = key.Key[:apiKeyPrefixLength]
+
+	if err := postgres.AddAPIKey(key); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// authenticateAPIKey returns the active API key with the given secret value and records its use.
+func authenticateAPIKey(secret string) (*models.APIKey, error) {
+	key, err := postgres.GetAPIKeyByKey(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := postgres.TouchAPIKey(key.ID); err != nil {
+		slog.Error("failed to record API key use", slog.Any("error", err), slog.Int("api_key_id", key.ID))
+	}
+
+	return key, nil
+}
+
+// apiKeyAllows reports whether a request can be made with an API key of the given scope.
+// Read-only keys allow only safe methods. No key can manage the account itself, except reading it,
+// so that a leaked key cannot be used to take the account over. The admin API is never available with a key.
+func apiKeyAllows(scope string, r *http.Request) bool {
+	isSafeMethod := r.Method == http.MethodGet || r.Method == http.MethodHead
+
+	if r.URL.Path == accountPath {
+		return isSafeMethod
+	}
+
+	if strings.HasPrefix(r.URL.Path, accountPath+"/") || strings.HasPrefix(r.URL.Path, adminPath+"/") {
+		return false
+	}
+
+	return scope == models.APIKeyScopeReadWrite || isSafeMethod
+}
@@ -0,0 +1,102 @@
+package rest
+
+import (
+	"github.com/k4sper1love/watchlist-api/internal/database/postgres"
+	"github.com/k4sper1love/watchlist-api/pkg/models"
+	"github.com/k4sper1love/watchlist-api/pkg/validator"
+	"net/http"
+)
+
+// AddAPIKey godoc
+// @Summary Create an API key
+// @Description Create a personal API key for scripts and integrations. Send it in the `X-API-Key` header instead of a JWT token.
+// @Description `read` keys allow only GET requests, `read-write` keys allow all requests. API keys cannot manage the account.
+// @Description The key is returned only once.
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param data body swagger.APIKeyRequest true "Information about the new API key"
+// @Success 201 {object} swagger.APIKeyResponse
+// @Failure 400 {object} swagger.ErrorResponse
+// @Failure 401 {object} swagger.ErrorResponse
+// @Failure 422 {object} swagger.ErrorResponse
+// @Failure 500 {object} swagger.ErrorResponse
+// @Security JWTAuth
+// @Router /user/api-keys [post]
+func addAPIKeyHandler(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(int)
+
+	var input models.APIKey
+
+	if err := parseRequestBody(r, &input); err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	if errs := validator.ValidateStruct(&input); errs != nil {
+		failedValidationResponse(w, r, errs)
+		return
+	}
+
+	key, err := createAPIKey(userID, &input)
+	if err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	writeJSON(w, r, http.StatusCreated, envelope{"api_key": key})
+}
+
+// GetAPIKeys godoc
+// @Summary Get API keys
+// @Description Get a list of API keys of the user that are not revoked. Secret keys are not included.
+// @Tags user
+// @Accept json
+// @Produce json
+// @Success 200 {object} swagger.APIKeysResponse
+// @Failure 401 {object} swagger.ErrorResponse
+// @Failure 500 {object} swagger.ErrorResponse
+// @Security JWTAuth
+// @Router /user/api-keys [get]
+func getAPIKeysHandler(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(int)
+
+	keys, err := postgres.GetAPIKeys(userID)
+	if err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, envelope{"api_keys": keys})
+}
+
+// DeleteAPIKey godoc
+// @Summary Revoke the API key
+// @Description Revoke the API key by ID. It can no longer be used.
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param api_key_id path int true "API key ID"
+// @Success 200 {object} swagger.MessageResponse
+// @Failure 400 {object} swagger.ErrorResponse
+// @Failure 401 {object} swagger.ErrorResponse
+// @Failure 404 {object} swagger.ErrorResponse
+// @Failure 500 {object} swagger.ErrorResponse
+// @Security JWTAuth
+// @Router /user/api-keys/{api_key_id} [delete]
+func deleteAPIKeyHandler(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(int)
+
+	keyID, err := parseIDParam(r, "apiKeyID")
+	if err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	if err := postgres.RevokeAPIKey(userID, keyID); err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, envelope{"message": "API key revoked"})
+}
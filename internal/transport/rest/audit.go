@@ -0,0 +1,69 @@
+package rest
+
+import (
+	"github.com/k4sper1love/watchlist-api/internal/database/postgres"
+	"github.com/k4sper1love/watchlist-api/pkg/models"
+	"log/slog"
+	"net/http"
+)
+
+// Names of audit events.
+const (
+	auditEventRegister          = "user.register"
+	auditEventLogin             = "user.login"
+	auditEventLoginFailed       = "user.login_failed"
+	auditEventRefresh           = "token.refresh"
+	auditEventRefreshReused     = "token.reuse_detected"
+	auditEventLogout            = "user.logout"
+	auditEventUserUpdate        = "user.update"
+	auditEventUserDelete        = "user.delete"
+	auditEventUserRestore       = "user.restore"
+	auditEventUserPurge         = "user.purge"
+	auditEventUserExport        = "user.export"
+	auditEventUserImport        = "user.import"
+	auditEventPermissionsGrant  = "permissions.grant"
+	auditEventPermissionsRevoke = "permissions.revoke"
+)
+
+// recordAuditEvent appends an event about a user to the audit log, with the client details and the ID of the request.
+// The authenticated user of the request, if any, is recorded as the actor. A failure is logged and does not fail the request.
+func recordAuditEvent(r *http.Request, event string, userID int, details map[string]any) {
+	actorID, _ := r.Context().Value("userID").(int)
+	requestID, _ := r.Context().Value("requestID").(string)
+
+	auditEvent := &models.AuditEvent{
+		Event:     event,
+		UserID:    userID,
+		ActorID:   actorID,
+		ClientIP:  getClientIP(r),
+		UserAgent: r.UserAgent(),
+		RequestID: requestID,
+		Details:   details,
+	}
+
+	if err := postgres.AddAuditEvent(auditEvent); err != nil {
+		slog.Error("failed to record audit event", slog.Any("error", err), slog.String("event", event), slog.String("request_id", requestID))
+	}
+}
+
+// auditLogin appends a successful login to the audit log, and the restore of the account if the login restored it.
+func auditLogin(r *http.Request, auth *models.AuthResponse, method string) {
+	recordAuditEvent(r, auditEventLogin, auth.ID, map[string]any{"method": method})
+
+	if auth.Restored {
+		recordAuditEvent(r, auditEventUserRestore, auth.ID, map[string]any{"method": method})
+	}
+}
+
+// auditLoginFailure appends a failed login to the audit log. The user is recorded if the username is known,
+// so that users can see failed attempts to log in to their account.
+func auditLoginFailure(r *http.Request, username string, err error) {
+	userID := 0
+	if username != "" {
+		if user, lookupErr := postgres.GetUserByUsername(username); lookupErr == nil {
+			userID = user.ID
+		}
+	}
+
+	recordAuditEvent(r, auditEventLoginFailed, userID, map[string]any{"method": "password", "username": username, "reason": err.Error()})
+}
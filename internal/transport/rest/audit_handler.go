@@ -0,0 +1,145 @@
+package rest
+
+import (
+	"github.com/k4sper1love/watchlist-api/internal/database/postgres"
+	"github.com/k4sper1love/watchlist-api/pkg/filters"
+	"github.com/k4sper1love/watchlist-api/pkg/models"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// GetUserAuditEvents godoc
+// @Summary Get the security events of the user
+// @Description Get the audit log of the authenticated user: registration, logins and failed logins, token refreshes, logouts, account changes and permission changes.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param event query string false "Filter by `event`, e.g. `user.login_failed`"
+// @Param from query string false "Only events at or after this RFC 3339 time"
+// @Param to query string false "Only events before this RFC 3339 time"
+// @Param page query int false "Specify the desired `page`"
+// @Param page_size query int false "Specify the desired `page size`"
+// @Success 200 {object} swagger.AuditEventsResponse
+// @Failure 401 {object} swagger.ErrorResponse
+// @Failure 422 {object} swagger.ErrorResponse
+// @Failure 500 {object} swagger.ErrorResponse
+// @Security JWTAuth
+// @Router /user/audit [get]
+func getUserAuditEventsHandler(w http.ResponseWriter, r *http.Request) {
+	input, errs, err := parseAndValidateAuditEventsFilters(r)
+	if err != nil {
+		serverErrorResponse(w, r, err)
+		return
+	}
+	if errs != nil {
+		failedValidationResponse(w, r, errs)
+		return
+	}
+
+	// Users only see events about their own account.
+	input.UserID = r.Context().Value("userID").(int)
+	input.ActorID, input.ClientIP, input.RequestID = 0, "", ""
+
+	writeAuditEvents(w, r, input)
+}
+
+// GetAdminAuditEvents godoc
+// @Summary Search the audit log
+// @Description Get security events of all users. You must be an admin.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param user_id query int false "Filter by the `user` the event is about"
+// @Param actor_id query int false "Filter by the `user` who performed the action"
+// @Param event query string false "Filter by `event`, e.g. `user.login_failed`"
+// @Param ip query string false "Filter by client `IP`"
+// @Param request_id query string false "Filter by `request ID`"
+// @Param from query string false "Only events at or after this RFC 3339 time"
+// @Param to query string false "Only events before this RFC 3339 time"
+// @Param page query int false "Specify the desired `page`"
+// @Param page_size query int false "Specify the desired `page size`"
+// @Success 200 {object} swagger.AuditEventsResponse
+// @Failure 401 {object} swagger.ErrorResponse
+// @Failure 403 {object} swagger.ErrorResponse
+// @Failure 422 {object} swagger.ErrorResponse
+// @Failure 500 {object} swagger.ErrorResponse
+// @Security JWTAuth
+// @Router /admin/audit [get]
+func getAdminAuditEventsHandler(w http.ResponseWriter, r *http.Request) {
+	input, errs, err := parseAndValidateAuditEventsFilters(r)
+	if err != nil {
+		serverErrorResponse(w, r, err)
+		return
+	}
+	if errs != nil {
+		failedValidationResponse(w, r, errs)
+		return
+	}
+
+	writeAuditEvents(w, r, input)
+}
+
+// writeAuditEvents writes the audit events matching the input with pagination metadata.
+func writeAuditEvents(w http.ResponseWriter, r *http.Request, input *models.AuditEventsQueryInput) {
+	events, metadata, err := postgres.GetAuditEvents(input)
+	if err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, envelope{"events": events, "metadata": metadata})
+}
+
+// parseAndValidateAuditEventsFilters parses and validates the query parameters for querying audit events.
+func parseAndValidateAuditEventsFilters(r *http.Request) (*models.AuditEventsQueryInput, map[string]string, error) {
+	input := models.AuditEventsQueryInput{}
+	qs := r.URL.Query()
+
+	input.UserID = parseQueryInt(qs, "user_id", 0)
+	input.ActorID = parseQueryInt(qs, "actor_id", 0)
+	input.Event = parseQueryString(qs, "event", "")
+	input.ClientIP = parseQueryString(qs, "ip", "")
+	input.RequestID = parseQueryString(qs, "request_id", "")
+
+	input.Filters.Page = parseQueryInt(qs, "page", 1)
+	input.Filters.PageSize = parseQueryInt(qs, "page_size", 20)
+	input.Filters.Sort = "-created_at"
+	input.Filters.SortSafeList = []string{"-created_at"}
+
+	errs, err := filters.ValidateFilters(input.Filters)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Invalid times are reported instead of ignored, so that a typo does not silently widen the search.
+	for key, target := range map[string]**time.Time{"from": &input.From, "to": &input.To} {
+		t, ok := parseQueryTime(qs, key)
+		if !ok {
+			if errs == nil {
+				errs = make(map[string]string)
+			}
+			errs[key] = "must be an RFC 3339 time"
+			continue
+		}
+		*target = t
+	}
+
+	return &input, errs, nil
+}
+
+// parseQueryTime extracts an RFC 3339 time query parameter from URL.Values.
+// It returns nil if the parameter is missing and false if it is invalid.
+func parseQueryTime(qs url.Values, key string) (*time.Time, bool) {
+	value := qs.Get(key)
+	if value == "" {
+		return nil, true
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, false
+	}
+
+	return &t, true
+}
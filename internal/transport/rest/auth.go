@@ -1,14 +1,18 @@
 package rest
 
 import (
-	"github.com/k4sper1love/watchlist-api/internal/config"
+	"database/sql"
+	"errors"
 	"github.com/k4sper1love/watchlist-api/internal/database/postgres"
 	"github.com/k4sper1love/watchlist-api/pkg/models"
+	"github.com/k4sper1love/watchlist-api/pkg/tokens"
 	"golang.org/x/crypto/bcrypt"
+	"log/slog"
+	"strconv"
 )
 
 // registerWithCredentials creates a new user using provided credentials and generates authentication tokens.
-func registerWithCredentials(credentials *models.Credentials) (*models.AuthResponse, error) {
+func registerWithCredentials(credentials *models.Credentials, session *models.Session) (*models.AuthResponse, error) {
 	if err := hashPassword(credentials); err != nil {
 		return nil, err
 	}
@@ -20,21 +24,17 @@ func registerWithCredentials(credentials *models.Credentials) (*models.AuthRespo
 
 	user.Password = "" // Clear the password before returning.
 
-	accessToken, err := generateAccessToken(user.ID)
-	if err != nil {
-		return nil, err
+	if user.Email != "" {
+		if err := requestEmailVerification(user); err != nil {
+			slog.Error("failed to request email verification", slog.Any("error", err), slog.Int("user_id", user.ID))
+		}
 	}
 
-	refreshToken, err := generateAndSaveRefreshToken(user.ID)
-	if err != nil {
-		return nil, err
-	}
-
-	return createAuthResponse(user, accessToken, refreshToken), nil
+	return issueAuthTokens(user, session)
 }
 
 // registerByTelegram creates a new user with the provided Telegram ID and generates authentication tokens.
-func registerByTelegram(telegramID int) (*models.AuthResponse, error) {
+func registerByTelegram(telegramID int, session *models.Session) (*models.AuthResponse, error) {
 	credentials := &models.Credentials{
 		TelegramID: telegramID,
 		Username:   generateUniqueUsername(4, telegramID),
@@ -45,105 +45,314 @@ func registerByTelegram(telegramID int) (*models.AuthResponse, error) {
 		return nil, err
 	}
 
-	accessToken, err := generateAccessToken(user.ID)
+	return issueAuthTokens(user, session)
+}
+
+// loginWithCredentials authenticates a user by their username and password, generating authentication tokens upon success.
+// Failed attempts are tracked per username and client IP, which are temporarily locked after too many failures.
+// If the user has two-factor authentication enabled, a challenge for the second login step is returned instead.
+func loginWithCredentials(username, password string, session *models.Session) (*models.AuthResponse, *models.TwoFactorChallenge, error) {
+	if err := checkLoginLockout(username, session.ClientIP); err != nil {
+		return nil, nil, err
+	}
+
+	// Retrieve the user from the database by email.
+	user, err := postgres.GetUserByUsername(username)
 	if err != nil {
-		return nil, err
+		if errors.Is(err, sql.ErrNoRows) {
+			recordLoginFailure(username, session.ClientIP)
+		}
+		return nil, nil, err
+	}
+
+	if user.Password == "" {
+		return nil, nil, errRequiredPassword
 	}
 
-	refreshToken, err := generateAndSaveRefreshToken(user.ID)
+	if err := comparePasswords(user.Password, password); err != nil {
+		recordLoginFailure(username, session.ClientIP)
+		return nil, nil, err
+	}
+
+	resetLoginFailures(username)
+
+	return issueAuthTokensOrChallenge(user, session)
+}
+
+// loginByTelegram authenticates a user using their Telegram ID and generates authentication tokens.
+// If the user has two-factor authentication enabled, a challenge for the second login step is returned instead.
+func loginByTelegram(telegramID int, session *models.Session) (*models.AuthResponse, *models.TwoFactorChallenge, error) {
+	// Retrieve the user from the database by Telegram ID.
+	user, err := postgres.GetUserByTelegramID(telegramID)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return createAuthResponse(user, accessToken, refreshToken), nil
+	return issueAuthTokensOrChallenge(user, session)
 }
 
-// loginWithCredentials authenticates a user by their username and password, generating authentication tokens upon success.
-func loginWithCredentials(username, password string) (*models.AuthResponse, error) {
-	// Retrieve the user from the database by email.
-	user, err := postgres.GetUserByUsername(username)
+// issueAuthTokensOrChallenge finishes the first login step of a user, whichever login method was used.
+// If the user has two-factor authentication enabled, it returns a challenge for the second login step; otherwise tokens.
+func issueAuthTokensOrChallenge(user *models.User, session *models.Session) (*models.AuthResponse, *models.TwoFactorChallenge, error) {
+	enabled, err := postgres.IsTOTPEnabled(user.ID)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	if user.Password == "" {
-		return nil, errRequiredPassword
+	if enabled {
+		challenge, err := createTwoFactorChallenge(user.ID)
+		return nil, challenge, err
 	}
 
-	if err := comparePasswords(user.Password, password); err != nil {
+	user.Password = "" // Clear the password before returning.
+
+	auth, err := issueAuthTokens(user, session)
+	return auth, nil, err
+}
+
+// addDefaultPermissions assigns the permissions every new user gets: creating films and collections.
+func addDefaultPermissions(userID int) error {
+	return postgres.AddUserPermissions(userID, "film:create", "collection:create")
+}
+
+// issueAuthTokens starts a new session for the user and returns the user with its access and refresh tokens.
+// Suspended users cannot log in. Logging in restores an account deleted within the grace period.
+func issueAuthTokens(user *models.User, session *models.Session) (*models.AuthResponse, error) {
+	suspended, err := postgres.IsUserSuspended(user.ID)
+	if err != nil {
 		return nil, err
 	}
 
-	user.Password = "" // Clear the password before returning.
+	if suspended {
+		return nil, errAccountSuspended
+	}
 
-	accessToken, err := generateAccessToken(user.ID)
+	restored, err := restoreDeletedAccount(user)
 	if err != nil {
 		return nil, err
 	}
 
-	refreshToken, err := generateAndSaveRefreshToken(user.ID)
+	refreshToken, err := generateAndSaveRefreshToken(user.ID, session)
 	if err != nil {
 		return nil, err
 	}
 
-	return createAuthResponse(user, accessToken, refreshToken), nil
+	accessToken, err := generateAccessToken(user.ID, session.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	auth := createAuthResponse(user, accessToken, refreshToken)
+	auth.Restored = restored
+	return auth, nil
 }
 
-// loginByTelegram authenticates a user using their Telegram ID and generates authentication tokens.
-func loginByTelegram(telegramID int) (*models.AuthResponse, error) {
-	// Retrieve the user from the database by email.
-	user, err := postgres.GetUserByTelegramID(telegramID)
+// refreshAccessToken generates a new access token and rotates the given refresh token.
+// Presenting an already revoked refresh token is treated as token theft: the whole token family is revoked.
+// The ID of the token owner is returned whenever the token is valid, also if it was reused.
+func refreshAccessToken(refreshToken string, session *models.Session) (int, string, string, error) {
+	claims, err := parseTokenClaims(refreshToken, signingKeys)
+	if err != nil || claims == nil || claims.Type != tokens.TypeRefresh {
+		return 0, "", "", errInvalidRefreshToken
+	}
+
+	userID, err := strconv.Atoi(claims.Sub)
 	if err != nil {
-		return nil, err
+		return 0, "", "", errInvalidRefreshToken
 	}
 
-	accessToken, err := generateAccessToken(user.ID)
+	isRevoked, err := postgres.IsRefreshTokenRevoked(refreshToken)
 	if err != nil {
-		return nil, err
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, "", "", errInvalidRefreshToken
+		}
+		return 0, "", "", err
+	}
+
+	if isRevoked {
+		if err := revokeRefreshTokenFamily(refreshToken); err != nil {
+			return userID, "", "", err
+		}
+		slog.Warn("refresh token reuse detected; token family revoked", slog.String("user_id", claims.Sub))
+		return userID, "", "", errRefreshTokenReused
 	}
 
-	refreshToken, err := generateAndSaveRefreshToken(user.ID)
+	// The new tokens belong to the session of the refresh token.
+	session.ID = claims.Sid
+
+	newRefreshToken, err := rotateRefreshToken(refreshToken, userID, session)
 	if err != nil {
-		return nil, err
+		if errors.Is(err, sql.ErrNoRows) {
+			return userID, "", "", errInvalidRefreshToken
+		}
+		return userID, "", "", err
+	}
+
+	accessToken, err := generateAccessToken(userID, session.ID)
+	if err != nil {
+		return userID, "", "", err
+	}
+
+	return userID, accessToken, newRefreshToken, nil
+}
+
+// revokeRefreshTokenFamily revokes every refresh token issued from the same login as the given token
+// and all access tokens of its session.
+func revokeRefreshTokenFamily(refreshToken string) error {
+	sessionID, err := postgres.GetRefreshTokenFamily(refreshToken)
+	if err != nil {
+		return err
 	}
 
-	return createAuthResponse(user, accessToken, refreshToken), nil
+	if err := postgres.RevokeRefreshTokenFamily(refreshToken); err != nil {
+		return err
+	}
+
+	return revokeSessionAccessTokens(sessionID)
 }
 
-// refreshAccessToken generates a new access token using a valid refresh token.
-func refreshAccessToken(refreshToken string) (string, error) {
-	if err := checkToken(refreshToken); err != nil {
-		return "", err
+// logout invalidates the given refresh token, the token itself and all access tokens of its session.
+// It returns the ID of the token owner.
+func logout(refreshToken string) (int, error) {
+	claims, err := parseTokenClaims(refreshToken, signingKeys)
+	if err != nil || claims == nil || claims.Type != tokens.TypeRefresh {
+		return 0, errInvalidRefreshToken
+	}
+
+	userID, err := strconv.Atoi(claims.Sub)
+	if err != nil {
+		return 0, errInvalidRefreshToken
+	}
+
+	isRevoked, err := postgres.IsRefreshTokenRevoked(refreshToken)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, errInvalidRefreshToken
+		}
+		return 0, err
 	}
 
-	if isRevoked, err := postgres.IsRefreshTokenRevoked(refreshToken); err != nil || isRevoked {
-		return "", errInvalidRefreshToken
+	if isRevoked {
+		return 0, errInvalidRefreshToken
 	}
 
-	userId, err := postgres.GetIdFromRefreshToken(refreshToken)
+	sessionID, err := postgres.GetRefreshTokenFamily(refreshToken)
 	if err != nil {
-		return "", err
+		return 0, err
 	}
 
-	return generateAccessToken(userId)
+	if err := postgres.RevokeRefreshToken(refreshToken); err != nil {
+		return 0, err
+	}
+
+	if err := revokeToken(claims); err != nil {
+		return 0, err
+	}
+
+	return userID, revokeSessionAccessTokens(sessionID)
 }
 
-// logout invalidates the given refresh token.
-func logout(refreshToken string) error {
-	if err := checkToken(refreshToken); err != nil {
+// changePassword verifies the current password of a user, stores the new one and revokes all tokens of the user.
+func changePassword(userID int, currentPassword, newPassword string) error {
+	user, err := postgres.GetUserById(userID)
+	if err != nil {
 		return err
 	}
 
-	if isRevoked, err := postgres.IsRefreshTokenRevoked(refreshToken); err != nil || isRevoked {
-		return errInvalidRefreshToken
+	if user.Password == "" {
+		return errRequiredPassword
 	}
 
-	return postgres.RevokeRefreshToken(refreshToken)
+	if err := comparePasswords(user.Password, currentPassword); err != nil {
+		return err
+	}
+
+	return setPassword(userID, newPassword)
+}
+
+// requestPasswordReset emails a password reset token to every credential user with the given email.
+func requestPasswordReset(email string) error {
+	users, err := postgres.GetCredentialUsersByEmail(email)
+	if err != nil {
+		return err
+	}
+
+	for _, user := range users {
+		resetToken, err := generateAndSavePasswordResetToken(user.ID)
+		if err != nil {
+			return err
+		}
+
+		sendPasswordResetEmail(user, resetToken)
+	}
+
+	return nil
+}
+
+// resetPassword consumes a password reset token, stores the new password and revokes all tokens of the user.
+func resetPassword(resetToken, newPassword string) error {
+	userID, err := postgres.ConsumePasswordResetToken(resetToken)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errInvalidResetToken
+		}
+		return err
+	}
+
+	return setPassword(userID, newPassword)
+}
+
+// requestEmailVerification emails a verification token for the current email of the user.
+func requestEmailVerification(user *models.User) error {
+	if user.Email == "" {
+		return errRequiredEmail
+	}
+
+	if user.EmailVerifiedAt != nil {
+		return errEmailAlreadyVerified
+	}
+
+	verificationToken, err := generateAndSaveEmailVerificationToken(user.ID, user.Email)
+	if err != nil {
+		return err
+	}
+
+	sendEmailVerificationEmail(user, verificationToken)
+	return nil
+}
+
+// verifyEmail consumes an email verification token and marks the email of its user as verified.
+func verifyEmail(verificationToken string) error {
+	if err := postgres.VerifyEmail(verificationToken); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errInvalidEmailToken
+		}
+		return err
+	}
+	return nil
+}
+
+// setPassword hashes and stores the new password of a user and revokes all refresh and access tokens of the user.
+func setPassword(userID int, newPassword string) error {
+	credentials := &models.Credentials{Password: newPassword}
+	if err := hashPassword(credentials); err != nil {
+		return err
+	}
+
+	if err := postgres.UpdateUserPassword(userID, credentials.Password); err != nil {
+		return err
+	}
+
+	if err := postgres.RevokeUserRefreshTokens(userID); err != nil {
+		return err
+	}
+
+	return revokeUserAccessTokens(userID)
 }
 
 // checkToken verifies the validity of the provided authentication token.
 func checkToken(token string) error {
-	claims, err := parseTokenClaims(token, config.JWTSecret)
+	claims, err := parseTokenClaims(token, signingKeys)
 	if err != nil {
 		return err
 	}
@@ -151,6 +360,11 @@ func checkToken(token string) error {
 	if claims == nil {
 		return errInvalidRefreshToken
 	}
+
+	userID, err := strconv.Atoi(claims.Sub)
+	if err != nil || revocations.isRevoked(userID, claims) {
+		return errInvalidToken
+	}
 	return nil
 }
 
@@ -1,7 +1,7 @@
 package rest
 
 import (
-	"github.com/k4sper1love/watchlist-api/internal/database/postgres"
+	"errors"
 	"github.com/k4sper1love/watchlist-api/pkg/models"
 	"github.com/k4sper1love/watchlist-api/pkg/validator"
 	"net/http"
@@ -35,16 +35,16 @@ func registerWithCredentialsHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Register the user in the system.
-	user, err := registerWithCredentials(&credentials)
+	user, err := registerWithCredentials(&credentials, newSession(r))
 	if err != nil {
 		handleDBError(w, r, err)
 		return
 	}
 
-	// Assign default permissions to the user.
-	permissionCodes := []string{"film:create", "collection:create"}
+	recordAuditEvent(r, auditEventRegister, user.ID, map[string]any{"method": "password"})
 
-	if err = postgres.AddUserPermissions(user.ID, permissionCodes...); err != nil {
+	// Assign default permissions to the user.
+	if err = addDefaultPermissions(user.ID); err != nil {
 		serverErrorResponse(w, r, err)
 		return
 	}
@@ -56,6 +56,8 @@ func registerWithCredentialsHandler(w http.ResponseWriter, r *http.Request) {
 // @Summary Register a new user by Telegram
 // @Description Register a new user using verification token from header. Returns user information and tokens.
 // @Description Basic permissions are available to you: creating films and collections.
+// @Description The verification token must have `exp`, `iat` and a unique `jti`, and can be used only once.
+// @Description If two-factor authentication is enabled, returns a two-factor token to complete the login at `/auth/login/2fa`.
 // @Tags auth
 // @Accept json
 // @Produce json
@@ -71,16 +73,16 @@ func registerByTelegramHandler(w http.ResponseWriter, r *http.Request) {
 	telegramID := r.Context().Value("telegramID").(int)
 
 	// Register the user in the system.
-	user, err := registerByTelegram(telegramID)
+	user, err := registerByTelegram(telegramID, newSession(r))
 	if err != nil {
 		handleDBError(w, r, err)
 		return
 	}
 
-	// Assign default permissions to the user.
-	permissionCodes := []string{"film:create", "collection:create"}
+	recordAuditEvent(r, auditEventRegister, user.ID, map[string]any{"method": "telegram"})
 
-	if err = postgres.AddUserPermissions(user.ID, permissionCodes...); err != nil {
+	// Assign default permissions to the user.
+	if err = addDefaultPermissions(user.ID); err != nil {
 		serverErrorResponse(w, r, err)
 		return
 	}
@@ -91,6 +93,8 @@ func registerByTelegramHandler(w http.ResponseWriter, r *http.Request) {
 // LoginWithCredentials godoc
 // @Summary Log in to your account with credentials
 // @Description Log in to your account using your email and password. Returns tokens.
+// @Description If two-factor authentication is enabled, returns a two-factor token to complete the login at `/auth/login/2fa`.
+// @Description After too many failed attempts the username or client IP is temporarily locked; see the `Retry-After` header.
 // @Tags auth
 // @Accept json
 // @Produce json
@@ -98,6 +102,7 @@ func registerByTelegramHandler(w http.ResponseWriter, r *http.Request) {
 // @Failure 400 {object} swagger.ErrorResponse
 // @Failure 404 {object} swagger.ErrorResponse
 // @Failure 422 {object} swagger.ErrorResponse
+// @Failure 429 {object} swagger.ErrorResponse
 // @Failure 500 {object} swagger.ErrorResponse
 // @Router /auth/login [post]
 func loginWithCredentialsHandler(w http.ResponseWriter, r *http.Request) {
@@ -114,18 +119,27 @@ func loginWithCredentialsHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Authenticate the user.
-	user, err := loginWithCredentials(credentials.Username, credentials.Password)
+	user, challenge, err := loginWithCredentials(credentials.Username, credentials.Password, newSession(r))
 	if err != nil {
+		auditLoginFailure(r, credentials.Username, err)
 		handleDBError(w, r, err)
 		return
 	}
 
+	if challenge != nil {
+		writeJSON(w, r, http.StatusOK, envelope{"two_factor": challenge})
+		return
+	}
+
+	auditLogin(r, user, "password")
+
 	writeJSON(w, r, http.StatusOK, envelope{"user": user})
 }
 
 // LoginByTelegram godoc
 // @Summary Log in to your account by Telegram
 // @Description Log in to your account using verification token from header. Returns tokens.
+// @Description The verification token must have `exp`, `iat` and a unique `jti`, and can be used only once.
 // @Tags auth
 // @Accept json
 // @Produce json
@@ -140,18 +154,128 @@ func loginByTelegramHandler(w http.ResponseWriter, r *http.Request) {
 	telegramID := r.Context().Value("telegramID").(int)
 
 	// Authenticate the user.
-	user, err := loginByTelegram(telegramID)
+	user, challenge, err := loginByTelegram(telegramID, newSession(r))
 	if err != nil {
+		recordAuditEvent(r, auditEventLoginFailed, 0, map[string]any{"method": "telegram", "telegram_id": telegramID, "reason": err.Error()})
 		handleDBError(w, r, err)
 		return
 	}
 
+	if challenge != nil {
+		writeJSON(w, r, http.StatusOK, envelope{"two_factor": challenge})
+		return
+	}
+
+	auditLogin(r, user, "telegram")
+
 	writeJSON(w, r, http.StatusOK, envelope{"user": user})
 }
 
+// ForgotPassword godoc
+// @Summary Request a password reset
+// @Description Send a single-use password reset token to the email of the account. The email must be verified.
+// @Description The response is the same whether or not an account with this email exists.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param data body swagger.ForgotPasswordRequest true "Email of the account"
+// @Success 200 {object} swagger.MessageResponse
+// @Failure 400 {object} swagger.ErrorResponse
+// @Failure 422 {object} swagger.ErrorResponse
+// @Failure 500 {object} swagger.ErrorResponse
+// @Router /auth/password/forgot [post]
+func forgotPasswordHandler(w http.ResponseWriter, r *http.Request) {
+	var input models.ForgotPassword
+
+	if err := parseRequestBody(r, &input); err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	if errs := validator.ValidateStruct(&input); errs != nil {
+		failedValidationResponse(w, r, errs)
+		return
+	}
+
+	if err := requestPasswordReset(input.Email); err != nil {
+		serverErrorResponse(w, r, err)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, envelope{"message": "if an account with this email exists, a password reset token has been sent"})
+}
+
+// ResetPassword godoc
+// @Summary Reset password
+// @Description Set a new password using the password reset token from the email. All sessions of the user are revoked.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param data body swagger.ResetPasswordRequest true "Reset token and new password"
+// @Success 200 {object} swagger.MessageResponse
+// @Failure 400 {object} swagger.ErrorResponse
+// @Failure 422 {object} swagger.ErrorResponse
+// @Failure 500 {object} swagger.ErrorResponse
+// @Router /auth/password/reset [post]
+func resetPasswordHandler(w http.ResponseWriter, r *http.Request) {
+	var input models.PasswordReset
+
+	if err := parseRequestBody(r, &input); err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	if errs := validator.ValidateStruct(&input); errs != nil {
+		failedValidationResponse(w, r, errs)
+		return
+	}
+
+	if err := resetPassword(input.Token, input.NewPassword); err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, envelope{"message": "password reset"})
+}
+
+// VerifyEmail godoc
+// @Summary Verify email
+// @Description Confirm the email of the account using the verification token from the email.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param data body swagger.VerifyEmailRequest true "Email verification token"
+// @Success 200 {object} swagger.MessageResponse
+// @Failure 400 {object} swagger.ErrorResponse
+// @Failure 422 {object} swagger.ErrorResponse
+// @Failure 500 {object} swagger.ErrorResponse
+// @Router /auth/email/verify [post]
+func verifyEmailHandler(w http.ResponseWriter, r *http.Request) {
+	var input models.EmailVerification
+
+	if err := parseRequestBody(r, &input); err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	if errs := validator.ValidateStruct(&input); errs != nil {
+		failedValidationResponse(w, r, errs)
+		return
+	}
+
+	if err := verifyEmail(input.Token); err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, envelope{"message": "email verified"})
+}
+
 // Refresh godoc
 // @Summary Refresh access token
 // @Description Refresh your access token using the refresh token in the Authorization header.
+// @Description A new refresh token is returned and the used one is revoked.
+// @Description Reusing a revoked refresh token revokes every token issued from the same login.
 // @Tags auth
 // @Accept json
 // @Produce json
@@ -167,19 +291,30 @@ func refreshAccessTokenHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Refresh the access token.
-	newAccessToken, err := refreshAccessToken(refreshToken)
+	// Refresh the access token and rotate the refresh token.
+	userID, newAccessToken, newRefreshToken, err := refreshAccessToken(refreshToken, newSession(r))
 	if err != nil {
-		invalidAuthTokenResponse(w, r)
+		switch {
+		case errors.Is(err, errRefreshTokenReused):
+			recordAuditEvent(r, auditEventRefreshReused, userID, nil)
+			invalidAuthTokenResponse(w, r)
+		case errors.Is(err, errInvalidRefreshToken):
+			invalidAuthTokenResponse(w, r)
+		default:
+			handleDBError(w, r, err)
+		}
 		return
 	}
 
-	writeJSON(w, r, http.StatusOK, envelope{"access_token": newAccessToken})
+	recordAuditEvent(r, auditEventRefresh, userID, nil)
+
+	writeJSON(w, r, http.StatusOK, envelope{"access_token": newAccessToken, "refresh_token": newRefreshToken})
 }
 
 // Logout godoc
 // @Summary Log out of your account
 // @Description Log out of your account using your refresh token in the Authorization header.
+// @Description The refresh token and all access tokens of its session are revoked immediately.
 // @Tags auth
 // @Accept json
 // @Produce json
@@ -196,11 +331,18 @@ func logoutHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Revoke the refresh token.
-	if err := logout(refreshToken); err != nil {
-		invalidAuthTokenResponse(w, r)
+	userID, err := logout(refreshToken)
+	if err != nil {
+		if errors.Is(err, errInvalidRefreshToken) {
+			invalidAuthTokenResponse(w, r)
+			return
+		}
+		handleDBError(w, r, err)
 		return
 	}
 
+	recordAuditEvent(r, auditEventLogout, userID, nil)
+
 	writeJSON(w, r, http.StatusOK, envelope{"message": "token revoked"})
 }
 
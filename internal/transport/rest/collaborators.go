@@ -0,0 +1,92 @@
+package rest
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/k4sper1love/watchlist-api/internal/database/postgres"
+	"github.com/k4sper1love/watchlist-api/pkg/models"
+)
+
+// collaboratorActions are the actions on a collection granted to collaborators with each role.
+var collaboratorActions = map[string][]string{
+	models.CollectionViewer: {"read"},
+	models.CollectionEditor: {"read", "update"},
+}
+
+// shareCollection shares a collection with the user by username with the role.
+func shareCollection(collectionID int, username, role string) (*models.Collaborator, error) {
+	collection, err := postgres.GetCollection(collectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := getInvitee(username)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.ID == collection.UserID {
+		return nil, errShareWithOwner
+	}
+
+	_, err = postgres.GetCollectionCollaborator(collectionID, user.ID)
+	switch {
+	case err == nil:
+		return nil, errAlreadyCollaborator
+	case !errors.Is(err, sql.ErrNoRows):
+		return nil, err
+	}
+
+	if err := postgres.SetCollectionCollaborator(collectionID, user.ID, collaboratorActions[role]); err != nil {
+		return nil, err
+	}
+
+	return postgres.GetCollectionCollaborator(collectionID, user.ID)
+}
+
+// getInvitee retrieves the user invited by username to a collection or group.
+// Accounts waiting to be purged cannot be invited and are reported as not found.
+func getInvitee(username string) (*models.User, error) {
+	user, err := postgres.GetUserByUsername(username)
+	if err != nil {
+		return nil, err
+	}
+
+	deleted, err := postgres.IsUserDeleted(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if deleted {
+		return nil, sql.ErrNoRows
+	}
+
+	return user, nil
+}
+
+// changeCollaboratorRole changes the role of a user the collection is shared with.
+func changeCollaboratorRole(collectionID, userID int, role string) (*models.Collaborator, error) {
+	if _, err := postgres.GetCollectionCollaborator(collectionID, userID); err != nil {
+		return nil, err
+	}
+
+	if err := postgres.SetCollectionCollaborator(collectionID, userID, collaboratorActions[role]); err != nil {
+		return nil, err
+	}
+
+	return postgres.GetCollectionCollaborator(collectionID, userID)
+}
+
+// removeCollaborator removes the access of a user to a collection. Owners can remove any collaborator,
+// and collaborators can only remove themselves to leave the collection.
+func removeCollaborator(actorID, collectionID, userID int) error {
+	collection, err := postgres.GetCollection(collectionID)
+	if err != nil {
+		return err
+	}
+
+	if actorID != collection.UserID && actorID != userID {
+		return errNotCollectionOwner
+	}
+
+	return postgres.DeleteCollectionCollaborator(collectionID, userID)
+}
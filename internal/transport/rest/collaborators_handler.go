@@ -0,0 +1,177 @@
+package rest
+
+import (
+	"github.com/k4sper1love/watchlist-api/internal/database/postgres"
+	"github.com/k4sper1love/watchlist-api/pkg/models"
+	"github.com/k4sper1love/watchlist-api/pkg/validator"
+	"net/http"
+)
+
+// GetCollaborators godoc
+// @Summary Get the collaborators of the collection
+// @Description Get the users the collection is shared with and their roles. You must have the permissions to get this collection.
+// @Tags collaborators
+// @Accept json
+// @Produce json
+// @Param collection_id path int true "Collection ID"
+// @Success 200 {object} swagger.CollaboratorsResponse
+// @Failure 400 {object} swagger.ErrorResponse
+// @Failure 401 {object} swagger.ErrorResponse
+// @Failure 403 {object} swagger.ErrorResponse
+// @Failure 500 {object} swagger.ErrorResponse
+// @Security JWTAuth
+// @Security APIKeyAuth
+// @Router /collections/{collection_id}/collaborators [get]
+func getCollaboratorsHandler(w http.ResponseWriter, r *http.Request) {
+	collectionID, err := parseIDParam(r, "collectionID")
+	if err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	collaborators, err := postgres.GetCollectionCollaborators(collectionID)
+	if err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, envelope{"collaborators": collaborators})
+}
+
+// AddCollaborator godoc
+// @Summary Share the collection with a user
+// @Description Invite a user by username as a viewer or an editor of the collection. Viewers can get the collection and its films; editors can also update them and add and remove films. You must own the collection.
+// @Tags collaborators
+// @Accept json
+// @Produce json
+// @Param collection_id path int true "Collection ID"
+// @Param collaborator body swagger.CollaboratorRequest true "Username and role of the user"
+// @Success 201 {object} swagger.CollaboratorResponse
+// @Failure 400 {object} swagger.ErrorResponse
+// @Failure 401 {object} swagger.ErrorResponse
+// @Failure 403 {object} swagger.ErrorResponse
+// @Failure 404 {object} swagger.ErrorResponse
+// @Failure 409 {object} swagger.ErrorResponse
+// @Failure 422 {object} swagger.ErrorResponse
+// @Failure 500 {object} swagger.ErrorResponse
+// @Security JWTAuth
+// @Security APIKeyAuth
+// @Router /collections/{collection_id}/collaborators [post]
+func addCollaboratorHandler(w http.ResponseWriter, r *http.Request) {
+	collectionID, err := parseIDParam(r, "collectionID")
+	if err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	var input models.CollaboratorInvite
+	if err := parseRequestBody(r, &input); err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	if errs := validator.ValidateStruct(&input); errs != nil {
+		failedValidationResponse(w, r, errs)
+		return
+	}
+
+	collaborator, err := shareCollection(collectionID, input.Username, input.Role)
+	if err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	writeJSON(w, r, http.StatusCreated, envelope{"collaborator": collaborator})
+}
+
+// UpdateCollaborator godoc
+// @Summary Change the role of a collaborator
+// @Description Change the role of a user the collection is shared with. You must own the collection.
+// @Tags collaborators
+// @Accept json
+// @Produce json
+// @Param collection_id path int true "Collection ID"
+// @Param user_id path int true "User ID"
+// @Param role body swagger.CollaboratorRoleRequest true "New role of the user"
+// @Success 200 {object} swagger.CollaboratorResponse
+// @Failure 400 {object} swagger.ErrorResponse
+// @Failure 401 {object} swagger.ErrorResponse
+// @Failure 403 {object} swagger.ErrorResponse
+// @Failure 404 {object} swagger.ErrorResponse
+// @Failure 422 {object} swagger.ErrorResponse
+// @Failure 500 {object} swagger.ErrorResponse
+// @Security JWTAuth
+// @Security APIKeyAuth
+// @Router /collections/{collection_id}/collaborators/{user_id} [put]
+func updateCollaboratorHandler(w http.ResponseWriter, r *http.Request) {
+	collectionID, err := parseIDParam(r, "collectionID")
+	if err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	userID, err := parseIDParam(r, "userID")
+	if err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	var input models.CollaboratorRole
+	if err := parseRequestBody(r, &input); err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	if errs := validator.ValidateStruct(&input); errs != nil {
+		failedValidationResponse(w, r, errs)
+		return
+	}
+
+	collaborator, err := changeCollaboratorRole(collectionID, userID, input.Role)
+	if err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, envelope{"collaborator": collaborator})
+}
+
+// DeleteCollaborator godoc
+// @Summary Remove a collaborator
+// @Description Remove the access of a user to the collection. The owner can remove any collaborator; collaborators can remove themselves to leave the collection.
+// @Tags collaborators
+// @Accept json
+// @Produce json
+// @Param collection_id path int true "Collection ID"
+// @Param user_id path int true "User ID"
+// @Success 200 {object} swagger.MessageResponse
+// @Failure 400 {object} swagger.ErrorResponse
+// @Failure 401 {object} swagger.ErrorResponse
+// @Failure 403 {object} swagger.ErrorResponse
+// @Failure 404 {object} swagger.ErrorResponse
+// @Failure 500 {object} swagger.ErrorResponse
+// @Security JWTAuth
+// @Security APIKeyAuth
+// @Router /collections/{collection_id}/collaborators/{user_id} [delete]
+func deleteCollaboratorHandler(w http.ResponseWriter, r *http.Request) {
+	actorID := r.Context().Value("userID").(int)
+
+	collectionID, err := parseIDParam(r, "collectionID")
+	if err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	userID, err := parseIDParam(r, "userID")
+	if err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	if err := removeCollaborator(actorID, collectionID, userID); err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, envelope{"message": "collaborator removed"})
+}
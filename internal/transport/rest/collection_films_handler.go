@@ -22,6 +22,7 @@ import (
 // @Failure 409 {object} swagger.ErrorResponse
 // @Failure 500 {object} swagger.ErrorResponse
 // @Security JWTAuth
+// @Security APIKeyAuth
 // @Router /collections/{collection_id}/films/{film_id} [post]
 func addCollectionFilmHandler(w http.ResponseWriter, r *http.Request) {
 	collectionID, err := parseIDParam(r, "collectionID")
@@ -65,6 +66,7 @@ func addCollectionFilmHandler(w http.ResponseWriter, r *http.Request) {
 // @Failure 409 {object} swagger.ErrorResponse
 // @Failure 500 {object} swagger.ErrorResponse
 // @Security JWTAuth
+// @Security APIKeyAuth
 // @Router /collections/{collection_id}/films [post]
 func addNewCollectionFilmHandler(w http.ResponseWriter, r *http.Request) {
 	userID := r.Context().Value("userID").(int)
@@ -105,15 +107,6 @@ func addNewCollectionFilmHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Define permissions for the film.
-	actions := []string{"read", "update", "delete"}
-	for _, action := range actions {
-		if err := addPermissionAndAssignToUser(userID, film.ID, "film", action); err != nil {
-			serverErrorResponse(w, r, err)
-			return
-		}
-	}
-
 	writeJSON(w, r, http.StatusCreated, envelope{"collection_film": collectionFilm})
 }
 
@@ -132,6 +125,7 @@ func addNewCollectionFilmHandler(w http.ResponseWriter, r *http.Request) {
 // @Failure 404 {object} swagger.ErrorResponse
 // @Failure 500 {object} swagger.ErrorResponse
 // @Security JWTAuth
+// @Security APIKeyAuth
 // @Router /collections/{collection_id}/films/{film_id} [get]
 func getCollectionFilmHandler(w http.ResponseWriter, r *http.Request) {
 	collectionID, err := parseIDParam(r, "collectionID")
@@ -178,6 +172,7 @@ func getCollectionFilmHandler(w http.ResponseWriter, r *http.Request) {
 // @Param page query int false "Specify the desired `page`"
 // @Param page_size query int false "Specify the desired `page size`"
 // @Param sort query string false "Sorting by `id`, `title`, `rating`, `year`, `user_rating`, `is_viewed`. Use `-` for desc"
+// @Param include_permissions query bool false "Add the `_permissions` block with the actions you may perform to each item"
 // @Success 200 {object} swagger.CollectionFilmsResponse
 // @Failure 400 {object} swagger.ErrorResponse
 // @Failure 401 {object} swagger.ErrorResponse
@@ -185,6 +180,7 @@ func getCollectionFilmHandler(w http.ResponseWriter, r *http.Request) {
 // @Failure 422 {object} swagger.ErrorResponse
 // @Failure 500 {object} swagger.ErrorResponse
 // @Security JWTAuth
+// @Security APIKeyAuth
 // @Router /collections/{collection_id}/films [get]
 func getCollectionFilmsHandler(w http.ResponseWriter, r *http.Request) {
 	collectionID, err := parseIDParam(r, "collectionID")
@@ -212,7 +208,13 @@ func getCollectionFilmsHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	writeJSON(w, r, http.StatusOK, envelope{"collection_films": collectionFilms, "metadata": metadata})
+	films, err := addFilmsPermissions(r, collectionFilms.Films)
+	if err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, envelope{"collection_films": envelope{"collection": collectionFilms.Collection, "films": films}, "metadata": metadata})
 }
 
 // DeleteCollectionFilms godoc
@@ -230,6 +232,7 @@ func getCollectionFilmsHandler(w http.ResponseWriter, r *http.Request) {
 // @Failure 404 {object} swagger.ErrorResponse
 // @Failure 500 {object} swagger.ErrorResponse
 // @Security JWTAuth
+// @Security APIKeyAuth
 // @Router /collections/{collection_id}/films/{films_id} [delete]
 func deleteCollectionFilmHandler(w http.ResponseWriter, r *http.Request) {
 	collectionID, err := parseIDParam(r, "collectionID")
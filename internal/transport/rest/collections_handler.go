@@ -20,11 +20,12 @@ type collectionsQueryInput struct {
 
 // AddCollection godoc
 // @Summary Add new collection
-// @Description Add a new collection. You will be granted the permissions to get, update, and delete it.
+// @Description Add a new collection. You will own it: you can get, update, delete and share it.
 // @Tags collections
 // @Accept json
 // @Produce json
 // @Param collection body swagger.CollectionRequest true "Information about the new collection"
+// @Param include_permissions query bool false "Add the `_permissions` block with the actions you may perform"
 // @Success 201 {object} swagger.CollectionResponse
 // @Failure 400 {object} swagger.ErrorResponse
 // @Failure 401 {object} swagger.ErrorResponse
@@ -32,6 +33,7 @@ type collectionsQueryInput struct {
 // @Failure 422 {object} swagger.ErrorResponse
 // @Failure 500 {object} swagger.ErrorResponse
 // @Security JWTAuth
+// @Security APIKeyAuth
 // @Router /collections [post]
 func addCollectionHandler(w http.ResponseWriter, r *http.Request) {
 	userID := r.Context().Value("userID").(int)
@@ -53,16 +55,13 @@ func addCollectionHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Define permissions for the collection.
-	actions := []string{"read", "update", "delete"}
-	for _, action := range actions {
-		if err := addPermissionAndAssignToUser(userID, collection.ID, "collection", action); err != nil {
-			serverErrorResponse(w, r, err)
-			return
-		}
+	data := envelope{"collection": collection}
+	if err := addPermissionsBlock(r, data, "collection", collection.ID); err != nil {
+		handleDBError(w, r, err)
+		return
 	}
 
-	writeJSON(w, r, http.StatusCreated, envelope{"collection": collection})
+	writeJSON(w, r, http.StatusCreated, data)
 }
 
 // GetCollection godoc
@@ -72,12 +71,14 @@ func addCollectionHandler(w http.ResponseWriter, r *http.Request) {
 // @Accept json
 // @Produce json
 // @Param collection_id path int true "Collection ID"
+// @Param include_permissions query bool false "Add the `_permissions` block with the actions you may perform"
 // @Success 200 {object} swagger.CollectionResponse
 // @Failure 400 {object} swagger.ErrorResponse
 // @Failure 401 {object} swagger.ErrorResponse
 // @Failure 403 {object} swagger.ErrorResponse
 // @Failure 500 {object} swagger.ErrorResponse
 // @Security JWTAuth
+// @Security APIKeyAuth
 // @Router /collections/{collection_id} [get]
 func getCollectionHandler(w http.ResponseWriter, r *http.Request) {
 	collectionID, err := parseIDParam(r, "collectionID")
@@ -92,12 +93,18 @@ func getCollectionHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	writeJSON(w, r, http.StatusOK, envelope{"collection": collection})
+	data := envelope{"collection": collection}
+	if err := addPermissionsBlock(r, data, "collection", collectionID); err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, data)
 }
 
 // GetCollections godoc
 // @Summary Get user collections
-// @Description Get a list of collections by user ID from authentication token. It also returns metadata.
+// @Description Get a list of collections by user ID from authentication token, including the collections shared with the user. Each collection has the `role` of the user: owner, editor or viewer. It also returns metadata.
 // @Tags collections
 // @Accept json
 // @Produce json
@@ -107,11 +114,13 @@ func getCollectionHandler(w http.ResponseWriter, r *http.Request) {
 // @Param page query int false "Specify the desired `page`"
 // @Param page_size query int false "Specify the desired `page size`"
 // @Param sort query string false "Sorting by `id`, `name`, `created_at, total_films`. Use `-` for desc"
+// @Param include_permissions query bool false "Add the `_permissions` block with the actions you may perform to each item"
 // @Success 200 {object} swagger.CollectionsResponse
 // @Failure 401 {object} swagger.ErrorResponse
 // @Failure 422 {object} swagger.ErrorResponse
 // @Failure 500 {object} swagger.ErrorResponse
 // @Security JWTAuth
+// @Security APIKeyAuth
 // @Router /collections [get]
 func getCollectionsHandler(w http.ResponseWriter, r *http.Request) {
 	userID := r.Context().Value("userID").(int)
@@ -127,13 +136,19 @@ func getCollectionsHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Retrieve the list of collections based on the filters.
-	collections, metadata, err := postgres.GetCollections(userID, input.Name, input.Film, input.ExcludeFilm, input.Filters)
+	collections, metadata, err := postgres.GetCollections(userID, input.Name, input.Film, input.ExcludeFilm, true, input.Filters)
+	if err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	list, err := addCollectionsPermissions(r, collections)
 	if err != nil {
 		handleDBError(w, r, err)
 		return
 	}
 
-	writeJSON(w, r, http.StatusOK, envelope{"collections": collections, "metadata": metadata})
+	writeJSON(w, r, http.StatusOK, envelope{"collections": list, "metadata": metadata})
 }
 
 // UpdateCollection godoc
@@ -144,6 +159,7 @@ func getCollectionsHandler(w http.ResponseWriter, r *http.Request) {
 // @Produce json
 // @Param collection_id path int true "Collection ID"
 // @Param film body swagger.CollectionRequest true "New information about the collection"
+// @Param include_permissions query bool false "Add the `_permissions` block with the actions you may perform"
 // @Success 200 {object} swagger.FilmResponse
 // @Failure 400 {object} swagger.ErrorResponse
 // @Failure 401 {object} swagger.ErrorResponse
@@ -153,6 +169,7 @@ func getCollectionsHandler(w http.ResponseWriter, r *http.Request) {
 // @Failure 422 {object} swagger.ErrorResponse
 // @Failure 500 {object} swagger.ErrorResponse
 // @Security JWTAuth
+// @Security APIKeyAuth
 // @Router /collections/{collection_id} [put]
 func updateCollectionHandler(w http.ResponseWriter, r *http.Request) {
 	collectionID, err := parseIDParam(r, "collectionID")
@@ -187,7 +204,13 @@ func updateCollectionHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	writeJSON(w, r, http.StatusOK, envelope{"collection": collection})
+	data := envelope{"collection": collection}
+	if err := addPermissionsBlock(r, data, "collection", collectionID); err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, data)
 }
 
 // DeleteCollection godoc
@@ -204,6 +227,7 @@ func updateCollectionHandler(w http.ResponseWriter, r *http.Request) {
 // @Failure 404 {object} swagger.ErrorResponse
 // @Failure 500 {object} swagger.ErrorResponse
 // @Security JWTAuth
+// @Security APIKeyAuth
 // @Router /collections/{collection_id} [delete]
 func deleteCollectionHandler(w http.ResponseWriter, r *http.Request) {
 	collectionID, err := parseIDParam(r, "collectionID")
@@ -223,11 +247,6 @@ func deleteCollectionHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := deletePermissionCodes(collectionID, "collection"); err != nil {
-		handleDBError(w, r, err)
-		return
-	}
-
 	writeJSON(w, r, http.StatusOK, envelope{"message": "collection deleted"})
 }
 
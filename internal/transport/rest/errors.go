@@ -7,18 +7,52 @@ import (
 	"github.com/k4sper1love/watchlist-api/pkg/logger/sl"
 	"github.com/lib/pq"
 	"golang.org/x/crypto/bcrypt"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 )
 
 // Predefined error messages
 var (
-	errAlreadyExists       = errors.New("resource already exists")
-	errNotFound            = errors.New("resource not found")
-	errEmptyRequest        = errors.New("empty request body")
-	errForeignKeyViolation = errors.New("attempted to reference a non-existent record")
-	errInvalidToken        = errors.New("invalid token")
-	errInvalidRefreshToken = errors.New("invalid or revoked refresh token")
-	errRequiredPassword    = errors.New("password is required for this login method")
+	errAlreadyExists            = errors.New("resource already exists")
+	errNotFound                 = errors.New("resource not found")
+	errEmptyRequest             = errors.New("empty request body")
+	errForeignKeyViolation      = errors.New("attempted to reference a non-existent record")
+	errInvalidToken             = errors.New("invalid token")
+	errInvalidRefreshToken      = errors.New("invalid or revoked refresh token")
+	errInvalidVerificationToken = errors.New("invalid, expired or already used verification token")
+	errRefreshTokenReused       = errors.New("refresh token reuse detected")
+	errRequiredPassword         = errors.New("password is required for this login method")
+	errInvalidResetToken        = errors.New("invalid or expired password reset token")
+	errInvalidEmailToken        = errors.New("invalid or expired email verification token")
+	errRequiredEmail            = errors.New("email is required for this action")
+	errEmailAlreadyVerified     = errors.New("email is already verified")
+	errTwoFactorEnabled         = errors.New("two-factor authentication is already enabled")
+	errTwoFactorNotEnabled      = errors.New("two-factor authentication is not enabled")
+	errTwoFactorNotEnrolled     = errors.New("two-factor authentication enrollment has not been started")
+	errInvalidTwoFactorCode     = errors.New("invalid two-factor authentication code")
+	errInvalidTwoFactorToken    = errors.New("invalid or expired two-factor token")
+	errLoginLocked              = errors.New("too many failed login attempts, try again later")
+	errRateLimitExceeded        = errors.New("rate limit exceeded, try again later")
+	errInvalidAPIKeyExpiry      = errors.New("API key expiration must be in the future")
+	errOIDCDisabled             = errors.New("OpenID Connect login is not enabled")
+	errInvalidOIDCState         = errors.New("invalid or expired OpenID Connect state")
+	errOIDCLoginFailed          = errors.New("OpenID Connect login failed")
+	errIdentityAlreadyLinked    = errors.New("this login method is already linked to the account")
+	errLastLoginMethod          = errors.New("the last login method cannot be unlinked")
+	errAccountSuspended         = errors.New("the account is suspended")
+	errAdminSelfAction          = errors.New("admins cannot suspend themselves or revoke their own admin role")
+	errExportInProgress         = errors.New("an export of the account is already being generated")
+	errExportNotReady           = errors.New("the export is not ready for download")
+	errInvalidArchive           = errors.New("invalid account archive")
+	errImportConflicts          = errors.New("the archive conflicts with the account; check the conflicts or force the restore")
+	errImportNotPermitted       = errors.New("you don't have permission to create the films or collections of the archive")
+	errShareWithOwner           = errors.New("a collection cannot be shared with its owner")
+	errAlreadyCollaborator      = errors.New("the collection is already shared with this user")
+	errNotCollectionOwner       = errors.New("only the owner of the collection can manage its collaborators")
+	errGroupOwnerLeave          = errors.New("the owner cannot leave the group; delete the group instead")
+	errNotGroupOwner            = errors.New("only the owner of the group can remove other members and their films and collections")
 )
 
 // errorResponse sends a JSON response with an error message and status code.
@@ -72,6 +106,20 @@ func invalidAuthTokenResponse(w http.ResponseWriter, r *http.Request) {
 	sl.PrintEndpointWarn(message, nil, r)
 }
 
+// invalidAPIKeyResponse handles cases where the API key is invalid, revoked or expired.
+func invalidAPIKeyResponse(w http.ResponseWriter, r *http.Request) {
+	message := "invalid, revoked or expired API key"
+	errorResponse(w, r, http.StatusUnauthorized, message)
+	sl.PrintEndpointWarn(message, nil, r)
+}
+
+// apiKeyNotAllowedResponse handles requests that are not allowed by the scope of the API key.
+func apiKeyNotAllowedResponse(w http.ResponseWriter, r *http.Request) {
+	message := "this action is not allowed with the API key"
+	errorResponse(w, r, http.StatusForbidden, message)
+	sl.PrintEndpointWarn(message, nil, r)
+}
+
 // invalidVerificationTokenResponse handles cases where the verification token is invalid or missing.
 func invalidVerificationTokenResponse(w http.ResponseWriter, r *http.Request) {
 	message := "invalid or missing verification token"
@@ -85,6 +133,20 @@ func incorrectPasswordResponse(w http.ResponseWriter, r *http.Request) {
 	sl.PrintEndpointWarn(message, nil, r)
 }
 
+// unauthorizedResponse handles authentication failures with a specific error message.
+func unauthorizedResponse(w http.ResponseWriter, r *http.Request, err error) {
+	errorResponse(w, r, http.StatusUnauthorized, err.Error())
+	sl.PrintEndpointWarn("unauthorized", err, r)
+}
+
+// tooManyRequestsResponse handles requests rejected until the given time has passed, setting the Retry-After header.
+func tooManyRequestsResponse(w http.ResponseWriter, r *http.Request, err error, retryAfter time.Duration) {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	w.Header().Set("Retry-After", strconv.Itoa(seconds))
+	errorResponse(w, r, http.StatusTooManyRequests, err.Error())
+	sl.PrintEndpointWarn("too many requests", err, r)
+}
+
 // failedValidationResponse handles cases where input validation fails.
 func failedValidationResponse(w http.ResponseWriter, r *http.Request, errs map[string]string) {
 	errorResponse(w, r, http.StatusUnprocessableEntity, errs)
@@ -101,6 +163,7 @@ func forbiddenResponse(w http.ResponseWriter, r *http.Request) {
 // handleDBError processes database errors and maps them to appropriate HTTP responses.
 func handleDBError(w http.ResponseWriter, r *http.Request, err error) {
 	var pqErr *pq.Error
+	var lockedErr *loginLockedError
 
 	switch {
 	case errors.As(err, &pqErr):
@@ -114,14 +177,33 @@ func handleDBError(w http.ResponseWriter, r *http.Request, err error) {
 		}
 		serverErrorResponse(w, r, err)
 		return
+	case errors.As(err, &lockedErr):
+		tooManyRequestsResponse(w, r, lockedErr, lockedErr.retryAfter)
+		return
 	case errors.Is(err, sql.ErrNoRows):
 		notFoundResponse(w, r)
 		return
 	case errors.Is(err, bcrypt.ErrMismatchedHashAndPassword):
 		incorrectPasswordResponse(w, r)
 		return
-	case errors.Is(err, errRequiredPassword):
+	case errors.Is(err, errRequiredPassword), errors.Is(err, errInvalidResetToken),
+		errors.Is(err, errInvalidEmailToken), errors.Is(err, errRequiredEmail), errors.Is(err, errEmailAlreadyVerified),
+		errors.Is(err, errTwoFactorNotEnabled), errors.Is(err, errTwoFactorNotEnrolled), errors.Is(err, errInvalidAPIKeyExpiry),
+		errors.Is(err, errInvalidOIDCState), errors.Is(err, errAdminSelfAction), errors.Is(err, errInvalidArchive),
+		errors.Is(err, errShareWithOwner), errors.Is(err, errGroupOwnerLeave):
 		badRequestResponse(w, r, err)
+	case errors.Is(err, errAccountSuspended), errors.Is(err, errNotCollectionOwner), errors.Is(err, errNotGroupOwner),
+		errors.Is(err, errImportNotPermitted):
+		errorResponse(w, r, http.StatusForbidden, err.Error())
+		sl.PrintEndpointWarn("forbidden", err, r)
+	case errors.Is(err, errOIDCDisabled):
+		notFoundResponse(w, r)
+	case errors.Is(err, errTwoFactorEnabled), errors.Is(err, errIdentityAlreadyLinked), errors.Is(err, errLastLoginMethod),
+		errors.Is(err, errExportInProgress), errors.Is(err, errExportNotReady), errors.Is(err, errAlreadyCollaborator):
+		uniqueConflictResponse(w, r, err)
+	case errors.Is(err, errInvalidTwoFactorCode), errors.Is(err, errInvalidTwoFactorToken),
+		errors.Is(err, errOIDCLoginFailed):
+		unauthorizedResponse(w, r, err)
 	default:
 		serverErrorResponse(w, r, err)
 		return
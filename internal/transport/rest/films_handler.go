@@ -12,10 +12,11 @@ import (
 
 // AddFilm godoc
 // @Summary Add new film
-// @Description Add a new film. You will be granted the permissions to get, update, and delete it.
+// @Description Add a new film. You will own it: you can get, update, and delete it.
 // @Tags films
 // @Accept json
 // @Produce json
+// @Param include_permissions query bool false "Add the `_permissions` block with the actions you may perform"
 // @Param film body swagger.FilmRequest true "Information about the new film".// @Success 201 {object} swagger.FilmResponse
 // @Failure 400 {object} swagger.ErrorResponse
 // @Failure 401 {object} swagger.ErrorResponse
@@ -23,6 +24,7 @@ import (
 // @Failure 422 {object} swagger.ErrorResponse
 // @Failure 500 {object} swagger.ErrorResponse
 // @Security JWTAuth
+// @Security APIKeyAuth
 // @Router /films [post]
 func addFilmHandler(w http.ResponseWriter, r *http.Request) {
 	userID := r.Context().Value("userID").(int)
@@ -46,16 +48,13 @@ func addFilmHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Define permissions for the film.
-	actions := []string{"read", "update", "delete"}
-	for _, action := range actions {
-		if err := addPermissionAndAssignToUser(userID, film.ID, "film", action); err != nil {
-			serverErrorResponse(w, r, err)
-			return
-		}
+	data := envelope{"film": film}
+	if err := addPermissionsBlock(r, data, "film", film.ID); err != nil {
+		handleDBError(w, r, err)
+		return
 	}
 
-	writeJSON(w, r, http.StatusCreated, envelope{"film": film})
+	writeJSON(w, r, http.StatusCreated, data)
 }
 
 // GetFilm godoc
@@ -65,12 +64,14 @@ func addFilmHandler(w http.ResponseWriter, r *http.Request) {
 // @Accept json
 // @Produce json
 // @Param film_id path int true "Film ID"
+// @Param include_permissions query bool false "Add the `_permissions` block with the actions you may perform"
 // @Success 200 {object} swagger.FilmResponse
 // @Failure 400 {object} swagger.ErrorResponse
 // @Failure 401 {object} swagger.ErrorResponse
 // @Failure 403 {object} swagger.ErrorResponse
 // @Failure 500 {object} swagger.ErrorResponse
 // @Security JWTAuth
+// @Security APIKeyAuth
 // @Router /films/{film_id} [get]
 func getFilmHandler(w http.ResponseWriter, r *http.Request) {
 	id, err := parseIDParam(r, "filmID")
@@ -85,15 +86,22 @@ func getFilmHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	writeJSON(w, r, http.StatusOK, envelope{"film": film})
+	data := envelope{"film": film}
+	if err := addPermissionsBlock(r, data, "film", id); err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, data)
 }
 
 // GetFilms godoc
 // @Summary Get user films
-// @Description Get a list of films by user ID from authentication token. It also returns metadata.
+// @Description Get a list of films by user ID from authentication token, or the library of a group the user is a member of. It also returns metadata.
 // @Tags films
 // @Accept json
 // @Produce json
+// @Param scope query string false "List `personal` films (default) or the library of a group, as in `group:1`"
 // @Param title query string false "Filter by `title`"
 // @Param rating query string false "Filter by `rating`, can be a specific value or a range like 'min-max'"
 // @Param year query string false "Filter by `year`"
@@ -105,11 +113,14 @@ func getFilmHandler(w http.ResponseWriter, r *http.Request) {
 // @Param page query int false "Specify the desired `page`"
 // @Param page_size query int false "Specify the desired `page size`"
 // @Param sort query string false "Sorting by `id`, `title`, `rating`, `year`, `user_rating`, `is_viewed`. Use `-` for desc"
+// @Param include_permissions query bool false "Add the `_permissions` block with the actions you may perform to each item"
 // @Success 200 {object} swagger.FilmsResponse
 // @Failure 401 {object} swagger.ErrorResponse
+// @Failure 403 {object} swagger.ErrorResponse
 // @Failure 422 {object} swagger.ErrorResponse
 // @Failure 500 {object} swagger.ErrorResponse
 // @Security JWTAuth
+// @Security APIKeyAuth
 // @Router /films [get]
 func getFilmsHandler(w http.ResponseWriter, r *http.Request) {
 	userId := r.Context().Value("userID").(int)
@@ -124,6 +135,26 @@ func getFilmsHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// List the library of a group instead of the personal films if requested. The user must be a member of the group.
+	groupID, ok := parseGroupScope(r.URL.Query())
+	if !ok {
+		failedValidationResponse(w, r, map[string]string{"scope": "must be personal or group:<id>"})
+		return
+	}
+
+	if groupID > 0 {
+		member, err := postgres.HasAccess(userId, "group", groupID, "read")
+		if err != nil {
+			handleDBError(w, r, err)
+			return
+		}
+		if !member {
+			forbiddenResponse(w, r)
+			return
+		}
+		input.GroupID = groupID
+	}
+
 	// Retrieve the list of films based on the filters.
 	films, metadata, err := postgres.GetFilms(userId, input)
 	if err != nil {
@@ -131,7 +162,13 @@ func getFilmsHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	writeJSON(w, r, http.StatusOK, envelope{"films": films, "metadata": metadata})
+	list, err := addFilmsPermissions(r, films)
+	if err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, envelope{"films": list, "metadata": metadata})
 }
 
 // UpdateFilm godoc
@@ -141,6 +178,7 @@ func getFilmsHandler(w http.ResponseWriter, r *http.Request) {
 // @Accept json
 // @Produce json
 // @Param film_id path int true "Film ID"
+// @Param include_permissions query bool false "Add the `_permissions` block with the actions you may perform"
 // @Param film body swagger.FilmRequest true "New information about the film"// @Success 200 {object} swagger.FilmResponse
 // @Failure 400 {object} swagger.ErrorResponse
 // @Failure 401 {object} swagger.ErrorResponse
@@ -150,6 +188,7 @@ func getFilmsHandler(w http.ResponseWriter, r *http.Request) {
 // @Failure 422 {object} swagger.ErrorResponse
 // @Failure 500 {object} swagger.ErrorResponse
 // @Security JWTAuth
+// @Security APIKeyAuth
 // @Router /films/{film_id} [put]
 func updateFilmHandler(w http.ResponseWriter, r *http.Request) {
 	id, err := parseIDParam(r, "filmID")
@@ -186,7 +225,13 @@ func updateFilmHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	writeJSON(w, r, http.StatusOK, envelope{"film": film})
+	data := envelope{"film": film}
+	if err := addPermissionsBlock(r, data, "film", id); err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, data)
 }
 
 // DeleteFilm godoc
@@ -203,6 +248,7 @@ func updateFilmHandler(w http.ResponseWriter, r *http.Request) {
 // @Failure 404 {object} swagger.ErrorResponse
 // @Failure 500 {object} swagger.ErrorResponse
 // @Security JWTAuth
+// @Security APIKeyAuth
 // @Router /films/{film_id} [delete]
 func deleteFilmHandler(w http.ResponseWriter, r *http.Request) {
 	id, err := parseIDParam(r, "filmID")
@@ -222,11 +268,6 @@ func deleteFilmHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := deletePermissionCodes(id, "film"); err != nil {
-		handleDBError(w, r, err)
-		return
-	}
-
 	writeJSON(w, r, http.StatusOK, envelope{"message": "film deleted"})
 }
 
@@ -0,0 +1,103 @@
+package rest
+
+import (
+	"github.com/k4sper1love/watchlist-api/internal/database/postgres"
+	"github.com/k4sper1love/watchlist-api/pkg/models"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// groupScopePrefix is the prefix of the scope query parameter that lists the library of a group, as in group:1.
+const groupScopePrefix = "group:"
+
+// getGroupWithMembers retrieves a group with its members and the role of the user in it.
+func getGroupWithMembers(userID, groupID int) (*models.Group, []*models.GroupMember, error) {
+	group, err := postgres.GetGroup(groupID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	members, err := postgres.GetGroupMembers(groupID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	group.Role = models.GroupRoleMember
+	if group.UserID == userID {
+		group.Role = models.GroupRoleOwner
+	}
+
+	return group, members, nil
+}
+
+// addGroupMember adds the user by username to a group.
+func addGroupMember(groupID int, username string) (*models.GroupMember, error) {
+	user, err := getInvitee(username)
+	if err != nil {
+		return nil, err
+	}
+
+	return postgres.AddGroupMember(groupID, user.ID)
+}
+
+// removeGroupMember removes a user from a group. The owner can remove any member,
+// and members can only remove themselves to leave the group. The owner cannot leave.
+func removeGroupMember(actorID, groupID, userID int) error {
+	group, err := postgres.GetGroup(groupID)
+	if err != nil {
+		return err
+	}
+
+	if userID == group.UserID {
+		return errGroupOwnerLeave
+	}
+
+	if actorID != group.UserID && actorID != userID {
+		return errNotGroupOwner
+	}
+
+	return postgres.DeleteGroupMember(groupID, userID)
+}
+
+// removeGroupResource removes a film or collection from the library of a group.
+// The owner of the group and the owner of the resource can remove it.
+func removeGroupResource(actorID, groupID int, resourceType string, resourceID int) error {
+	group, err := postgres.GetGroup(groupID)
+	if err != nil {
+		return err
+	}
+
+	if actorID != group.UserID {
+		// Only owners may share a resource, so the check finds whether the user owns it.
+		owner, err := postgres.HasAccess(actorID, resourceType, resourceID, "share")
+		if err != nil {
+			return err
+		}
+		if !owner {
+			return errNotGroupOwner
+		}
+	}
+
+	return postgres.DeleteGroupResource(groupID, resourceType, resourceID)
+}
+
+// parseGroupScope parses the scope query parameter: personal or group:<id>.
+// It returns the ID of the group, or 0 for the personal scope, and false if the scope is invalid.
+func parseGroupScope(qs url.Values) (int, bool) {
+	scope := parseQueryString(qs, "scope", "personal")
+	if scope == "personal" {
+		return 0, true
+	}
+
+	if !strings.HasPrefix(scope, groupScopePrefix) {
+		return 0, false
+	}
+
+	groupID, err := strconv.Atoi(strings.TrimPrefix(scope, groupScopePrefix))
+	if err != nil || groupID < 1 {
+		return 0, false
+	}
+
+	return groupID, true
+}
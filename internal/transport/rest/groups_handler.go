@@ -0,0 +1,350 @@
+package rest
+
+import (
+	"github.com/k4sper1love/watchlist-api/internal/database/postgres"
+	"github.com/k4sper1love/watchlist-api/pkg/models"
+	"github.com/k4sper1love/watchlist-api/pkg/validator"
+	"net/http"
+)
+
+// AddGroup godoc
+// @Summary Create a group
+// @Description Create a group, such as a household, to share a library of films and collections. You will be its owner.
+// @Tags groups
+// @Accept json
+// @Produce json
+// @Param group body swagger.GroupRequest true "Name of the group"
+// @Success 201 {object} swagger.GroupResponse
+// @Failure 400 {object} swagger.ErrorResponse
+// @Failure 401 {object} swagger.ErrorResponse
+// @Failure 422 {object} swagger.ErrorResponse
+// @Failure 500 {object} swagger.ErrorResponse
+// @Security JWTAuth
+// @Security APIKeyAuth
+// @Router /groups [post]
+func addGroupHandler(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(int)
+
+	var group models.Group
+	if err := parseRequestBody(r, &group); err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	group.UserID = userID
+
+	if errs := validator.ValidateStruct(&group); errs != nil {
+		failedValidationResponse(w, r, errs)
+		return
+	}
+
+	if err := postgres.AddGroup(&group); err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	writeJSON(w, r, http.StatusCreated, envelope{"group": group})
+}
+
+// GetGroups godoc
+// @Summary Get user groups
+// @Description Get the groups the user is a member of, with the role of the user in each group.
+// @Tags groups
+// @Accept json
+// @Produce json
+// @Success 200 {object} swagger.GroupsResponse
+// @Failure 401 {object} swagger.ErrorResponse
+// @Failure 500 {object} swagger.ErrorResponse
+// @Security JWTAuth
+// @Security APIKeyAuth
+// @Router /groups [get]
+func getGroupsHandler(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(int)
+
+	groups, err := postgres.GetUserGroups(userID)
+	if err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, envelope{"groups": groups})
+}
+
+// GetGroup godoc
+// @Summary Get group by ID
+// @Description Get the group by ID with its members. You must be a member of the group.
+// @Tags groups
+// @Accept json
+// @Produce json
+// @Param group_id path int true "Group ID"
+// @Success 200 {object} swagger.GroupMembersResponse
+// @Failure 400 {object} swagger.ErrorResponse
+// @Failure 401 {object} swagger.ErrorResponse
+// @Failure 403 {object} swagger.ErrorResponse
+// @Failure 500 {object} swagger.ErrorResponse
+// @Security JWTAuth
+// @Security APIKeyAuth
+// @Router /groups/{group_id} [get]
+func getGroupHandler(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(int)
+
+	groupID, err := parseIDParam(r, "groupID")
+	if err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	group, members, err := getGroupWithMembers(userID, groupID)
+	if err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, envelope{"group": group, "members": members})
+}
+
+// DeleteGroup godoc
+// @Summary Delete the group
+// @Description Delete the group by ID. The films and collections of its library stay with the members who added them. You must own the group.
+// @Tags groups
+// @Accept json
+// @Produce json
+// @Param group_id path int true "Group ID"
+// @Success 200 {object} swagger.MessageResponse
+// @Failure 400 {object} swagger.ErrorResponse
+// @Failure 401 {object} swagger.ErrorResponse
+// @Failure 403 {object} swagger.ErrorResponse
+// @Failure 500 {object} swagger.ErrorResponse
+// @Security JWTAuth
+// @Security APIKeyAuth
+// @Router /groups/{group_id} [delete]
+func deleteGroupHandler(w http.ResponseWriter, r *http.Request) {
+	groupID, err := parseIDParam(r, "groupID")
+	if err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	if err := postgres.DeleteGroup(groupID); err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, envelope{"message": "group deleted"})
+}
+
+// AddGroupMember godoc
+// @Summary Add a member to the group
+// @Description Invite a user by username to the group. Members can get and update the films and collections of the group library. You must own the group.
+// @Tags groups
+// @Accept json
+// @Produce json
+// @Param group_id path int true "Group ID"
+// @Param member body swagger.GroupMemberRequest true "Username of the user"
+// @Success 201 {object} swagger.GroupMemberResponse
+// @Failure 400 {object} swagger.ErrorResponse
+// @Failure 401 {object} swagger.ErrorResponse
+// @Failure 403 {object} swagger.ErrorResponse
+// @Failure 404 {object} swagger.ErrorResponse
+// @Failure 409 {object} swagger.ErrorResponse
+// @Failure 422 {object} swagger.ErrorResponse
+// @Failure 500 {object} swagger.ErrorResponse
+// @Security JWTAuth
+// @Security APIKeyAuth
+// @Router /groups/{group_id}/members [post]
+func addGroupMemberHandler(w http.ResponseWriter, r *http.Request) {
+	groupID, err := parseIDParam(r, "groupID")
+	if err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	var input models.GroupMemberInvite
+	if err := parseRequestBody(r, &input); err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	if errs := validator.ValidateStruct(&input); errs != nil {
+		failedValidationResponse(w, r, errs)
+		return
+	}
+
+	member, err := addGroupMember(groupID, input.Username)
+	if err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	writeJSON(w, r, http.StatusCreated, envelope{"member": member})
+}
+
+// DeleteGroupMember godoc
+// @Summary Remove a member from the group
+// @Description Remove a user from the group; the films and collections they added leave the group library. The owner can remove any member; members can remove themselves to leave the group.
+// @Tags groups
+// @Accept json
+// @Produce json
+// @Param group_id path int true "Group ID"
+// @Param user_id path int true "User ID"
+// @Success 200 {object} swagger.MessageResponse
+// @Failure 400 {object} swagger.ErrorResponse
+// @Failure 401 {object} swagger.ErrorResponse
+// @Failure 403 {object} swagger.ErrorResponse
+// @Failure 404 {object} swagger.ErrorResponse
+// @Failure 500 {object} swagger.ErrorResponse
+// @Security JWTAuth
+// @Security APIKeyAuth
+// @Router /groups/{group_id}/members/{user_id} [delete]
+func deleteGroupMemberHandler(w http.ResponseWriter, r *http.Request) {
+	actorID := r.Context().Value("userID").(int)
+
+	groupID, err := parseIDParam(r, "groupID")
+	if err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	userID, err := parseIDParam(r, "userID")
+	if err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	if err := removeGroupMember(actorID, groupID, userID); err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, envelope{"message": "member removed"})
+}
+
+// AddGroupFilm godoc
+// @Summary Add a film to the group library
+// @Description Add the film to the library of the group, so that all members can get and update it. You must own the film and be a member of the group.
+// @Tags groups
+// @Accept json
+// @Produce json
+// @Param group_id path int true "Group ID"
+// @Param film_id path int true "Film ID"
+// @Success 201 {object} swagger.MessageResponse
+// @Failure 400 {object} swagger.ErrorResponse
+// @Failure 401 {object} swagger.ErrorResponse
+// @Failure 403 {object} swagger.ErrorResponse
+// @Failure 409 {object} swagger.ErrorResponse
+// @Failure 500 {object} swagger.ErrorResponse
+// @Security JWTAuth
+// @Security APIKeyAuth
+// @Router /groups/{group_id}/films/{film_id} [post]
+func addGroupFilmHandler(w http.ResponseWriter, r *http.Request) {
+	addGroupResource(w, r, "film", "filmID")
+}
+
+// DeleteGroupFilm godoc
+// @Summary Remove a film from the group library
+// @Description Remove the film from the library of the group. The film stays with its owner. You must own the film or the group.
+// @Tags groups
+// @Accept json
+// @Produce json
+// @Param group_id path int true "Group ID"
+// @Param film_id path int true "Film ID"
+// @Success 200 {object} swagger.MessageResponse
+// @Failure 400 {object} swagger.ErrorResponse
+// @Failure 401 {object} swagger.ErrorResponse
+// @Failure 403 {object} swagger.ErrorResponse
+// @Failure 404 {object} swagger.ErrorResponse
+// @Failure 500 {object} swagger.ErrorResponse
+// @Security JWTAuth
+// @Security APIKeyAuth
+// @Router /groups/{group_id}/films/{film_id} [delete]
+func deleteGroupFilmHandler(w http.ResponseWriter, r *http.Request) {
+	deleteGroupResource(w, r, "film", "filmID")
+}
+
+// AddGroupCollection godoc
+// @Summary Add a collection to the group library
+// @Description Add the collection to the library of the group, so that all members can get and update it. You must own the collection and be a member of the group.
+// @Tags groups
+// @Accept json
+// @Produce json
+// @Param group_id path int true "Group ID"
+// @Param collection_id path int true "Collection ID"
+// @Success 201 {object} swagger.MessageResponse
+// @Failure 400 {object} swagger.ErrorResponse
+// @Failure 401 {object} swagger.ErrorResponse
+// @Failure 403 {object} swagger.ErrorResponse
+// @Failure 409 {object} swagger.ErrorResponse
+// @Failure 500 {object} swagger.ErrorResponse
+// @Security JWTAuth
+// @Security APIKeyAuth
+// @Router /groups/{group_id}/collections/{collection_id} [post]
+func addGroupCollectionHandler(w http.ResponseWriter, r *http.Request) {
+	addGroupResource(w, r, "collection", "collectionID")
+}
+
+// DeleteGroupCollection godoc
+// @Summary Remove a collection from the group library
+// @Description Remove the collection from the library of the group. The collection stays with its owner. You must own the collection or the group.
+// @Tags groups
+// @Accept json
+// @Produce json
+// @Param group_id path int true "Group ID"
+// @Param collection_id path int true "Collection ID"
+// @Success 200 {object} swagger.MessageResponse
+// @Failure 400 {object} swagger.ErrorResponse
+// @Failure 401 {object} swagger.ErrorResponse
+// @Failure 403 {object} swagger.ErrorResponse
+// @Failure 404 {object} swagger.ErrorResponse
+// @Failure 500 {object} swagger.ErrorResponse
+// @Security JWTAuth
+// @Security APIKeyAuth
+// @Router /groups/{group_id}/collections/{collection_id} [delete]
+func deleteGroupCollectionHandler(w http.ResponseWriter, r *http.Request) {
+	deleteGroupResource(w, r, "collection", "collectionID")
+}
+
+// addGroupResource adds the film or collection identified by the route parameter to the library of the group.
+func addGroupResource(w http.ResponseWriter, r *http.Request, resourceType, paramName string) {
+	groupID, err := parseIDParam(r, "groupID")
+	if err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	resourceID, err := parseIDParam(r, paramName)
+	if err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	if err := postgres.AddGroupResource(groupID, resourceType, resourceID); err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	writeJSON(w, r, http.StatusCreated, envelope{"message": resourceType + " added to the group"})
+}
+
+// deleteGroupResource removes the film or collection identified by the route parameter from the library of the group.
+func deleteGroupResource(w http.ResponseWriter, r *http.Request, resourceType, paramName string) {
+	actorID := r.Context().Value("userID").(int)
+
+	groupID, err := parseIDParam(r, "groupID")
+	if err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	resourceID, err := parseIDParam(r, paramName)
+	if err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	if err := removeGroupResource(actorID, groupID, resourceType, resourceID); err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, envelope{"message": resourceType + " removed from the group"})
+}
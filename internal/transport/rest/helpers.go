@@ -4,14 +4,16 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"github.com/golang-jwt/jwt"
 	"github.com/gorilla/mux"
+	"github.com/k4sper1love/watchlist-api/internal/config"
 	"github.com/k4sper1love/watchlist-api/internal/database/postgres"
 	"github.com/k4sper1love/watchlist-api/pkg/metrics"
 	"github.com/k4sper1love/watchlist-api/pkg/models"
+	"github.com/k4sper1love/watchlist-api/pkg/tokens"
 	"io"
 	"log/slog"
 	"math/rand"
+	"net"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -78,40 +80,43 @@ func parseTokenFromHeader(r *http.Request) string {
 	return strings.TrimPrefix(tokenHeader, "Bearer ")
 }
 
-// parseTokenClaims parses and validates a JWT token string, extracting the claims if valid.
-func parseTokenClaims(tokenString, secret string) (*models.JWTClaims, error) {
-	claims := &models.JWTClaims{}
-	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-		return []byte(secret), nil
-	})
-
-	if err != nil || !token.Valid {
-		return nil, errInvalidToken
+// getClientIP returns the IP address of the client that sent the request.
+// Proxy headers are only taken into account if config.TrustProxy is set.
+func getClientIP(r *http.Request) string {
+	if config.TrustProxy {
+		if ip := r.Header.Get("X-Real-IP"); ip != "" {
+			return ip
+		}
+		if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+			return strings.TrimSpace(strings.Split(forwarded, ",")[0])
+		}
 	}
 
-	return claims, nil
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
 }
 
-// addPermissionAndAssignToUser adds a permission string to the database and assigns it to a user.
-func addPermissionAndAssignToUser(userID, objectID int, objectType, action string) error {
-	permission := fmt.Sprintf("%s:%d:%s", objectType, objectID, action)
-
-	if err := postgres.AddPermission(permission); err != nil {
-		return err
+// newSession creates a session description from the client details of the request.
+func newSession(r *http.Request) *models.Session {
+	return &models.Session{
+		UserAgent: r.UserAgent(),
+		ClientIP:  getClientIP(r),
 	}
-
-	return postgres.AddUserPermissions(userID, permission)
 }
 
-// deletePermissionCodes removes permission codes related to an object from the database.
-func deletePermissionCodes(objectID int, objectType string) error {
-	codes := []string{
-		fmt.Sprintf("%s:%d:%s", objectType, objectID, "read"),
-		fmt.Sprintf("%s:%d:%s", objectType, objectID, "update"),
-		fmt.Sprintf("%s:%d:%s", objectType, objectID, "delete"),
+// parseTokenClaims parses and validates a JWT token string with the given keys, extracting the claims if valid.
+func parseTokenClaims(tokenString string, keys *tokens.KeySet) (*models.JWTClaims, error) {
+	claims := &models.JWTClaims{}
+	token, err := keys.Parse(tokenString, claims)
+
+	if err != nil || !token.Valid {
+		return nil, errInvalidToken
 	}
 
-	return postgres.DeletePermissions(codes...)
+	return claims, nil
 }
 
 // parseQuery is a generic function for parsing query parameters from a URL.Values map.
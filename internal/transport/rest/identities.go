@@ -0,0 +1,89 @@
+package rest
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/k4sper1love/watchlist-api/internal/database/postgres"
+	"github.com/k4sper1love/watchlist-api/pkg/models"
+)
+
+// linkTelegramIdentity links the Telegram account of a verification token to a user without one.
+func linkTelegramIdentity(userID, telegramID int) error {
+	user, err := postgres.GetUserById(userID)
+	if err != nil {
+		return err
+	}
+
+	if user.TelegramID != -1 {
+		return errIdentityAlreadyLinked
+	}
+
+	if err := postgres.LinkTelegramIdentity(userID, telegramID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errIdentityAlreadyLinked
+		}
+		return err
+	}
+
+	return nil
+}
+
+// linkPasswordIdentity sets the username and password of a user without a password, allowing login with credentials.
+func linkPasswordIdentity(userID int, input *models.PasswordIdentity) error {
+	user, err := postgres.GetUserById(userID)
+	if err != nil {
+		return err
+	}
+
+	if user.Password != "" {
+		return errIdentityAlreadyLinked
+	}
+
+	credentials := &models.Credentials{Password: input.Password}
+	if err := hashPassword(credentials); err != nil {
+		return err
+	}
+
+	if err := postgres.LinkPasswordIdentity(userID, input.Username, credentials.Password); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errIdentityAlreadyLinked
+		}
+		return err
+	}
+
+	return nil
+}
+
+// unlinkIdentity removes a login method of a user. The last login method cannot be removed.
+func unlinkIdentity(userID, identityID int) error {
+	identities, err := postgres.GetUserIdentities(userID)
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for _, identity := range identities {
+		if identity.ID == identityID {
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		return sql.ErrNoRows
+	}
+
+	if len(identities) == 1 {
+		return errLastLoginMethod
+	}
+
+	if _, err := postgres.DeleteUserIdentity(userID, identityID); err != nil {
+		// The identity exists, so a concurrent request has removed the other login methods.
+		if errors.Is(err, sql.ErrNoRows) {
+			return errLastLoginMethod
+		}
+		return err
+	}
+
+	return nil
+}
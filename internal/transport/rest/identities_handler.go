@@ -0,0 +1,128 @@
+package rest
+
+import (
+	"github.com/k4sper1love/watchlist-api/internal/database/postgres"
+	"github.com/k4sper1love/watchlist-api/pkg/models"
+	"github.com/k4sper1love/watchlist-api/pkg/validator"
+	"net/http"
+)
+
+// GetIdentities godoc
+// @Summary Get login methods
+// @Description Get a list of login methods linked to the account: password, Telegram and OpenID Connect identities.
+// @Tags user
+// @Accept json
+// @Produce json
+// @Success 200 {object} swagger.IdentitiesResponse
+// @Failure 401 {object} swagger.ErrorResponse
+// @Failure 500 {object} swagger.ErrorResponse
+// @Security JWTAuth
+// @Router /user/identities [get]
+func getIdentitiesHandler(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(int)
+
+	identities, err := postgres.GetUserIdentities(userID)
+	if err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, envelope{"identities": identities})
+}
+
+// LinkTelegramIdentity godoc
+// @Summary Link a Telegram account
+// @Description Link the Telegram account of the verification token from header to the account, allowing login by Telegram.
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param Verification header string true "Verification token from Telegram"
+// @Success 200 {object} swagger.MessageResponse
+// @Failure 401 {object} swagger.ErrorResponse
+// @Failure 403 {object} swagger.ErrorResponse
+// @Failure 409 {object} swagger.ErrorResponse
+// @Failure 500 {object} swagger.ErrorResponse
+// @Security JWTAuth
+// @Router /user/identities/telegram [post]
+func linkTelegramIdentityHandler(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(int)
+	telegramID := r.Context().Value("telegramID").(int)
+
+	if err := linkTelegramIdentity(userID, telegramID); err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, envelope{"message": "Telegram account linked"})
+}
+
+// LinkPasswordIdentity godoc
+// @Summary Link a username and password
+// @Description Set a username and password for an account without a password, allowing login with credentials.
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param data body swagger.PasswordIdentityRequest true "Username and password"
+// @Success 200 {object} swagger.MessageResponse
+// @Failure 400 {object} swagger.ErrorResponse
+// @Failure 401 {object} swagger.ErrorResponse
+// @Failure 409 {object} swagger.ErrorResponse
+// @Failure 422 {object} swagger.ErrorResponse
+// @Failure 500 {object} swagger.ErrorResponse
+// @Security JWTAuth
+// @Router /user/identities/password [post]
+func linkPasswordIdentityHandler(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(int)
+
+	var input models.PasswordIdentity
+
+	if err := parseRequestBody(r, &input); err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	if errs := validator.ValidateStruct(&input); errs != nil {
+		failedValidationResponse(w, r, errs)
+		return
+	}
+
+	if err := linkPasswordIdentity(userID, &input); err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, envelope{"message": "password linked"})
+}
+
+// DeleteIdentity godoc
+// @Summary Unlink a login method
+// @Description Unlink the login method by ID. Unlinking the password or Telegram identity removes it from the account.
+// @Description The last login method cannot be unlinked.
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param identity_id path int true "Identity ID"
+// @Success 200 {object} swagger.MessageResponse
+// @Failure 400 {object} swagger.ErrorResponse
+// @Failure 401 {object} swagger.ErrorResponse
+// @Failure 404 {object} swagger.ErrorResponse
+// @Failure 409 {object} swagger.ErrorResponse
+// @Failure 500 {object} swagger.ErrorResponse
+// @Security JWTAuth
+// @Router /user/identities/{identity_id} [delete]
+func deleteIdentityHandler(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(int)
+
+	identityID, err := parseIDParam(r, "identityID")
+	if err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	if err := unlinkIdentity(userID, identityID); err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, envelope{"message": "login method unlinked"})
+}
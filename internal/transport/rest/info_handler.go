@@ -13,6 +13,7 @@ var (
 	availableEndpoints = []Endpoint{
 		{Path: "/swagger/index.html", Description: "API documentation"},
 		{Path: "/api/v1/healthcheck", Description: "Server status"},
+		{Path: "/.well-known/jwks.json", Description: "Public keys for verifying tokens"},
 		{Path: "/api/v1/auth", Description: "Authorization"},
 		{Path: "/api/v1/user", Description: "Profile Management"},
 		{Path: "/api/v1/films", Description: "Films Management"},
@@ -0,0 +1,12 @@
+package rest
+
+import (
+	"net/http"
+)
+
+// jwksHandler publishes the public keys for verifying access and refresh tokens as a JSON Web Key Set.
+// Retired keys stay in the set until they are removed from the keys folder.
+func jwksHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	writeJSON(w, r, http.StatusOK, envelope{"keys": signingKeys.JWKS().Keys})
+}
@@ -0,0 +1,100 @@
+package rest
+
+import (
+	"fmt"
+	"github.com/k4sper1love/watchlist-api/internal/config"
+	"github.com/k4sper1love/watchlist-api/pkg/tokens"
+	"log/slog"
+	"strconv"
+	"time"
+)
+
+var (
+	signingKeys      *tokens.KeySet // Keys for signing and verifying access and refresh tokens.
+	verificationKeys *tokens.KeySet // Keys for verifying tokens issued by the Telegram bot.
+)
+
+// initKeys sets up the keys for signing and verifying tokens from the configuration.
+func initKeys() error {
+	var err error
+
+	if signingKeys, err = newSigningKeys(); err != nil {
+		return err
+	}
+
+	if verificationKeys, err = tokens.NewKeySet(tokens.NewHMACKey("", []byte(config.TelegramSecret))); err != nil {
+		return err
+	}
+
+	slog.Info("configured JWT signing",
+		slog.String("algorithm", signingKeys.SigningKey().Method.Alg()),
+		slog.String("kid", signingKeys.SigningKey().ID),
+	)
+	return nil
+}
+
+// newSigningKeys creates the key set for access and refresh tokens.
+// HS256 uses the shared secret. RS256 and EdDSA use the keys from the keys folder; if it has no suitable private key,
+// a temporary key is generated and tokens are no longer valid after a restart.
+func newSigningKeys() (*tokens.KeySet, error) {
+	switch config.JWTAlgorithm {
+	case "HS256":
+		return tokens.NewKeySet(tokens.NewHMACKey("", []byte(config.JWTSecret)))
+	case "RS256", "EdDSA":
+	default:
+		return nil, fmt.Errorf("unsupported JWT algorithm %q", config.JWTAlgorithm)
+	}
+
+	var keys []*tokens.Key
+	if config.JWTKeys != "" {
+		loaded, err := tokens.LoadKeys(config.JWTKeys)
+		if err != nil {
+			return nil, err
+		}
+		keys = loaded
+	}
+
+	signing, err := findSigningKey(keys)
+	if err != nil {
+		return nil, err
+	}
+
+	if signing == nil {
+		slog.Warn("no JWT signing key found; generating a temporary key", slog.String("algorithm", config.JWTAlgorithm))
+
+		signing, err = tokens.GenerateKey(strconv.FormatInt(time.Now().Unix(), 10), config.JWTAlgorithm)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return tokens.NewKeySet(signing, keys...)
+}
+
+// findSigningKey returns the configured signing key, or the last private key for the algorithm if none is configured.
+// Keys are sorted by ID, so naming them by creation date makes the newest key sign new tokens.
+func findSigningKey(keys []*tokens.Key) (*tokens.Key, error) {
+	if config.JWTSigningKey != "" {
+		for _, key := range keys {
+			if key.ID != config.JWTSigningKey {
+				continue
+			}
+
+			if !key.CanSign() || key.Method.Alg() != config.JWTAlgorithm {
+				return nil, fmt.Errorf("key %q cannot sign %s tokens", key.ID, config.JWTAlgorithm)
+			}
+			return key, nil
+		}
+
+		return nil, fmt.Errorf("signing key %q not found", config.JWTSigningKey)
+	}
+
+	var signing *tokens.Key
+	for _, key := range keys {
+		if key.CanSign() && key.Method.Alg() == config.JWTAlgorithm {
+			signing = key
+		}
+	}
+
+	return signing, nil
+}
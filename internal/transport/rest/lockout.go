@@ -0,0 +1,113 @@
+package rest
+
+import (
+	"github.com/k4sper1love/watchlist-api/internal/config"
+	"github.com/k4sper1love/watchlist-api/internal/database/postgres"
+	"log/slog"
+	"time"
+)
+
+// loginFailureWindow is the time after which failed logins of a key are forgotten.
+const loginFailureWindow = 24 * time.Hour
+
+// loginLockedError reports that logins are temporarily locked after too many failed attempts.
+type loginLockedError struct {
+	retryAfter time.Duration // Time left until the lock expires.
+}
+
+func (e *loginLockedError) Error() string {
+	return errLoginLocked.Error()
+}
+
+func (e *loginLockedError) Unwrap() error {
+	return errLoginLocked
+}
+
+// loginKey is a key of failed login tracking with its lockout threshold.
+type loginKey struct {
+	scope       string
+	key         string
+	maxFailures int
+}
+
+// loginKeys returns the keys tracked for a login attempt: the username and, if known, the client IP.
+func loginKeys(username, clientIP string) []loginKey {
+	keys := []loginKey{{postgres.LoginScopeUsername, username, config.LoginMaxFailures}}
+
+	if clientIP != "" {
+		keys = append(keys, loginKey{postgres.LoginScopeIP, clientIP, config.LoginMaxFailuresIP})
+	}
+
+	return keys
+}
+
+// checkLoginLockout returns a loginLockedError if the username or the client IP is locked.
+func checkLoginLockout(username, clientIP string) error {
+	var lockedUntil time.Time
+
+	for _, k := range loginKeys(username, clientIP) {
+		until, err := postgres.GetLoginLockout(k.scope, k.key)
+		if err != nil {
+			return err
+		}
+
+		if until.After(lockedUntil) {
+			lockedUntil = until
+		}
+	}
+
+	if retryAfter := time.Until(lockedUntil); retryAfter > 0 {
+		return &loginLockedError{retryAfter: retryAfter}
+	}
+
+	return nil
+}
+
+// recordLoginFailure counts a failed login of the username and the client IP.
+// A key is locked once it reaches its threshold; every further failure doubles the lockout, up to the configured maximum.
+// Errors are logged, so that a tracking failure does not change the response of the login.
+func recordLoginFailure(username, clientIP string) {
+	for _, k := range loginKeys(username, clientIP) {
+		failures, err := postgres.RecordLoginFailure(k.scope, k.key, loginFailureWindow)
+		if err != nil {
+			slog.Error("failed to record login failure", slog.Any("error", err), slog.String("scope", k.scope))
+			continue
+		}
+
+		if k.maxFailures <= 0 || failures < k.maxFailures {
+			continue
+		}
+
+		lockedUntil := time.Now().Add(lockoutDuration(failures - k.maxFailures))
+
+		if err := postgres.LockLogin(k.scope, k.key, failures, lockedUntil); err != nil {
+			slog.Error("failed to lock login", slog.Any("error", err), slog.String("scope", k.scope))
+			continue
+		}
+
+		slog.Warn("login locked after failed attempts",
+			slog.String("scope", k.scope),
+			slog.String("key", k.key),
+			slog.Int("failures", failures),
+			slog.Time("locked_until", lockedUntil),
+		)
+	}
+}
+
+// resetLoginFailures forgets the failed logins of the username after a successful login.
+func resetLoginFailures(username string) {
+	if err := postgres.ResetLoginFailures(postgres.LoginScopeUsername, username); err != nil {
+		slog.Error("failed to reset login failures", slog.Any("error", err))
+	}
+}
+
+// lockoutDuration returns the duration of a lockout after the given number of failures over the threshold.
+func lockoutDuration(excess int) time.Duration {
+	duration := config.LoginLockout
+
+	for i := 0; i < excess && duration < config.LoginMaxLockout; i++ {
+		duration *= 2
+	}
+
+	return min(duration, config.LoginMaxLockout)
+}
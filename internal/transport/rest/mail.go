@@ -0,0 +1,53 @@
+package rest
+
+import (
+	"fmt"
+	"github.com/k4sper1love/watchlist-api/pkg/mailer"
+	"github.com/k4sper1love/watchlist-api/pkg/models"
+	"log/slog"
+)
+
+// sendPasswordResetEmail sends a password reset token to the email of the user.
+func sendPasswordResetEmail(user *models.User, resetToken string) {
+	body := fmt.Sprintf(`Hello, %s!
+
+We received a request to reset the password of your Watchlist account.
+Use the token below with POST /api/v1/auth/password/reset within %s:
+
+%s
+
+If you did not request a password reset, you can ignore this email.`, user.Username, passwordResetTokenExpiration, resetToken)
+
+	sendEmail(mailer.Message{
+		To:      user.Email,
+		Subject: "Watchlist password reset",
+		Body:    body,
+	})
+}
+
+// sendEmailVerificationEmail sends an email verification token to the email of the user.
+func sendEmailVerificationEmail(user *models.User, verificationToken string) {
+	body := fmt.Sprintf(`Hello, %s!
+
+Please confirm the email address of your Watchlist account.
+Use the token below with POST /api/v1/auth/email/verify within %s:
+
+%s
+
+If you did not create an account or change your email, you can ignore this email.`, user.Username, emailVerificationExpiration, verificationToken)
+
+	sendEmail(mailer.Message{
+		To:      user.Email,
+		Subject: "Confirm your Watchlist email",
+		Body:    body,
+	})
+}
+
+// sendEmail delivers the message in the background so that slow mail servers do not delay responses.
+func sendEmail(msg mailer.Message) {
+	go func() {
+		if err := mailer.Send(msg); err != nil {
+			slog.Error("failed to send email", slog.Any("error", err), slog.String("subject", msg.Subject))
+		}
+	}()
+}
@@ -2,12 +2,17 @@ package rest
 
 import (
 	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
 	"github.com/gorilla/mux"
 	"github.com/k4sper1love/watchlist-api/internal/config"
-	"github.com/k4sper1love/watchlist-api/internal/database/postgres"
 	"github.com/k4sper1love/watchlist-api/pkg/logger/sl"
 	"github.com/k4sper1love/watchlist-api/pkg/metrics"
+	"github.com/k4sper1love/watchlist-api/pkg/tokens"
 	"net/http"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -19,11 +24,24 @@ var notRequireAuth = map[string]struct{}{
 	"/favicon.ico":                   {},
 	"/api":                           {},
 	"/api/v1/healthcheck":            {},
+	"/.well-known/jwks.json":         {},
 	"/api/v1/auth/register":          {},
 	"/api/v1/auth/register/telegram": {},
 	"/api/v1/auth/login":             {},
 	"/api/v1/auth/login/telegram":    {},
+	"/api/v1/auth/login/2fa":         {},
+	"/api/v1/auth/oidc/login":        {},
+	"/api/v1/auth/oidc/callback":     {},
 	"/api/v1/auth/refresh":           {},
+	"/api/v1/auth/email/verify":      {},
+	"/api/v1/auth/password/forgot":   {},
+	"/api/v1/auth/password/reset":    {},
+}
+
+// Map of route path templates that do not require authentication. Unlike notRequireAuth, they are matched
+// against the route the router selected, so that path variables never open other endpoints.
+var publicRoutes = map[string]struct{}{
+	sharedCollectionRoute: {},
 }
 
 var internalPaths = []string{
@@ -33,7 +51,7 @@ var internalPaths = []string{
 	"/upload",
 }
 
-// authenticate ensures that requests have a valid authentication token or are to an excluded path.
+// authenticate ensures that requests have a valid authentication token or API key, or are to an excluded path.
 func authenticate(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		requestPath := r.URL.Path
@@ -50,6 +68,38 @@ func authenticate(next http.Handler) http.Handler {
 			return
 		}
 
+		// Allow access to public routes, such as shared collections.
+		if isPublicRoute(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		// Authenticate with an API key instead of a token if one is provided.
+		if secret := r.Header.Get(apiKeyHeader); secret != "" {
+			key, err := authenticateAPIKey(secret)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					invalidAPIKeyResponse(w, r)
+					return
+				}
+				serverErrorResponse(w, r, err)
+				return
+			}
+
+			if !apiKeyAllows(key.Scope, r) {
+				apiKeyNotAllowedResponse(w, r)
+				return
+			}
+
+			// Add the user ID of the key owner to the request context. API keys do not belong to a session.
+			// The scope of the key is added as well, so that handlers report only the actions it allows.
+			ctx := context.WithValue(r.Context(), "userID", key.UserID)
+			ctx = context.WithValue(ctx, "sessionID", "")
+			ctx = context.WithValue(ctx, "apiKeyScope", key.Scope)
+			next.ServeHTTP(w, r.WithContext(ctx))
+			return
+		}
+
 		// Extract the token from the request header.
 		tokenString := parseTokenFromHeader(r)
 		if tokenString == "" {
@@ -57,9 +107,9 @@ func authenticate(next http.Handler) http.Handler {
 			return
 		}
 
-		// Parse the token to extract claims.
-		claims, err := parseTokenClaims(tokenString, config.JWTSecret)
-		if err != nil || claims == nil {
+		// Parse the token to extract claims. Refresh tokens are signed with the same keys but cannot authenticate requests.
+		claims, err := parseTokenClaims(tokenString, signingKeys)
+		if err != nil || claims == nil || claims.Type != tokens.TypeAccess {
 			invalidAuthTokenResponse(w, r)
 			return
 		}
@@ -69,8 +119,16 @@ func authenticate(next http.Handler) http.Handler {
 			invalidAuthTokenResponse(w, r)
 			return
 		}
-		// Add the user ID from claims to the request context.
+
+		// Reject tokens revoked by logout, password change or account deletion.
+		if revocations.isRevoked(userID, claims) {
+			invalidAuthTokenResponse(w, r)
+			return
+		}
+
+		// Add the user ID and session ID from claims to the request context.
 		ctx := context.WithValue(r.Context(), "userID", userID)
+		ctx = context.WithValue(ctx, "sessionID", claims.Sid)
 		r = r.WithContext(ctx)
 
 		// Proceed to the next handler with the modified request.
@@ -78,22 +136,68 @@ func authenticate(next http.Handler) http.Handler {
 	})
 }
 
+// rateLimitClientIP limits the request rate of each client IP across all route groups.
+// It runs before authenticate, so that requests with invalid or forged credentials are limited before they are verified.
+// If the limiter fails, the request is allowed.
+func rateLimitClientIP(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if config.RateLimiter == "off" || config.RateLimitIP <= 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if _, ok := findRateLimitGroup(r.URL.Path); !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if !allowRequest(w, r, "ip:"+getClientIP(r), config.RateLimitIP) {
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// rateLimit limits the request rate of each client per route group using token buckets.
+// Clients are identified by the authenticated user ID or by the client IP, so it must run after authenticate.
+// If the limiter fails, the request is allowed.
+func rateLimit(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if config.RateLimiter == "off" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		group, ok := findRateLimitGroup(r.URL.Path)
+		if !ok || group.requests() <= 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if !allowRequest(w, r, rateLimitKey(r, group.name), group.requests()) {
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
 // verificate is a middleware that checks the verification token from the request header.
+// Each verification token can be used only once.
 func verificate(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Extract the token from the request header.
 		verificationToken := r.Header.Get("Verification")
 
-		// Parse the token to extract claims.
-		claims, err := parseTokenClaims(verificationToken, config.TelegramSecret)
-		if err != nil || claims == nil {
-			invalidVerificationTokenResponse(w, r)
-			return
-		}
-
-		telegramID, err := strconv.Atoi(claims.Sub)
+		// Check the token and mark it as used, so it cannot be replayed.
+		telegramID, err := useVerificationToken(verificationToken)
 		if err != nil {
-			invalidVerificationTokenResponse(w, r)
+			if errors.Is(err, errInvalidVerificationToken) {
+				invalidVerificationTokenResponse(w, r)
+				return
+			}
+			serverErrorResponse(w, r, err)
 			return
 		}
 
@@ -107,53 +211,64 @@ func verificate(next http.HandlerFunc) http.HandlerFunc {
 }
 
 // requirePermissions ensures that the user has the necessary permissions for the specified resource and action.
+// Actions on films and collections are allowed to their owners, to users with an ACL entry for the action,
+// and to the members of a group the resource belongs to.
 func requirePermissions(resource, action string, next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		userID := r.Context().Value("userID").(int)
 		params := mux.Vars(r)
 
-		// Initialize a slice to store the required permission codes.
-		var permissionCodes []string
+		// Initialize a slice to store the required permissions.
+		var checks []accessCheck
 
-		// Determine permission codes based on the resource type and action.
+		// Determine the required permissions based on the resource type and action.
 		switch resource {
 		case "collectionFilm":
 			if action == "add" {
-				permissionCodes = append(permissionCodes, "film"+":"+params["filmID"]+":"+"read")
-				permissionCodes = append(permissionCodes, "collection"+":"+params["collectionID"]+":"+"update")
-			} else if action == "create" {
-				permissionCodes = append(permissionCodes, "collection"+":"+params["collectionID"]+":"+"update")
+				checks = append(checks, objectAccess("film", params["filmID"], "read"))
+				checks = append(checks, objectAccess("collection", params["collectionID"], "update"))
 			} else if action == "read" {
-				permissionCodes = append(permissionCodes, "collection"+":"+params["collectionID"]+":"+"read")
+				checks = append(checks, objectAccess("collection", params["collectionID"], "read"))
 			} else {
-				permissionCodes = append(permissionCodes, "collection"+":"+params["collectionID"]+":"+"update")
+				checks = append(checks, objectAccess("collection", params["collectionID"], "update"))
 			}
 		case "collection":
 			if action == "create" {
-				permissionCodes = append(permissionCodes, resource+":"+action)
+				checks = append(checks, codeAccess(resource+":"+action))
 			} else {
-				permissionCodes = append(permissionCodes, resource+":"+params["collectionID"]+":"+action)
+				checks = append(checks, objectAccess(resource, params["collectionID"], action))
 			}
 		case "film":
 			if action == "create" {
-				permissionCodes = append(permissionCodes, resource+":"+action)
+				checks = append(checks, codeAccess(resource+":"+action))
 			} else {
-				permissionCodes = append(permissionCodes, resource+":"+params["filmID"]+":"+action)
+				checks = append(checks, objectAccess(resource, params["filmID"], action))
+			}
+		case "group":
+			checks = append(checks, objectAccess(resource, params["groupID"], action))
+		case "groupFilm":
+			checks = append(checks, objectAccess("group", params["groupID"], "read"))
+			if action == "add" {
+				checks = append(checks, objectAccess("film", params["filmID"], "share"))
+			}
+		case "groupCollection":
+			checks = append(checks, objectAccess("group", params["groupID"], "read"))
+			if action == "add" {
+				checks = append(checks, objectAccess("collection", params["collectionID"], "share"))
 			}
 		default:
-			permissionCodes = append(permissionCodes, resource+":"+action)
-		}
-
-		// Retrieve the user's permissions from the database.
-		permissions, err := postgres.GetUserPermissions(userID)
-		if err != nil {
-			handleDBError(w, r, err)
-			return
+			checks = append(checks, codeAccess(resource+":"+action))
 		}
 
 		// Check if the user has all required permissions.
-		for _, permissionCode := range permissionCodes {
-			if !permissions.Include(permissionCode) {
+		for _, check := range checks {
+			allowed, err := check.allowed(userID)
+			if err != nil {
+				handleDBError(w, r, err)
+				return
+			}
+
+			if !allowed {
 				forbiddenResponse(w, r)
 				return
 			}
@@ -164,6 +279,38 @@ func requirePermissions(resource, action string, next http.HandlerFunc) http.Han
 	}
 }
 
+// requestIDHeader is the header carrying the identifier of a request.
+const requestIDHeader = "X-Request-ID"
+
+// validRequestID matches request IDs accepted from clients and proxies.
+var validRequestID = regexp.MustCompile(`^[a-zA-Z0-9._-]{1,64}$`)
+
+// requestID assigns an identifier to the request, keeping a valid one sent by the client or a proxy.
+// The identifier is returned in the X-Request-ID header, included in logs and recorded in audit events.
+func requestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(requestIDHeader)
+		if !validRequestID.MatchString(id) {
+			id = generateRequestID()
+			r.Header.Set(requestIDHeader, id)
+		}
+
+		w.Header().Set(requestIDHeader, id)
+
+		ctx := context.WithValue(r.Context(), "requestID", id)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// generateRequestID returns a random 128-bit request identifier encoded as a hexadecimal string.
+func generateRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 36)
+	}
+	return hex.EncodeToString(b)
+}
+
 // logAndRecordMetrics logs the endpoint info and records metrics for the request.
 func logAndRecordMetrics(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -191,3 +338,19 @@ func isInternalPath(requestPath string) bool {
 	}
 	return false
 }
+
+// isPublicRoute checks if the route matched for the request is in the list of public routes.
+func isPublicRoute(r *http.Request) bool {
+	route := mux.CurrentRoute(r)
+	if route == nil {
+		return false
+	}
+
+	template, err := route.GetPathTemplate()
+	if err != nil {
+		return false
+	}
+
+	_, exists := publicRoutes[template]
+	return exists
+}
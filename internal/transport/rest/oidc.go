@@ -0,0 +1,165 @@
+package rest
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/k4sper1love/watchlist-api/internal/config"
+	"github.com/k4sper1love/watchlist-api/internal/database/postgres"
+	"github.com/k4sper1love/watchlist-api/pkg/models"
+	"github.com/k4sper1love/watchlist-api/pkg/oidc"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// oidcStateExpiration is the time a user has to finish logging in at the OpenID Connect provider.
+const oidcStateExpiration = 10 * time.Minute
+
+// oidcProvider is the configured OpenID Connect provider; nil if OpenID Connect login is disabled.
+var oidcProvider *oidc.Provider
+
+// validOIDCUsername matches provider usernames that can be used as they are.
+var validOIDCUsername = regexp.MustCompile(`^[a-zA-Z0-9._]{3,20}$`)
+
+// initOIDC sets up the OpenID Connect provider from the configuration.
+func initOIDC() {
+	if config.OIDCIssuer == "" {
+		return
+	}
+
+	oidcProvider = oidc.NewProvider(
+		config.OIDCIssuer,
+		config.OIDCClientID,
+		config.OIDCClientSecret,
+		config.OIDCRedirectURL,
+		strings.Fields(config.OIDCScopes),
+	)
+
+	slog.Info("OpenID Connect login enabled", slog.String("issuer", config.OIDCIssuer))
+}
+
+// startOIDCLogin creates the state, nonce and PKCE verifier of a login and returns the authorization URL of the provider.
+func startOIDCLogin(ctx context.Context) (string, string, error) {
+	if oidcProvider == nil {
+		return "", "", errOIDCDisabled
+	}
+
+	state, err := oidc.GenerateRandom()
+	if err != nil {
+		return "", "", err
+	}
+
+	nonce, err := oidc.GenerateRandom()
+	if err != nil {
+		return "", "", err
+	}
+
+	codeVerifier, err := oidc.GenerateRandom()
+	if err != nil {
+		return "", "", err
+	}
+
+	authURL, err := oidcProvider.AuthCodeURL(ctx, state, nonce, oidc.CodeChallenge(codeVerifier))
+	if err != nil {
+		return "", "", err
+	}
+
+	if err := postgres.SaveOIDCState(state, nonce, codeVerifier, time.Now().Add(oidcStateExpiration)); err != nil {
+		return "", "", err
+	}
+
+	return authURL, state, nil
+}
+
+// finishOIDCLogin exchanges the authorization code, verifies the ID token and logs the user in.
+// Users signing in for the first time are registered with default permissions, like users registered by Telegram.
+// If the user has two-factor authentication enabled, a challenge for the second login step is returned instead.
+func finishOIDCLogin(r *http.Request, state, code string) (*models.AuthResponse, *models.TwoFactorChallenge, error) {
+	ctx := r.Context()
+
+	if oidcProvider == nil {
+		return nil, nil, errOIDCDisabled
+	}
+
+	nonce, codeVerifier, err := postgres.ConsumeOIDCState(state)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, errInvalidOIDCState
+		}
+		return nil, nil, err
+	}
+
+	token, err := oidcProvider.Exchange(ctx, code, codeVerifier)
+	if err != nil {
+		slog.Warn("OpenID Connect code exchange failed", slog.Any("error", err))
+		return nil, nil, errOIDCLoginFailed
+	}
+
+	claims, err := oidcProvider.VerifyIDToken(ctx, token.IDToken, nonce)
+	if err != nil {
+		slog.Warn("OpenID Connect ID token rejected", slog.Any("error", err))
+		return nil, nil, errOIDCLoginFailed
+	}
+
+	user, registered, err := getOrRegisterOIDCUser(claims)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if registered {
+		recordAuditEvent(r, auditEventRegister, user.ID, map[string]any{"method": "oidc", "issuer": claims.Issuer})
+	}
+
+	return issueAuthTokensOrChallenge(user, newSession(r))
+}
+
+// getOrRegisterOIDCUser returns the user linked to the identity of the ID token, registering a new user if there is none.
+// It reports whether the user was registered.
+func getOrRegisterOIDCUser(claims *oidc.IDTokenClaims) (*models.User, bool, error) {
+	userID, err := postgres.GetOIDCIdentityUserID(claims.Issuer, claims.Subject)
+	if err == nil {
+		user, err := postgres.GetUserById(userID)
+		return user, false, err
+	}
+
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, false, err
+	}
+
+	credentials := &models.Credentials{Username: generateOIDCUsername(claims)}
+
+	// Only an email verified by the provider is stored, and it is treated as verified.
+	if claims.EmailVerified {
+		credentials.Email = claims.Email
+	}
+
+	user, err := postgres.AddUserByOIDCIdentity(credentials, claims.Issuer, claims.Subject)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if err := addDefaultPermissions(user.ID); err != nil {
+		return nil, false, err
+	}
+
+	return user, true, nil
+}
+
+// generateOIDCUsername uses the preferred username of the provider if it is valid and free, or generates a random one.
+func generateOIDCUsername(claims *oidc.IDTokenClaims) string {
+	if validOIDCUsername.MatchString(claims.PreferredUsername) && !postgres.IsUsernameExists(claims.PreferredUsername) {
+		return claims.PreferredUsername
+	}
+
+	for tries := 0; tries < 5; tries++ {
+		username := "user_" + generateString(8)
+		if !postgres.IsUsernameExists(username) {
+			return username
+		}
+	}
+
+	return ""
+}
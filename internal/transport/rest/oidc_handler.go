@@ -0,0 +1,77 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+)
+
+// StartOIDCLogin godoc
+// @Summary Start logging in with OpenID Connect
+// @Description Create a login at the configured OpenID Connect provider. Redirect the user to the returned authorization URL.
+// @Description The provider redirects back to the configured redirect URL with a `code` and the `state`, which must be passed to `/auth/oidc/callback` within 10 minutes.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Success 200 {object} swagger.OIDCLoginResponse
+// @Failure 404 {object} swagger.ErrorResponse
+// @Failure 500 {object} swagger.ErrorResponse
+// @Router /auth/oidc/login [get]
+func startOIDCLoginHandler(w http.ResponseWriter, r *http.Request) {
+	authURL, state, err := startOIDCLogin(r.Context())
+	if err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, envelope{"authorization_url": authURL, "state": state})
+}
+
+// FinishOIDCLogin godoc
+// @Summary Finish logging in with OpenID Connect
+// @Description Exchange the authorization code from the provider for tokens. A user signing in for the first time is registered.
+// @Description Basic permissions are available to new users: creating films and collections.
+// @Description If two-factor authentication is enabled, returns a two-factor token to complete the login at `/auth/login/2fa`.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param code query string true "Authorization code"
+// @Param state query string true "State from /auth/oidc/login"
+// @Success 200 {object} swagger.AuthResponse
+// @Failure 400 {object} swagger.ErrorResponse
+// @Failure 401 {object} swagger.ErrorResponse
+// @Failure 404 {object} swagger.ErrorResponse
+// @Failure 500 {object} swagger.ErrorResponse
+// @Router /auth/oidc/callback [get]
+func finishOIDCLoginHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	// The provider reports a denied or failed authorization with an error parameter instead of a code.
+	if providerErr := query.Get("error"); providerErr != "" {
+		badRequestResponse(w, r, errors.New("OpenID Connect provider error: "+providerErr))
+		return
+	}
+
+	state, code := query.Get("state"), query.Get("code")
+	if state == "" || code == "" {
+		badRequestResponse(w, r, errors.New("state and code are required"))
+		return
+	}
+
+	user, challenge, err := finishOIDCLogin(r, state, code)
+	if err != nil {
+		if errors.Is(err, errOIDCLoginFailed) || errors.Is(err, errAccountSuspended) {
+			recordAuditEvent(r, auditEventLoginFailed, 0, map[string]any{"method": "oidc", "reason": err.Error()})
+		}
+		handleDBError(w, r, err)
+		return
+	}
+
+	if challenge != nil {
+		writeJSON(w, r, http.StatusOK, envelope{"two_factor": challenge})
+		return
+	}
+
+	auditLogin(r, user, "oidc")
+
+	writeJSON(w, r, http.StatusOK, envelope{"user": user})
+}
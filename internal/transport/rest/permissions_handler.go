@@ -0,0 +1,58 @@
+package rest
+
+import (
+	"github.com/k4sper1love/watchlist-api/internal/database/postgres"
+	"github.com/k4sper1love/watchlist-api/pkg/models"
+	"net/http"
+)
+
+// GetOwnPermissions godoc
+// @Summary Get the effective permissions of the user
+// @Description Get the permission codes of the user: account-wide codes, such as `film:create`, and access to films and collections of other users, such as `film:1:read`. Access to own films and collections and through groups is not listed.
+// @Description With `resource` and `id`, get the actions the user may perform on that film, collection or group instead, so that clients can hide actions that would be rejected.
+// @Description With a read-only API key, only the codes and actions for reading are reported.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param resource query string false "Type of the resource: `film`, `collection` or `group`"
+// @Param id query int false "ID of the resource"
+// @Success 200 {object} swagger.PermissionsResponse
+// @Success 200 {object} swagger.ResourcePermissionsResponse
+// @Failure 401 {object} swagger.ErrorResponse
+// @Failure 422 {object} swagger.ErrorResponse
+// @Failure 500 {object} swagger.ErrorResponse
+// @Security JWTAuth
+// @Router /user/permissions [get]
+func getOwnPermissionsHandler(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(int)
+	qs := r.URL.Query()
+
+	if qs.Has("resource") || qs.Has("id") {
+		resource, id, errs := parseResourceQuery(qs)
+		if errs != nil {
+			failedValidationResponse(w, r, errs)
+			return
+		}
+
+		actions, err := postgres.GetAllowedActions(userID, resource, id)
+		if err != nil {
+			handleDBError(w, r, err)
+			return
+		}
+
+		writeJSON(w, r, http.StatusOK, envelope{"resource_permissions": models.ResourcePermissions{Resource: resource, ID: id, Actions: scopeActions(r, actions)}})
+		return
+	}
+
+	permissions, err := postgres.GetUserPermissions(userID)
+	if err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	if permissions == nil {
+		permissions = postgres.Permissions{}
+	}
+
+	writeJSON(w, r, http.StatusOK, envelope{"permissions": scopePermissions(r, permissions)})
+}
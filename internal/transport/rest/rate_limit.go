@@ -0,0 +1,79 @@
+package rest
+
+import (
+	"fmt"
+	"github.com/k4sper1love/watchlist-api/internal/config"
+	"github.com/k4sper1love/watchlist-api/pkg/logger/sl"
+	"github.com/k4sper1love/watchlist-api/pkg/ratelimit"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// rateLimitPeriod is the period of the per-minute limits configured for route groups.
+const rateLimitPeriod = time.Minute
+
+// rateLimitGroup is a group of routes sharing a rate limit budget.
+type rateLimitGroup struct {
+	name     string
+	prefix   string
+	requests func() int // Requests per period, read from the configuration at request time.
+}
+
+// rateLimitGroups lists the route groups in the order they are matched. The last group covers the remaining API endpoints.
+var rateLimitGroups = []rateLimitGroup{
+	{"auth", "/api/v1/auth", func() int { return config.RateLimitAuth }},
+	{"films", "/api/v1/films", func() int { return config.RateLimitFilms }},
+	{"collections", "/api/v1/collections", func() int { return config.RateLimitCollections }},
+	{"upload", "/upload", func() int { return config.RateLimitUpload }},
+	{"default", "/api", func() int { return config.RateLimitDefault }},
+}
+
+// findRateLimitGroup returns the route group of the path, or false if the path is not rate limited.
+func findRateLimitGroup(path string) (rateLimitGroup, bool) {
+	for _, group := range rateLimitGroups {
+		if path == group.prefix || strings.HasPrefix(path, group.prefix+"/") {
+			return group, true
+		}
+	}
+	return rateLimitGroup{}, false
+}
+
+// rateLimitKey identifies the client of a request: the authenticated user or, otherwise, the client IP.
+func rateLimitKey(r *http.Request, group string) string {
+	if userID, ok := r.Context().Value("userID").(int); ok {
+		return fmt.Sprintf("%s:user:%d", group, userID)
+	}
+	return fmt.Sprintf("%s:ip:%s", group, getClientIP(r))
+}
+
+// allowRequest takes a token for the key from a bucket allowing the given requests per period and sets the rate limit headers.
+// If the request is limited, it writes the response and returns false. If the limiter fails, the request is allowed.
+func allowRequest(w http.ResponseWriter, r *http.Request, key string, requests int) bool {
+	limit := ratelimit.Limit{Requests: requests, Period: rateLimitPeriod}
+
+	result, err := ratelimit.Allow(key, limit)
+	if err != nil {
+		sl.PrintEndpointError("rate limiter failed", err, r)
+		return true
+	}
+
+	setRateLimitHeaders(w, limit, result)
+
+	if !result.Allowed {
+		tooManyRequestsResponse(w, r, errRateLimitExceeded, result.RetryAfter)
+		return false
+	}
+
+	return true
+}
+
+// setRateLimitHeaders sets the RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset and RateLimit-Policy headers.
+func setRateLimitHeaders(w http.ResponseWriter, limit ratelimit.Limit, result ratelimit.Result) {
+	w.Header().Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+	w.Header().Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	w.Header().Set("RateLimit-Reset", strconv.Itoa(int(math.Ceil(result.Reset.Seconds()))))
+	w.Header().Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d", limit.Requests, int(limit.Period.Seconds())))
+}
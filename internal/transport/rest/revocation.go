@@ -0,0 +1,191 @@
+package rest
+
+import (
+	"github.com/k4sper1love/watchlist-api/internal/database/postgres"
+	"github.com/k4sper1love/watchlist-api/pkg/models"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Revocation store settings
+const (
+	revocationSyncInterval  = 10 * time.Second // How often revocations made by other instances are loaded.
+	revocationSyncOverlap   = time.Minute      // Overlap of loads, so that slowly committed revocations are not missed.
+	revocationPurgeInterval = time.Hour        // How often expired revocations are deleted from the database.
+)
+
+// revocations is the revocation store used to check access tokens.
+var revocations = newRevocationStore()
+
+// revocationStore is an in-memory cache of the access token revocations stored in the database.
+// Revocations made by this instance apply immediately; those made by other instances apply after the next sync,
+// which runs in the background.
+type revocationStore struct {
+	mu        sync.Mutex
+	tokens    map[string]time.Time                  // Revoked token IDs with their expiration time.
+	sessions  map[string]time.Time                  // Revoked session IDs with the expiration time of their tokens.
+	users     map[int]*models.AccessTokenRevocation // Latest revocation of all tokens of a user.
+	synced    time.Time                             // Revocation time of the newest loaded revocation.
+	nextPurge time.Time                             // Time of the next purge of expired revocations; only used by sync.
+}
+
+// newRevocationStore creates an empty revocation store that loads all revocations on the first sync.
+func newRevocationStore() *revocationStore {
+	return &revocationStore{
+		tokens:   make(map[string]time.Time),
+		sessions: make(map[string]time.Time),
+		users:    make(map[int]*models.AccessTokenRevocation),
+	}
+}
+
+// isRevoked reports whether a token has been revoked by its ID, its session or its user.
+// Tokens of a user are revoked if they were issued before the second of the revocation.
+func (s *revocationStore) isRevoked(userID int, claims *models.JWTClaims) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.tokens[claims.Id]; ok && claims.Id != "" {
+		return true
+	}
+
+	if _, ok := s.sessions[claims.Sid]; ok && claims.Sid != "" {
+		return true
+	}
+
+	if r, ok := s.users[userID]; ok && claims.IssuedAt < r.RevokedAt.Unix() {
+		return true
+	}
+
+	return false
+}
+
+// revoke stores a revocation in the database and applies it immediately.
+func (s *revocationStore) revoke(r *models.AccessTokenRevocation) error {
+	if err := postgres.SaveAccessTokenRevocation(r); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.apply(r)
+	return nil
+}
+
+// apply adds a revocation to the cache.
+func (s *revocationStore) apply(r *models.AccessTokenRevocation) {
+	switch {
+	case r.TokenID != "":
+		s.tokens[r.TokenID] = r.ExpiresAt
+	case r.SessionID != "":
+		s.sessions[r.SessionID] = r.ExpiresAt
+	case r.UserID != 0:
+		if current, ok := s.users[r.UserID]; !ok || r.RevokedAt.After(current.RevokedAt) {
+			s.users[r.UserID] = r
+		}
+	}
+}
+
+// startRevocationSync loads the stored revocations and then periodically loads the revocations
+// made by other instances in the background.
+func startRevocationSync() {
+	revocations.sync()
+
+	go func() {
+		ticker := time.NewTicker(revocationSyncInterval)
+		defer ticker.Stop()
+
+		for {
+			<-ticker.C
+			revocations.sync()
+		}
+	}()
+}
+
+// sync loads the revocations made since the last sync and drops expired ones from the cache.
+// The database is queried without holding the lock, so that token checks never wait for it.
+// Errors are logged and the cached revocations are used until the next sync.
+func (s *revocationStore) sync() {
+	s.mu.Lock()
+	since := time.Time{}
+	if !s.synced.IsZero() {
+		since = s.synced.Add(-revocationSyncOverlap)
+	}
+	s.mu.Unlock()
+
+	loaded, err := postgres.GetAccessTokenRevocations(since)
+	if err != nil {
+		slog.Error("failed to load access token revocations", slog.Any("error", err))
+		return
+	}
+
+	now := time.Now()
+
+	s.mu.Lock()
+	for _, r := range loaded {
+		s.apply(r)
+		if r.RevokedAt.After(s.synced) {
+			s.synced = r.RevokedAt
+		}
+	}
+	s.dropExpired(now)
+	s.mu.Unlock()
+
+	if now.After(s.nextPurge) {
+		s.nextPurge = now.Add(revocationPurgeInterval)
+		if err := postgres.DeleteExpiredAccessTokenRevocations(); err != nil {
+			slog.Error("failed to delete expired access token revocations", slog.Any("error", err))
+		}
+	}
+}
+
+// dropExpired removes revocations whose affected tokens have all expired. The caller must hold the lock.
+func (s *revocationStore) dropExpired(now time.Time) {
+	for id, expiresAt := range s.tokens {
+		if now.After(expiresAt) {
+			delete(s.tokens, id)
+		}
+	}
+
+	for id, expiresAt := range s.sessions {
+		if now.After(expiresAt) {
+			delete(s.sessions, id)
+		}
+	}
+
+	for id, r := range s.users {
+		if now.After(r.ExpiresAt) {
+			delete(s.users, id)
+		}
+	}
+}
+
+// revokeToken revokes a single token by the ID from its claims.
+func revokeToken(claims *models.JWTClaims) error {
+	if claims.Id == "" {
+		return nil
+	}
+
+	return revocations.revoke(&models.AccessTokenRevocation{
+		TokenID:   claims.Id,
+		ExpiresAt: time.Unix(claims.ExpiresAt, 0),
+	})
+}
+
+// revokeSessionAccessTokens revokes all access tokens of a session.
+func revokeSessionAccessTokens(sessionID string) error {
+	return revocations.revoke(&models.AccessTokenRevocation{
+		SessionID: sessionID,
+		ExpiresAt: time.Now().Add(accessTokenExpiration),
+	})
+}
+
+// revokeUserAccessTokens revokes all tokens of a user issued so far.
+// Refresh tokens are covered as well, because they are signed with the same keys.
+func revokeUserAccessTokens(userID int) error {
+	return revocations.revoke(&models.AccessTokenRevocation{
+		UserID:    userID,
+		ExpiresAt: time.Now().Add(refreshTokenExpiration),
+	})
+}
@@ -13,8 +13,11 @@ func route() *mux.Router {
 	router := mux.NewRouter()
 
 	// Apply middlewares
+	router.Use(requestID)
 	router.Use(logAndRecordMetrics)
+	router.Use(rateLimitClientIP)
 	router.Use(authenticate)
+	router.Use(rateLimit)
 
 	// API Icon Endpoint
 	router.HandleFunc("/favicon.ico", func(w http.ResponseWriter, r *http.Request) {
@@ -42,6 +45,9 @@ func route() *mux.Router {
 	// Health check Endpoint
 	router.HandleFunc("/api/v1/healthcheck", healthcheckHandler).Methods(http.MethodGet)
 
+	// Public keys for verifying tokens
+	router.HandleFunc("/.well-known/jwks.json", jwksHandler).Methods(http.MethodGet)
+
 	// Swagger documentation UI Endpoint
 	router.HandleFunc("/swagger/{rest:.*}", swaggerHandler)
 
@@ -51,9 +57,12 @@ func route() *mux.Router {
 	// Set up routes
 	setupAuthRoutes(router)
 	setupUserRoutes(router)
+	setupAdminRoutes(router)
 	setupFilmRoutes(router)
 	setupCollectionRoutes(router)
 	setupCollectionFilmRoutes(router)
+	setupGroupRoutes(router)
+	setupSharedRoutes(router)
 
 	return router
 }
@@ -64,6 +73,12 @@ func setupAuthRoutes(router *mux.Router) {
 	auth.HandleFunc("/register/telegram", verificate(registerByTelegramHandler)).Methods(http.MethodPost)
 	auth.HandleFunc("/login", loginWithCredentialsHandler).Methods(http.MethodPost)
 	auth.HandleFunc("/login/telegram", verificate(loginByTelegramHandler)).Methods(http.MethodPost)
+	auth.HandleFunc("/login/2fa", loginWithTwoFactorHandler).Methods(http.MethodPost)
+	auth.HandleFunc("/oidc/login", startOIDCLoginHandler).Methods(http.MethodGet)
+	auth.HandleFunc("/oidc/callback", finishOIDCLoginHandler).Methods(http.MethodGet)
+	auth.HandleFunc("/email/verify", verifyEmailHandler).Methods(http.MethodPost)
+	auth.HandleFunc("/password/forgot", forgotPasswordHandler).Methods(http.MethodPost)
+	auth.HandleFunc("/password/reset", resetPasswordHandler).Methods(http.MethodPost)
 	auth.HandleFunc("/refresh", refreshAccessTokenHandler).Methods(http.MethodPost)
 	auth.HandleFunc("/logout", logoutHandler).Methods(http.MethodPost)
 	auth.HandleFunc("/check", checkTokenHandler).Methods(http.MethodGet)
@@ -74,6 +89,43 @@ func setupUserRoutes(router *mux.Router) {
 	user.HandleFunc("/user", getUserHandler).Methods(http.MethodGet)
 	user.HandleFunc("/user", updateUserHandler).Methods(http.MethodPut)
 	user.HandleFunc("/user", deleteUserHandler).Methods(http.MethodDelete)
+	user.HandleFunc("/user/email/verify", requestEmailVerificationHandler).Methods(http.MethodPost)
+	user.HandleFunc("/user/password", changePasswordHandler).Methods(http.MethodPut)
+	user.HandleFunc("/user/2fa/enroll", enrollTwoFactorHandler).Methods(http.MethodPost)
+	user.HandleFunc("/user/2fa/confirm", confirmTwoFactorHandler).Methods(http.MethodPost)
+	user.HandleFunc("/user/2fa/disable", disableTwoFactorHandler).Methods(http.MethodPost)
+	user.HandleFunc("/user/identities", getIdentitiesHandler).Methods(http.MethodGet)
+	user.HandleFunc("/user/identities/telegram", verificate(linkTelegramIdentityHandler)).Methods(http.MethodPost)
+	user.HandleFunc("/user/identities/password", linkPasswordIdentityHandler).Methods(http.MethodPost)
+	user.HandleFunc("/user/identities/{identityID:[0-9]+}", deleteIdentityHandler).Methods(http.MethodDelete)
+	user.HandleFunc("/user/api-keys", getAPIKeysHandler).Methods(http.MethodGet)
+	user.HandleFunc("/user/api-keys", addAPIKeyHandler).Methods(http.MethodPost)
+	user.HandleFunc("/user/api-keys/{apiKeyID:[0-9]+}", deleteAPIKeyHandler).Methods(http.MethodDelete)
+	user.HandleFunc("/user/sessions", getSessionsHandler).Methods(http.MethodGet)
+	user.HandleFunc("/user/sessions", deleteOtherSessionsHandler).Methods(http.MethodDelete)
+	user.HandleFunc("/user/sessions/{sessionID:[0-9a-fA-F-]{36}}", deleteSessionHandler).Methods(http.MethodDelete)
+	user.HandleFunc("/user/permissions", getOwnPermissionsHandler).Methods(http.MethodGet)
+	user.HandleFunc("/user/audit", getUserAuditEventsHandler).Methods(http.MethodGet)
+	user.HandleFunc("/user/export", startAccountExportHandler).Methods(http.MethodPost)
+	user.HandleFunc("/user/export/{exportID:[0-9a-fA-F-]{36}}", getAccountExportHandler).Methods(http.MethodGet)
+	user.HandleFunc("/user/export/{exportID:[0-9a-fA-F-]{36}}/download", downloadAccountExportHandler).Methods(http.MethodGet)
+	user.HandleFunc("/user/import", importAccountHandler).Methods(http.MethodPost)
+}
+
+func setupAdminRoutes(router *mux.Router) {
+	admin := router.PathPrefix("/api/v1/admin").Subrouter()
+	admin.HandleFunc("/users", requirePermissions("admin", "*", getAdminUsersHandler)).Methods(http.MethodGet)
+	admin.HandleFunc("/users/{userID:[0-9]+}", requirePermissions("admin", "*", getAdminUserHandler)).Methods(http.MethodGet)
+	admin.HandleFunc("/users/{userID:[0-9]+}/films", requirePermissions("admin", "*", getAdminUserFilmsHandler)).Methods(http.MethodGet)
+	admin.HandleFunc("/users/{userID:[0-9]+}/collections", requirePermissions("admin", "*", getAdminUserCollectionsHandler)).Methods(http.MethodGet)
+	admin.HandleFunc("/users/{userID:[0-9]+}/suspend", requirePermissions("admin", "*", suspendUserHandler)).Methods(http.MethodPost)
+	admin.HandleFunc("/users/{userID:[0-9]+}/unsuspend", requirePermissions("admin", "*", unsuspendUserHandler)).Methods(http.MethodPost)
+	admin.HandleFunc("/users/{userID:[0-9]+}/logout", requirePermissions("admin", "*", logoutUserHandler)).Methods(http.MethodPost)
+	admin.HandleFunc("/users/{userID:[0-9]+}/permissions", requirePermissions("admin", "*", getUserPermissionsHandler)).Methods(http.MethodGet)
+	admin.HandleFunc("/users/{userID:[0-9]+}/permissions", requirePermissions("admin", "*", grantUserPermissionsHandler)).Methods(http.MethodPost)
+	admin.HandleFunc("/users/{userID:[0-9]+}/permissions", requirePermissions("admin", "*", revokeUserPermissionsHandler)).Methods(http.MethodDelete)
+	admin.HandleFunc("/actions", requirePermissions("admin", "*", getAdminActionsHandler)).Methods(http.MethodGet)
+	admin.HandleFunc("/audit", requirePermissions("admin", "*", getAdminAuditEventsHandler)).Methods(http.MethodGet)
 }
 
 func setupFilmRoutes(router *mux.Router) {
@@ -92,6 +144,12 @@ func setupCollectionRoutes(router *mux.Router) {
 	collections.HandleFunc("/{collectionID:[0-9]+}", requirePermissions("collection", "read", getCollectionHandler)).Methods(http.MethodGet)
 	collections.HandleFunc("/{collectionID:[0-9]+}", requirePermissions("collection", "update", updateCollectionHandler)).Methods(http.MethodPut)
 	collections.HandleFunc("/{collectionID:[0-9]+}", requirePermissions("collection", "delete", deleteCollectionHandler)).Methods(http.MethodDelete)
+	collections.HandleFunc("/{collectionID:[0-9]+}/collaborators", requirePermissions("collection", "read", getCollaboratorsHandler)).Methods(http.MethodGet)
+	collections.HandleFunc("/{collectionID:[0-9]+}/collaborators", requirePermissions("collection", "share", addCollaboratorHandler)).Methods(http.MethodPost)
+	collections.HandleFunc("/{collectionID:[0-9]+}/collaborators/{userID:[0-9]+}", requirePermissions("collection", "share", updateCollaboratorHandler)).Methods(http.MethodPut)
+	collections.HandleFunc("/{collectionID:[0-9]+}/collaborators/{userID:[0-9]+}", requirePermissions("collection", "read", deleteCollaboratorHandler)).Methods(http.MethodDelete)
+	collections.HandleFunc("/{collectionID:[0-9]+}/share", requirePermissions("collection", "share", createShareLinkHandler)).Methods(http.MethodPost)
+	collections.HandleFunc("/{collectionID:[0-9]+}/share", requirePermissions("collection", "share", deleteShareLinkHandler)).Methods(http.MethodDelete)
 }
 
 func setupCollectionFilmRoutes(router *mux.Router) {
@@ -102,3 +160,21 @@ func setupCollectionFilmRoutes(router *mux.Router) {
 	collectionFilms.HandleFunc("/{filmID:[0-9]+}", requirePermissions("collectionFilm", "read", getCollectionFilmHandler)).Methods(http.MethodGet)
 	collectionFilms.HandleFunc("/{filmID:[0-9]+}", requirePermissions("collectionFilm", "delete", deleteCollectionFilmHandler)).Methods(http.MethodDelete)
 }
+
+func setupGroupRoutes(router *mux.Router) {
+	groups := router.PathPrefix("/api/v1/groups").Subrouter()
+	groups.HandleFunc("", getGroupsHandler).Methods(http.MethodGet)
+	groups.HandleFunc("", addGroupHandler).Methods(http.MethodPost)
+	groups.HandleFunc("/{groupID:[0-9]+}", requirePermissions("group", "read", getGroupHandler)).Methods(http.MethodGet)
+	groups.HandleFunc("/{groupID:[0-9]+}", requirePermissions("group", "delete", deleteGroupHandler)).Methods(http.MethodDelete)
+	groups.HandleFunc("/{groupID:[0-9]+}/members", requirePermissions("group", "update", addGroupMemberHandler)).Methods(http.MethodPost)
+	groups.HandleFunc("/{groupID:[0-9]+}/members/{userID:[0-9]+}", requirePermissions("group", "read", deleteGroupMemberHandler)).Methods(http.MethodDelete)
+	groups.HandleFunc("/{groupID:[0-9]+}/films/{filmID:[0-9]+}", requirePermissions("groupFilm", "add", addGroupFilmHandler)).Methods(http.MethodPost)
+	groups.HandleFunc("/{groupID:[0-9]+}/films/{filmID:[0-9]+}", requirePermissions("groupFilm", "remove", deleteGroupFilmHandler)).Methods(http.MethodDelete)
+	groups.HandleFunc("/{groupID:[0-9]+}/collections/{collectionID:[0-9]+}", requirePermissions("groupCollection", "add", addGroupCollectionHandler)).Methods(http.MethodPost)
+	groups.HandleFunc("/{groupID:[0-9]+}/collections/{collectionID:[0-9]+}", requirePermissions("groupCollection", "remove", deleteGroupCollectionHandler)).Methods(http.MethodDelete)
+}
+
+func setupSharedRoutes(router *mux.Router) {
+	router.HandleFunc(sharedCollectionRoute, getSharedCollectionHandler).Methods(http.MethodGet)
+}
@@ -15,6 +15,19 @@ import (
 // Serve initializes and starts the HTTP server.
 // Handles graceful shutdown when receiving termination signals.
 func Serve() error {
+	if err := initKeys(); err != nil {
+		return err
+	}
+
+	initOIDC()
+
+	if err := initAdmin(); err != nil {
+		return err
+	}
+
+	startRevocationSync()
+	startAccountPurge()
+
 	host := getServerHost()
 	port := fmt.Sprintf("%d", config.Port)
 	server := newServer(port)
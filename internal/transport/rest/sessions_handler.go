@@ -0,0 +1,98 @@
+package rest
+
+import (
+	"github.com/gorilla/mux"
+	"github.com/k4sper1love/watchlist-api/internal/database/postgres"
+	"net/http"
+)
+
+// GetSessions godoc
+// @Summary Get active sessions
+// @Description Get a list of active sessions of the user with creation, expiration and last use time, user agent and client IP.
+// @Description The session of the current access token is marked as `current`.
+// @Tags user
+// @Accept json
+// @Produce json
+// @Success 200 {object} swagger.SessionsResponse
+// @Failure 401 {object} swagger.ErrorResponse
+// @Failure 500 {object} swagger.ErrorResponse
+// @Security JWTAuth
+// @Router /user/sessions [get]
+func getSessionsHandler(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(int)
+	sessionID := r.Context().Value("sessionID").(string)
+
+	sessions, err := postgres.GetSessions(userID)
+	if err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	for _, session := range sessions {
+		session.Current = session.ID == sessionID
+	}
+
+	writeJSON(w, r, http.StatusOK, envelope{"sessions": sessions})
+}
+
+// DeleteSession godoc
+// @Summary Revoke the session
+// @Description Revoke the session by ID. Its refresh token and access tokens can no longer be used.
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param session_id path string true "Session ID"
+// @Success 200 {object} swagger.MessageResponse
+// @Failure 401 {object} swagger.ErrorResponse
+// @Failure 404 {object} swagger.ErrorResponse
+// @Failure 500 {object} swagger.ErrorResponse
+// @Security JWTAuth
+// @Router /user/sessions/{session_id} [delete]
+func deleteSessionHandler(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(int)
+	sessionID := mux.Vars(r)["sessionID"]
+
+	if err := postgres.RevokeSession(userID, sessionID); err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	if err := revokeSessionAccessTokens(sessionID); err != nil {
+		serverErrorResponse(w, r, err)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, envelope{"message": "session revoked"})
+}
+
+// DeleteOtherSessions godoc
+// @Summary Revoke other sessions
+// @Description Revoke all sessions of the user except the session of the current access token.
+// @Description Their refresh tokens and access tokens can no longer be used.
+// @Tags user
+// @Accept json
+// @Produce json
+// @Success 200 {object} swagger.MessageResponse
+// @Failure 401 {object} swagger.ErrorResponse
+// @Failure 500 {object} swagger.ErrorResponse
+// @Security JWTAuth
+// @Router /user/sessions [delete]
+func deleteOtherSessionsHandler(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(int)
+	sessionID := r.Context().Value("sessionID").(string)
+
+	revokedIDs, err := postgres.RevokeOtherSessions(userID, sessionID)
+	if err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	for _, revokedID := range revokedIDs {
+		if err := revokeSessionAccessTokens(revokedID); err != nil {
+			serverErrorResponse(w, r, err)
+			return
+		}
+	}
+
+	writeJSON(w, r, http.StatusOK, envelope{"message": "other sessions revoked"})
+}
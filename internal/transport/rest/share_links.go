@@ -0,0 +1,104 @@
+package rest
+
+import (
+	"fmt"
+	"github.com/k4sper1love/watchlist-api/internal/database/postgres"
+	"github.com/k4sper1love/watchlist-api/pkg/filters"
+	"github.com/k4sper1love/watchlist-api/pkg/models"
+	"net/http"
+)
+
+// sharedCollectionRoute is the path template of the public share links. It is allow-listed in publicRoutes.
+const sharedCollectionRoute = "/api/v1/shared/{token:[A-Za-z0-9_-]{43}}"
+
+// createShareLink generates a new share token for a collection. The previous link of the collection stops working.
+func createShareLink(collectionID int, host string) (*models.ShareLink, error) {
+	if _, err := postgres.GetCollection(collectionID); err != nil {
+		return nil, err
+	}
+
+	token, err := generateOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+
+	createdAt, err := postgres.SaveCollectionShareLink(collectionID, token)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ShareLink{
+		Token:     token,
+		URL:       fmt.Sprintf("http://%s/api/v1/shared/%s", host, token),
+		CreatedAt: createdAt,
+	}, nil
+}
+
+// getSharedCollection retrieves the collection of a share token with the films on the requested page.
+func getSharedCollection(token string, input *models.FilmsQueryInput) (*models.SharedCollection, filters.Metadata, error) {
+	collectionID, err := postgres.GetCollectionIDByShareToken(token)
+	if err != nil {
+		return nil, filters.Metadata{}, err
+	}
+
+	collectionFilms := models.CollectionFilms{
+		Collection: models.Collection{ID: collectionID},
+	}
+
+	metadata, err := postgres.GetCollectionFilms(&collectionFilms, input)
+	if err != nil {
+		return nil, filters.Metadata{}, err
+	}
+
+	return newSharedCollection(&collectionFilms), metadata, nil
+}
+
+// newSharedCollection copies the public fields of a collection and its films.
+func newSharedCollection(c *models.CollectionFilms) *models.SharedCollection {
+	shared := &models.SharedCollection{
+		Name:        c.Collection.Name,
+		Description: c.Collection.Description,
+		TotalFilms:  c.Collection.TotalFilms,
+		Films:       make([]models.SharedFilm, 0, len(c.Films)),
+		CreatedAt:   c.Collection.CreatedAt,
+		UpdatedAt:   c.Collection.UpdatedAt,
+	}
+
+	for _, film := range c.Films {
+		shared.Films = append(shared.Films, models.SharedFilm{
+			Title:       film.Title,
+			Year:        film.Year,
+			Genre:       film.Genre,
+			Description: film.Description,
+			Rating:      film.Rating,
+			ImageURL:    film.ImageURL,
+			URL:         film.URL,
+		})
+	}
+
+	return shared
+}
+
+// parseAndValidateSharedFilmsFilters parses the filter and pagination parameters of a shared collection.
+// Only the public fields of films can be filtered and sorted, so that the owner's ratings and viewing status do not leak.
+func parseAndValidateSharedFilmsFilters(r *http.Request) (*models.FilmsQueryInput, map[string]string, error) {
+	input := models.FilmsQueryInput{}
+	qs := r.URL.Query()
+
+	input.Title = parseQueryString(qs, "title", "")
+	input.Rating = parseQueryString(qs, "rating", "")
+	input.Year = parseQueryString(qs, "year", "")
+	input.ExcludeCollection = -1
+
+	input.Filters.Page = parseQueryInt(qs, "page", 1)
+	input.Filters.PageSize = parseQueryInt(qs, "page_size", 5)
+	input.Filters.Sort = parseQueryString(qs, "sort", "id")
+	input.Filters.SortSafeList = []string{
+		"id", "title", "rating", "year", "created_at",
+		"-id", "-title", "-rating", "-year", "-created_at",
+	}
+
+	errs, err := filters.ValidateFilters(input.Filters)
+
+	return &input, errs, err
+}
@@ -0,0 +1,109 @@
+package rest
+
+import (
+	"github.com/gorilla/mux"
+	"github.com/k4sper1love/watchlist-api/internal/database/postgres"
+	"net/http"
+)
+
+// CreateShareLink godoc
+// @Summary Create a share link for the collection
+// @Description Generate a public read-only link to the collection for people without an account. The token is returned only once; creating a new link revokes the previous one. You must own the collection.
+// @Tags shareLinks
+// @Accept json
+// @Produce json
+// @Param collection_id path int true "Collection ID"
+// @Success 201 {object} swagger.ShareLinkResponse
+// @Failure 400 {object} swagger.ErrorResponse
+// @Failure 401 {object} swagger.ErrorResponse
+// @Failure 403 {object} swagger.ErrorResponse
+// @Failure 404 {object} swagger.ErrorResponse
+// @Failure 500 {object} swagger.ErrorResponse
+// @Security JWTAuth
+// @Security APIKeyAuth
+// @Router /collections/{collection_id}/share [post]
+func createShareLinkHandler(w http.ResponseWriter, r *http.Request) {
+	collectionID, err := parseIDParam(r, "collectionID")
+	if err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	link, err := createShareLink(collectionID, r.Host)
+	if err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	writeJSON(w, r, http.StatusCreated, envelope{"share_link": link})
+}
+
+// DeleteShareLink godoc
+// @Summary Revoke the share link of the collection
+// @Description Revoke the public link to the collection. You must own the collection.
+// @Tags shareLinks
+// @Accept json
+// @Produce json
+// @Param collection_id path int true "Collection ID"
+// @Success 200 {object} swagger.MessageResponse
+// @Failure 400 {object} swagger.ErrorResponse
+// @Failure 401 {object} swagger.ErrorResponse
+// @Failure 403 {object} swagger.ErrorResponse
+// @Failure 404 {object} swagger.ErrorResponse
+// @Failure 500 {object} swagger.ErrorResponse
+// @Security JWTAuth
+// @Security APIKeyAuth
+// @Router /collections/{collection_id}/share [delete]
+func deleteShareLinkHandler(w http.ResponseWriter, r *http.Request) {
+	collectionID, err := parseIDParam(r, "collectionID")
+	if err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	if err := postgres.DeleteCollectionShareLink(collectionID); err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, envelope{"message": "share link revoked"})
+}
+
+// GetSharedCollection godoc
+// @Summary Get a shared collection
+// @Description Get the collection of a share link and its films without authentication. The owner's comments, reviews, ratings and viewing status are not included.
+// @Tags shareLinks
+// @Accept json
+// @Produce json
+// @Param token path string true "Share token"
+// @Param title query string false "Filter by `title`"
+// @Param rating query string false "Filter by `rating`, can be a specific value or a range like 'min-max'"
+// @Param year query string false "Filter by `year`"
+// @Param page query int false "Specify the desired `page`"
+// @Param page_size query int false "Specify the desired `page size`"
+// @Param sort query string false "Sorting by `id`, `title`, `rating`, `year`, `created_at`. Use `-` for desc"
+// @Success 200 {object} swagger.SharedCollectionResponse
+// @Failure 404 {object} swagger.ErrorResponse
+// @Failure 422 {object} swagger.ErrorResponse
+// @Failure 500 {object} swagger.ErrorResponse
+// @Router /shared/{token} [get]
+func getSharedCollectionHandler(w http.ResponseWriter, r *http.Request) {
+	token := mux.Vars(r)["token"]
+
+	input, errs, err := parseAndValidateSharedFilmsFilters(r)
+	if err != nil {
+		serverErrorResponse(w, r, err)
+		return
+	} else if errs != nil {
+		failedValidationResponse(w, r, errs)
+		return
+	}
+
+	collection, metadata, err := getSharedCollection(token, input)
+	if err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, envelope{"collection": collection, "metadata": metadata})
+}
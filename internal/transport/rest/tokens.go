@@ -1,27 +1,39 @@
 package rest
 
 import (
-	"github.com/k4sper1love/watchlist-api/internal/config"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
 	"github.com/k4sper1love/watchlist-api/internal/database/postgres"
+	"github.com/k4sper1love/watchlist-api/pkg/models"
 	"github.com/k4sper1love/watchlist-api/pkg/tokens"
 	"time"
 )
 
 // Token expiration durations
 const (
-	accessTokenExpiration  = 1 * time.Hour
-	refreshTokenExpiration = 48 * time.Hour
+	accessTokenExpiration        = 1 * time.Hour
+	refreshTokenExpiration       = 48 * time.Hour
+	passwordResetTokenExpiration = 1 * time.Hour
+	emailVerificationExpiration  = 24 * time.Hour
+	twoFactorTokenExpiration     = 5 * time.Minute
 )
 
-// generateAccessToken creates a JWT access token for a user with a short expiration time.
-func generateAccessToken(id int) (string, error) {
-	return tokens.GenerateToken(config.JWTSecret, id, accessTokenExpiration)
+// generateAccessToken creates a JWT access token for a user session with a short expiration time.
+func generateAccessToken(id int, sessionID string) (string, error) {
+	return tokens.GenerateSignedToken(signingKeys, id, sessionID, tokens.TypeAccess, accessTokenExpiration)
 }
 
 // generateAndSaveRefreshToken creates a JWT refresh token for a user with a longer expiration time.
-// It also saves the refresh token in the database.
-func generateAndSaveRefreshToken(id int) (string, error) {
-	tokenString, err := tokens.GenerateToken(config.JWTSecret, id, refreshTokenExpiration)
+// It also saves the refresh token in the database, starting a new session; session.ID is set to its ID.
+func generateAndSaveRefreshToken(id int, session *models.Session) (string, error) {
+	sessionID, err := generateSessionID()
+	if err != nil {
+		return "", err
+	}
+	session.ID = sessionID
+
+	tokenString, err := tokens.GenerateSignedToken(signingKeys, id, session.ID, tokens.TypeRefresh, refreshTokenExpiration)
 	if err != nil {
 		return "", err
 	}
@@ -29,9 +41,96 @@ func generateAndSaveRefreshToken(id int) (string, error) {
 	// Save the refresh token in the database for later use.
 	expirationTime := time.Now().Add(refreshTokenExpiration)
 
-	if err := postgres.SaveRefreshToken(tokenString, id, expirationTime); err != nil {
+	if err := postgres.SaveRefreshToken(tokenString, id, expirationTime, session); err != nil {
+		return "", err
+	}
+
+	return tokenString, nil
+}
+
+// rotateRefreshToken replaces an active refresh token with a new one in the same token family.
+// The session ID must be the one of the old refresh token. The old refresh token is revoked and can no longer be used.
+func rotateRefreshToken(refreshToken string, id int, session *models.Session) (string, error) {
+	tokenString, err := tokens.GenerateSignedToken(signingKeys, id, session.ID, tokens.TypeRefresh, refreshTokenExpiration)
+	if err != nil {
+		return "", err
+	}
+
+	expirationTime := time.Now().Add(refreshTokenExpiration)
+
+	if err := postgres.RotateRefreshToken(refreshToken, tokenString, expirationTime, session); err != nil {
+		return "", err
+	}
+
+	return tokenString, nil
+}
+
+// generateAndSavePasswordResetToken creates a single-use password reset token for a user and saves it in the database.
+func generateAndSavePasswordResetToken(id int) (string, error) {
+	tokenString, err := generateOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+
+	expirationTime := time.Now().Add(passwordResetTokenExpiration)
+
+	if err := postgres.SavePasswordResetToken(tokenString, id, expirationTime); err != nil {
+		return "", err
+	}
+
+	return tokenString, nil
+}
+
+// generateAndSaveEmailVerificationToken creates a single-use token that verifies the email of a user and saves it in the database.
+func generateAndSaveEmailVerificationToken(id int, email string) (string, error) {
+	tokenString, err := generateOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+
+	expirationTime := time.Now().Add(emailVerificationExpiration)
+
+	if err := postgres.SaveEmailVerificationToken(tokenString, id, email, expirationTime); err != nil {
 		return "", err
 	}
 
 	return tokenString, nil
 }
+
+// generateAndSaveTwoFactorToken creates a short-lived token for the second login step and saves it in the database.
+func generateAndSaveTwoFactorToken(id int) (string, time.Time, error) {
+	tokenString, err := generateOpaqueToken()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	expirationTime := time.Now().Add(twoFactorTokenExpiration)
+
+	if err := postgres.SaveTwoFactorChallenge(tokenString, id, expirationTime); err != nil {
+		return "", time.Time{}, err
+	}
+
+	return tokenString, expirationTime, nil
+}
+
+// generateSessionID creates a random UUID (version 4) that identifies a session and its refresh token family.
+func generateSessionID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	b[6] = b[6]&0x0f | 0x40 // Version 4
+	b[8] = b[8]&0x3f | 0x80 // Variant 10
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
+
+// generateOpaqueToken creates a random URL-safe token with 256 bits of entropy.
+func generateOpaqueToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,212 @@
+package rest
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"errors"
+	"github.com/k4sper1love/watchlist-api/internal/database/postgres"
+	"github.com/k4sper1love/watchlist-api/pkg/models"
+	"github.com/k4sper1love/watchlist-api/pkg/totp"
+	"strings"
+	"time"
+)
+
+// Two-factor authentication settings
+const (
+	totpIssuer           = "Watchlist" // Issuer shown in authenticator apps.
+	totpSkew             = 1           // Accepted clock skew in time steps.
+	recoveryCodeCount    = 10          // Number of recovery codes issued on confirmation.
+	twoFactorMaxAttempts = 5           // Maximum number of codes that can be tried per login.
+)
+
+// enrollTwoFactor generates a new TOTP secret for a credential user. It stays pending until confirmed with a code.
+func enrollTwoFactor(userID int) (*models.TwoFactorEnrollment, error) {
+	user, err := postgres.GetUserById(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Password == "" {
+		return nil, errRequiredPassword
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := postgres.SaveTOTPSecret(userID, secret); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errTwoFactorEnabled
+		}
+		return nil, err
+	}
+
+	return &models.TwoFactorEnrollment{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(totpIssuer, user.Username, secret),
+	}, nil
+}
+
+// confirmTwoFactor enables two-factor authentication once the user proves possession of the secret.
+// It returns the recovery codes, which are stored hashed and cannot be retrieved again.
+func confirmTwoFactor(userID int, code string) ([]string, error) {
+	t, err := postgres.GetTOTP(userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errTwoFactorNotEnrolled
+		}
+		return nil, err
+	}
+
+	if t.EnabledAt != nil {
+		return nil, errTwoFactorEnabled
+	}
+
+	step, ok := totp.Validate(t.Secret, code, time.Now(), totpSkew)
+	if !ok {
+		return nil, errInvalidTwoFactorCode
+	}
+
+	recoveryCodes, err := generateRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := postgres.EnableTOTP(userID, step, recoveryCodes); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errTwoFactorEnabled
+		}
+		return nil, err
+	}
+
+	return recoveryCodes, nil
+}
+
+// disableTwoFactor turns off two-factor authentication after checking the password and a second factor.
+func disableTwoFactor(userID int, password, code string) error {
+	user, err := postgres.GetUserById(userID)
+	if err != nil {
+		return err
+	}
+
+	if user.Password == "" {
+		return errRequiredPassword
+	}
+
+	if err := comparePasswords(user.Password, password); err != nil {
+		return err
+	}
+
+	if err := verifyTwoFactorCode(userID, code); err != nil {
+		return err
+	}
+
+	return postgres.DeleteTOTP(userID)
+}
+
+// verifyTwoFactorCode checks a TOTP code or an unused recovery code of a user with enabled two-factor authentication.
+// Accepted TOTP codes and recovery codes cannot be used again.
+func verifyTwoFactorCode(userID int, code string) error {
+	t, err := postgres.GetTOTP(userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errTwoFactorNotEnabled
+		}
+		return err
+	}
+
+	if t.EnabledAt == nil {
+		return errTwoFactorNotEnabled
+	}
+
+	if step, ok := totp.Validate(t.Secret, code, time.Now(), totpSkew); ok {
+		if err := postgres.UseTOTPStep(userID, step); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return errInvalidTwoFactorCode
+			}
+			return err
+		}
+		return nil
+	}
+
+	if err := postgres.UseRecoveryCode(userID, normalizeRecoveryCode(code)); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errInvalidTwoFactorCode
+		}
+		return err
+	}
+
+	return nil
+}
+
+// createTwoFactorChallenge issues a short-lived token that lets a user finish logging in with a second factor.
+func createTwoFactorChallenge(userID int) (*models.TwoFactorChallenge, error) {
+	challengeToken, expiresAt, err := generateAndSaveTwoFactorToken(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.TwoFactorChallenge{
+		TwoFactorRequired: true,
+		TwoFactorToken:    challengeToken,
+		ExpiresAt:         expiresAt,
+	}, nil
+}
+
+// loginWithTwoFactor exchanges a two-factor token and a code for authentication tokens.
+func loginWithTwoFactor(challengeToken, code string, session *models.Session) (*models.AuthResponse, error) {
+	userID, err := postgres.RecordTwoFactorAttempt(challengeToken, twoFactorMaxAttempts)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errInvalidTwoFactorToken
+		}
+		return nil, err
+	}
+
+	if err := verifyTwoFactorCode(userID, code); err != nil {
+		return nil, err
+	}
+
+	if err := postgres.CompleteTwoFactorChallenge(challengeToken); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errInvalidTwoFactorToken
+		}
+		return nil, err
+	}
+
+	user, err := postgres.GetUserById(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	user.Password = "" // Clear the password before returning.
+
+	return issueAuthTokens(user, session)
+}
+
+// generateRecoveryCodes creates random recovery codes in the form "xxxxx-xxxxx".
+func generateRecoveryCodes(count int) ([]string, error) {
+	const charset = "abcdefghijkmnpqrstuvwxyz23456789"
+
+	codes := make([]string, count)
+	for i := range codes {
+		b := make([]byte, 10)
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+
+		for j := range b {
+			b[j] = charset[int(b[j])%len(charset)]
+		}
+
+		codes[i] = string(b[:5]) + "-" + string(b[5:])
+	}
+
+	return codes, nil
+}
+
+// normalizeRecoveryCode converts a recovery code entered by a user to the stored form.
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.TrimSpace(code))
+}
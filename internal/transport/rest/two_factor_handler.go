@@ -0,0 +1,150 @@
+package rest
+
+import (
+	"github.com/k4sper1love/watchlist-api/pkg/models"
+	"github.com/k4sper1love/watchlist-api/pkg/validator"
+	"net/http"
+)
+
+// LoginWithTwoFactor godoc
+// @Summary Finish logging in with a second factor
+// @Description Exchange the two-factor token from `/auth/login`, `/auth/login/telegram` or the OpenID Connect callback and a TOTP or recovery code for tokens.
+// @Description The two-factor token expires after 5 minutes and allows a limited number of attempts.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param data body swagger.TwoFactorLoginRequest true "Two-factor token and code"
+// @Success 200 {object} swagger.AuthResponse
+// @Failure 400 {object} swagger.ErrorResponse
+// @Failure 401 {object} swagger.ErrorResponse
+// @Failure 422 {object} swagger.ErrorResponse
+// @Failure 500 {object} swagger.ErrorResponse
+// @Router /auth/login/2fa [post]
+func loginWithTwoFactorHandler(w http.ResponseWriter, r *http.Request) {
+	var input models.TwoFactorLogin
+
+	if err := parseRequestBody(r, &input); err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	if errs := validator.ValidateStruct(&input); errs != nil {
+		failedValidationResponse(w, r, errs)
+		return
+	}
+
+	user, err := loginWithTwoFactor(input.Token, input.Code, newSession(r))
+	if err != nil {
+		recordAuditEvent(r, auditEventLoginFailed, 0, map[string]any{"method": "two_factor", "reason": err.Error()})
+		handleDBError(w, r, err)
+		return
+	}
+
+	auditLogin(r, user, "two_factor")
+
+	writeJSON(w, r, http.StatusOK, envelope{"user": user})
+}
+
+// EnrollTwoFactor godoc
+// @Summary Start two-factor authentication enrollment
+// @Description Generate a new TOTP secret and a provisioning URI for an authenticator app.
+// @Description Two-factor authentication is enabled only after the secret is confirmed with a code.
+// @Tags user
+// @Accept json
+// @Produce json
+// @Success 200 {object} swagger.TwoFactorEnrollmentResponse
+// @Failure 400 {object} swagger.ErrorResponse
+// @Failure 401 {object} swagger.ErrorResponse
+// @Failure 409 {object} swagger.ErrorResponse
+// @Failure 500 {object} swagger.ErrorResponse
+// @Security JWTAuth
+// @Router /user/2fa/enroll [post]
+func enrollTwoFactorHandler(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(int)
+
+	enrollment, err := enrollTwoFactor(userID)
+	if err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, envelope{"two_factor": enrollment})
+}
+
+// ConfirmTwoFactor godoc
+// @Summary Confirm two-factor authentication
+// @Description Enable two-factor authentication using a code from the authenticator app.
+// @Description Returns single-use recovery codes. They are shown only once.
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param data body swagger.TwoFactorCodeRequest true "TOTP code"
+// @Success 200 {object} swagger.RecoveryCodesResponse
+// @Failure 400 {object} swagger.ErrorResponse
+// @Failure 401 {object} swagger.ErrorResponse
+// @Failure 409 {object} swagger.ErrorResponse
+// @Failure 422 {object} swagger.ErrorResponse
+// @Failure 500 {object} swagger.ErrorResponse
+// @Security JWTAuth
+// @Router /user/2fa/confirm [post]
+func confirmTwoFactorHandler(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(int)
+
+	var input models.TwoFactorCode
+
+	if err := parseRequestBody(r, &input); err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	if errs := validator.ValidateStruct(&input); errs != nil {
+		failedValidationResponse(w, r, errs)
+		return
+	}
+
+	recoveryCodes, err := confirmTwoFactor(userID, input.Code)
+	if err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, envelope{"recovery_codes": recoveryCodes})
+}
+
+// DisableTwoFactor godoc
+// @Summary Disable two-factor authentication
+// @Description Disable two-factor authentication using the current password and a TOTP or recovery code.
+// @Description The secret and all recovery codes are deleted.
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param data body swagger.TwoFactorDisableRequest true "Password and code"
+// @Success 200 {object} swagger.MessageResponse
+// @Failure 400 {object} swagger.ErrorResponse
+// @Failure 401 {object} swagger.ErrorResponse
+// @Failure 422 {object} swagger.ErrorResponse
+// @Failure 500 {object} swagger.ErrorResponse
+// @Security JWTAuth
+// @Router /user/2fa/disable [post]
+func disableTwoFactorHandler(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(int)
+
+	var input models.TwoFactorDisable
+
+	if err := parseRequestBody(r, &input); err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	if errs := validator.ValidateStruct(&input); errs != nil {
+		failedValidationResponse(w, r, errs)
+		return
+	}
+
+	if err := disableTwoFactor(userID, input.Password, input.Code); err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, envelope{"message": "two-factor authentication disabled"})
+}
@@ -4,8 +4,10 @@ import (
 	"database/sql"
 	"errors"
 	"github.com/k4sper1love/watchlist-api/internal/database/postgres"
+	"github.com/k4sper1love/watchlist-api/pkg/models"
 	"github.com/k4sper1love/watchlist-api/pkg/validator"
 	"net/http"
+	"strings"
 )
 
 // GetUser godoc
@@ -19,6 +21,7 @@ import (
 // @Failure 404 {object} swagger.ErrorResponse
 // @Failure 500 {object} swagger.ErrorResponse
 // @Security JWTAuth
+// @Security APIKeyAuth
 // @Router /user [get]
 func getUserHandler(w http.ResponseWriter, r *http.Request) {
 	userID := r.Context().Value("userID").(int)
@@ -58,6 +61,7 @@ func updateUserHandler(w http.ResponseWriter, r *http.Request) {
 		handleDBError(w, r, err)
 		return
 	}
+	oldEmail := user.Email
 
 	if err := parseRequestBody(r, user); err != nil {
 		badRequestResponse(w, r, err)
@@ -83,16 +87,94 @@ func updateUserHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	recordAuditEvent(r, auditEventUserUpdate, userID, map[string]any{"email_changed": !strings.EqualFold(user.Email, oldEmail)})
+
+	// A changed email has to be verified again.
+	if user.Email != "" && !strings.EqualFold(user.Email, oldEmail) {
+		if err := requestEmailVerification(user); err != nil {
+			serverErrorResponse(w, r, err)
+			return
+		}
+	}
+
 	writeJSON(w, r, http.StatusOK, envelope{"user": user})
 }
 
+// RequestEmailVerification godoc
+// @Summary Request email verification
+// @Description Send a new email verification token to the current email of the user.
+// @Tags user
+// @Accept json
+// @Produce json
+// @Success 200 {object} swagger.MessageResponse
+// @Failure 400 {object} swagger.ErrorResponse
+// @Failure 401 {object} swagger.ErrorResponse
+// @Failure 404 {object} swagger.ErrorResponse
+// @Failure 500 {object} swagger.ErrorResponse
+// @Security JWTAuth
+// @Router /user/email/verify [post]
+func requestEmailVerificationHandler(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(int)
+
+	user, err := postgres.GetUserById(userID)
+	if err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	if err := requestEmailVerification(user); err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, envelope{"message": "verification email sent"})
+}
+
+// ChangePassword godoc
+// @Summary Change user password
+// @Description Change the password of the user using the current password. All sessions and access tokens of the user are revoked.
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param data body swagger.ChangePasswordRequest true "Current and new password"
+// @Success 200 {object} swagger.MessageResponse
+// @Failure 400 {object} swagger.ErrorResponse
+// @Failure 401 {object} swagger.ErrorResponse
+// @Failure 404 {object} swagger.ErrorResponse
+// @Failure 422 {object} swagger.ErrorResponse
+// @Failure 500 {object} swagger.ErrorResponse
+// @Security JWTAuth
+// @Router /user/password [put]
+func changePasswordHandler(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(int)
+
+	var input models.PasswordChange
+	if err := parseRequestBody(r, &input); err != nil {
+		badRequestResponse(w, r, err)
+		return
+	}
+
+	if errs := validator.ValidateStruct(&input); errs != nil {
+		failedValidationResponse(w, r, errs)
+		return
+	}
+
+	if err := changePassword(userID, input.CurrentPassword, input.NewPassword); err != nil {
+		handleDBError(w, r, err)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, envelope{"message": "password changed"})
+}
+
 // DeleteUser godoc
 // @Summary Delete user account
-// @Description Delete user by ID using an authentication token.
+// @Description Delete the account of the authenticated user and log out of all sessions.
+// @Description The account is kept for a grace period (14 days by default) and restored by logging in again. After that, it is purged with all films, collections and permissions.
 // @Tags user
 // @Accept json
 // @Produce json
-// @Success 200 {object} swagger.MessageResponse
+// @Success 200 {object} swagger.AccountDeletionResponse
 // @Failure 401 {object} swagger.ErrorResponse
 // @Failure 404 {object} swagger.ErrorResponse
 // @Failure 500 {object} swagger.ErrorResponse
@@ -107,10 +189,14 @@ func deleteUserHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := postgres.DeleteUser(userID); err != nil {
+	// Schedule the deletion and revoke all sessions; the data is kept until the grace period passes.
+	purgeAt, err := deleteAccount(userID)
+	if err != nil {
 		handleDBError(w, r, err)
 		return
 	}
 
-	writeJSON(w, r, http.StatusOK, envelope{"message": "user deleted"})
+	recordAuditEvent(r, auditEventUserDelete, userID, map[string]any{"purge_at": purgeAt})
+
+	writeJSON(w, r, http.StatusOK, envelope{"message": "user deleted", "purge_at": purgeAt})
 }
@@ -0,0 +1,48 @@
+package rest
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/k4sper1love/watchlist-api/internal/config"
+	"github.com/k4sper1love/watchlist-api/internal/database/postgres"
+	"strconv"
+	"time"
+)
+
+// useVerificationToken checks a verification token from Telegram and marks it as used, returning its Telegram ID.
+// The token must have an expiration, an issue time within the maximum age and a unique ID,
+// so that a captured token can be used at most once and only for a short time.
+func useVerificationToken(tokenString string) (int, error) {
+	claims, err := parseTokenClaims(tokenString, verificationKeys)
+	if err != nil || claims == nil {
+		return 0, errInvalidVerificationToken
+	}
+
+	telegramID, err := strconv.Atoi(claims.Sub)
+	if err != nil {
+		return 0, errInvalidVerificationToken
+	}
+
+	if claims.Id == "" || claims.ExpiresAt == 0 || claims.IssuedAt == 0 {
+		return 0, errInvalidVerificationToken
+	}
+
+	// The token is rejected after its expiration or its maximum age, whichever comes first.
+	expiresAt := time.Unix(claims.ExpiresAt, 0)
+	if maxAge := time.Unix(claims.IssuedAt, 0).Add(config.TelegramTokenMaxAge); maxAge.Before(expiresAt) {
+		expiresAt = maxAge
+	}
+
+	if !time.Now().Before(expiresAt) {
+		return 0, errInvalidVerificationToken
+	}
+
+	if err := postgres.UseVerificationToken(claims.Id, telegramID, expiresAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, errInvalidVerificationToken
+		}
+		return 0, err
+	}
+
+	return telegramID, nil
+}
@@ -4,7 +4,8 @@
 // 1. Sets up logging with configurable formats based on the environment.
 // 2. Loads configuration from environment variables and command-line flags.
 // 3. Establishes a connection to the PostgreSQL database.
-// 4. Starts the REST API server.
+// 4. Configures the mailer used to deliver emails and the rate limiter.
+// 5. Starts the REST API server.
 //
 // The Run function is the entry point for starting the application and manages the overall setup and execution flow.
 package watchlist
@@ -15,9 +16,12 @@ import (
 	"github.com/k4sper1love/watchlist-api/internal/database/postgres"
 	"github.com/k4sper1love/watchlist-api/internal/transport/rest"
 	"github.com/k4sper1love/watchlist-api/pkg/logger/sl"
+	"github.com/k4sper1love/watchlist-api/pkg/mailer"
 	"github.com/k4sper1love/watchlist-api/pkg/metrics"
+	"github.com/k4sper1love/watchlist-api/pkg/ratelimit"
 	"github.com/k4sper1love/watchlist-api/pkg/version"
 	"log/slog"
+	"os"
 )
 
 // Run initializes and starts the application, handling configuration,
@@ -38,7 +42,48 @@ func Run(args []string) error {
 
 	defer postgres.CloseDB()
 
+	// Configure email delivery.
+	mailer.Init(newMailer())
+
+	// Configure request rate limiting.
+	ratelimit.Init(newRateLimiter())
+
 	// Start the REST server.
 	metrics.InitUptime()
 	return rest.Serve()
 }
+
+// newMailer creates the mailer selected in the configuration.
+func newMailer() mailer.Mailer {
+	switch config.Mailer {
+	case "smtp":
+		slog.Info("delivering emails via SMTP", slog.String("host", config.SMTPHost), slog.Int("port", config.SMTPPort))
+		return mailer.NewSMTPMailer(config.SMTPHost, config.SMTPPort, config.SMTPUsername, config.SMTPPassword, config.MailFrom)
+	case "memory":
+		slog.Info("keeping emails in memory")
+		return mailer.NewMemoryMailer()
+	case "log":
+		return mailer.NewLogMailer(os.Stdout)
+	default:
+		slog.Warn("unknown mailer; defaulting to 'log'", slog.String("mailer", config.Mailer))
+		return mailer.NewLogMailer(os.Stdout)
+	}
+}
+
+// newRateLimiter creates the rate limiter selected in the configuration.
+func newRateLimiter() ratelimit.Limiter {
+	switch config.RateLimiter {
+	case "postgres":
+		slog.Info("sharing rate limits via PostgreSQL")
+		return postgres.NewRateLimiter()
+	case "off":
+		slog.Info("rate limiting is disabled")
+		return ratelimit.NewMemoryLimiter()
+	case "memory":
+		return ratelimit.NewMemoryLimiter()
+	default:
+		slog.Warn("unknown rate limiter; defaulting to 'memory'", slog.String("rate_limiter", config.RateLimiter))
+		config.RateLimiter = "memory"
+		return ratelimit.NewMemoryLimiter()
+	}
+}
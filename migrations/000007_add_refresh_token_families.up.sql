@@ -0,0 +1,7 @@
+-- Every refresh token belongs to a family that starts at login and follows all its rotations.
+-- Presenting a revoked token again revokes the whole family.
+ALTER TABLE refresh_tokens
+    ADD COLUMN IF NOT EXISTS family_id UUID NOT NULL DEFAULT gen_random_uuid();
+
+CREATE INDEX IF NOT EXISTS refresh_tokens_token_idx ON refresh_tokens (token);
+CREATE INDEX IF NOT EXISTS refresh_tokens_family_id_idx ON refresh_tokens (family_id);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS refresh_tokens_user_id_idx;
+
+ALTER TABLE refresh_tokens
+    DROP COLUMN IF EXISTS client_ip,
+    DROP COLUMN IF EXISTS user_agent,
+    DROP COLUMN IF EXISTS last_used_at,
+    DROP COLUMN IF EXISTS created_at;
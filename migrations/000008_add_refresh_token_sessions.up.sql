@@ -0,0 +1,8 @@
+-- Session details of refresh tokens. A session is identified by the token family.
+ALTER TABLE refresh_tokens
+    ADD COLUMN IF NOT EXISTS created_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    ADD COLUMN IF NOT EXISTS last_used_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    ADD COLUMN IF NOT EXISTS user_agent   TEXT,
+    ADD COLUMN IF NOT EXISTS client_ip    TEXT;
+
+CREATE INDEX IF NOT EXISTS refresh_tokens_user_id_idx ON refresh_tokens (user_id);
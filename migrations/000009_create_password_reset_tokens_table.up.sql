@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS password_reset_tokens
+(
+    id         BIGSERIAL PRIMARY KEY,
+    token      TEXT UNIQUE              NOT NULL,
+    user_id    BIGINT                   NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_at    TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS users_email_idx ON users (email);
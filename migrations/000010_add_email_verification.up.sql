@@ -0,0 +1,14 @@
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS email_verified_at TIMESTAMP WITH TIME ZONE;
+
+CREATE TABLE IF NOT EXISTS email_verification_tokens
+(
+    id         BIGSERIAL PRIMARY KEY,
+    token      TEXT UNIQUE              NOT NULL,
+    user_id    BIGINT                   NOT NULL,
+    email      CITEXT                   NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_at    TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
@@ -0,0 +1,30 @@
+CREATE TABLE IF NOT EXISTS user_totp
+(
+    user_id        BIGINT PRIMARY KEY,
+    secret         TEXT                     NOT NULL,
+    enabled_at     TIMESTAMP WITH TIME ZONE,
+    last_used_step BIGINT                   NOT NULL DEFAULT 0,
+    created_at     TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS totp_recovery_codes
+(
+    id      BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    code    TEXT   NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+    UNIQUE (user_id, code),
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS two_factor_challenges
+(
+    id         BIGSERIAL PRIMARY KEY,
+    token      TEXT UNIQUE              NOT NULL,
+    user_id    BIGINT                   NOT NULL,
+    attempts   INT                      NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_at    TIMESTAMP WITH TIME ZONE,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS login_failures
+(
+    scope           TEXT                     NOT NULL,
+    key             TEXT                     NOT NULL,
+    failures        INT                      NOT NULL DEFAULT 0,
+    last_failure_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    locked_until    TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY (scope, key)
+);
+
+CREATE TABLE IF NOT EXISTS login_lockouts
+(
+    id           BIGSERIAL PRIMARY KEY,
+    scope        TEXT                     NOT NULL,
+    key          TEXT                     NOT NULL,
+    failures     INT                      NOT NULL,
+    locked_until TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS login_lockouts_scope_key_idx ON login_lockouts (scope, key);
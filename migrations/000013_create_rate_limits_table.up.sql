@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS rate_limits
+(
+    key        TEXT PRIMARY KEY,
+    tokens     DOUBLE PRECISION         NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    full_at    TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS rate_limits_full_at_idx ON rate_limits (full_at);
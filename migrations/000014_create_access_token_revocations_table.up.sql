@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS access_token_revocations
+(
+    id         BIGSERIAL PRIMARY KEY,
+    jti        TEXT,
+    session_id UUID,
+    user_id    BIGINT,
+    revoked_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS access_token_revocations_revoked_at_idx ON access_token_revocations (revoked_at);
+CREATE INDEX IF NOT EXISTS access_token_revocations_expires_at_idx ON access_token_revocations (expires_at);
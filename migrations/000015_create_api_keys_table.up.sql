@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS api_keys
+(
+    id           BIGSERIAL PRIMARY KEY,
+    user_id      BIGINT                   NOT NULL,
+    name         TEXT                     NOT NULL,
+    key          TEXT UNIQUE              NOT NULL,
+    prefix       TEXT                     NOT NULL,
+    scope        TEXT                     NOT NULL,
+    expires_at   TIMESTAMP WITH TIME ZONE,
+    last_used_at TIMESTAMP WITH TIME ZONE,
+    revoked_at   TIMESTAMP WITH TIME ZONE,
+    created_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS api_keys_user_id_idx ON api_keys (user_id);
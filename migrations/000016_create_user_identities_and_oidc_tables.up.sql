@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS user_identities
+(
+    id         BIGSERIAL PRIMARY KEY,
+    user_id    BIGINT                   NOT NULL,
+    provider   TEXT                     NOT NULL,
+    issuer     TEXT                     NOT NULL DEFAULT '',
+    subject    TEXT                     NOT NULL DEFAULT '',
+    email      CITEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS user_identities_user_id_idx ON user_identities (user_id);
+
+-- An external account can be linked to one user only, and a user has at most one password.
+CREATE UNIQUE INDEX IF NOT EXISTS user_identities_subject_idx ON user_identities (provider, issuer, subject)
+    WHERE provider <> 'password';
+CREATE UNIQUE INDEX IF NOT EXISTS user_identities_password_idx ON user_identities (user_id)
+    WHERE provider = 'password';
+
+CREATE TABLE IF NOT EXISTS oidc_states
+(
+    state         TEXT PRIMARY KEY,
+    nonce         TEXT                     NOT NULL,
+    code_verifier TEXT                     NOT NULL,
+    expires_at    TIMESTAMP WITH TIME ZONE NOT NULL
+);
@@ -0,0 +1 @@
+DELETE FROM user_identities WHERE provider IN ('password', 'telegram');
@@ -0,0 +1,12 @@
+-- Existing passwords and Telegram accounts become identities, so that they can be linked and unlinked like OpenID Connect accounts.
+INSERT INTO user_identities (user_id, provider, created_at)
+SELECT id, 'password', created_at
+FROM users
+WHERE password IS NOT NULL
+ON CONFLICT DO NOTHING;
+
+INSERT INTO user_identities (user_id, provider, subject, created_at)
+SELECT id, 'telegram', telegram_id::text, created_at
+FROM users
+WHERE telegram_id IS NOT NULL
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS verification_token_uses
+(
+    jti         TEXT PRIMARY KEY,
+    telegram_id BIGINT                   NOT NULL,
+    used_at     TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at  TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS verification_token_uses_expires_at_idx ON verification_token_uses (expires_at);
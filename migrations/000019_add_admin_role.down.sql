@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS admin_actions;
+
+DELETE FROM permissions WHERE code = 'admin:*';
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS suspended_at;
@@ -0,0 +1,20 @@
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS suspended_at TIMESTAMP WITH TIME ZONE;
+
+INSERT INTO permissions (code)
+VALUES ('admin:*')
+ON CONFLICT (code) DO NOTHING;
+
+-- Actions are kept when the admin or the target user is deleted.
+CREATE TABLE IF NOT EXISTS admin_actions
+(
+    id             BIGSERIAL PRIMARY KEY,
+    admin_id       BIGINT                   NOT NULL,
+    action         TEXT                     NOT NULL,
+    target_user_id BIGINT,
+    details        JSONB                    NOT NULL DEFAULT '{}',
+    created_at     TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS admin_actions_created_at_idx ON admin_actions (created_at);
+CREATE INDEX IF NOT EXISTS admin_actions_target_user_id_idx ON admin_actions (target_user_id);
@@ -0,0 +1,36 @@
+-- Events are kept when users are deleted, so user_id and actor_id do not reference users.
+CREATE TABLE IF NOT EXISTS audit_events
+(
+    id         BIGSERIAL PRIMARY KEY,
+    event      TEXT                     NOT NULL,
+    user_id    BIGINT,
+    actor_id   BIGINT,
+    client_ip  TEXT                     NOT NULL DEFAULT '',
+    user_agent TEXT                     NOT NULL DEFAULT '',
+    request_id TEXT                     NOT NULL DEFAULT '',
+    details    JSONB                    NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS audit_events_user_id_idx ON audit_events (user_id, created_at);
+CREATE INDEX IF NOT EXISTS audit_events_created_at_idx ON audit_events (created_at);
+
+-- The audit log is append-only: recorded events cannot be changed or removed.
+CREATE OR REPLACE FUNCTION audit_events_append_only() RETURNS TRIGGER AS
+$$
+BEGIN
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_events_no_update_or_delete
+    BEFORE UPDATE OR DELETE
+    ON audit_events
+    FOR EACH ROW
+EXECUTE FUNCTION audit_events_append_only();
+
+CREATE TRIGGER audit_events_no_truncate
+    BEFORE TRUNCATE
+    ON audit_events
+    FOR EACH STATEMENT
+EXECUTE FUNCTION audit_events_append_only();
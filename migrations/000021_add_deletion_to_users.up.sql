@@ -0,0 +1,6 @@
+-- Deleted accounts are kept for a grace period, during which logging in restores them.
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS deletion_scheduled_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS users_deletion_scheduled_at_idx ON users (deletion_scheduled_at)
+    WHERE deletion_scheduled_at IS NOT NULL;
@@ -0,0 +1,20 @@
+-- Account exports are generated in the background and can be downloaded until they expire.
+CREATE TABLE IF NOT EXISTS account_exports
+(
+    id           UUID PRIMARY KEY                  DEFAULT gen_random_uuid(),
+    user_id      BIGINT                   NOT NULL,
+    status       TEXT                     NOT NULL DEFAULT 'pending',
+    error        TEXT,
+    archive      BYTEA,
+    size         BIGINT                   NOT NULL DEFAULT 0,
+    created_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMP WITH TIME ZONE,
+    expires_at   TIMESTAMP WITH TIME ZONE,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS account_exports_user_id_idx ON account_exports (user_id);
+
+-- A user can generate only one export at a time.
+CREATE UNIQUE INDEX IF NOT EXISTS account_exports_pending_idx ON account_exports (user_id)
+    WHERE status = 'pending';
@@ -0,0 +1,35 @@
+-- Recreate the per-object permission codes of owners and of ACL entries.
+INSERT INTO permissions (code)
+SELECT 'film:' || f.id || ':' || a.action
+FROM films f
+CROSS JOIN (VALUES ('read'), ('update'), ('delete')) AS a (action)
+UNION
+SELECT 'collection:' || c.id || ':' || a.action
+FROM collections c
+CROSS JOIN (VALUES ('read'), ('update'), ('delete')) AS a (action)
+UNION
+SELECT resource_type || ':' || resource_id || ':' || action
+FROM acl_entries
+ON CONFLICT (code) DO NOTHING;
+
+INSERT INTO user_permissions (user_id, permissions_id)
+SELECT owners.user_id, p.id
+FROM (
+    SELECT f.user_id, 'film:' || f.id || ':' || a.action AS code
+    FROM films f
+    CROSS JOIN (VALUES ('read'), ('update'), ('delete')) AS a (action)
+    UNION ALL
+    SELECT c.user_id, 'collection:' || c.id || ':' || a.action
+    FROM collections c
+    CROSS JOIN (VALUES ('read'), ('update'), ('delete')) AS a (action)
+    UNION ALL
+    SELECT user_id, resource_type || ':' || resource_id || ':' || action
+    FROM acl_entries
+) AS owners
+JOIN permissions p ON p.code = owners.code
+ON CONFLICT DO NOTHING;
+
+DROP TRIGGER IF EXISTS collections_delete_acl_entries ON collections;
+DROP TRIGGER IF EXISTS films_delete_acl_entries ON films;
+DROP FUNCTION IF EXISTS acl_entries_delete_resource();
+DROP TABLE IF EXISTS acl_entries;
@@ -0,0 +1,58 @@
+-- Owners have full access to their films and collections through films.user_id and collections.user_id.
+-- Access of other users is stored as ACL entries instead of per-object permission codes.
+CREATE TABLE IF NOT EXISTS acl_entries
+(
+    resource_type TEXT                     NOT NULL,
+    resource_id   BIGINT                   NOT NULL,
+    user_id       BIGINT                   NOT NULL,
+    action        TEXT                     NOT NULL,
+    created_at    TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (resource_type, resource_id, user_id, action),
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS acl_entries_user_id_idx ON acl_entries (user_id);
+
+-- Entries of deleted films and collections are removed with them, so that new objects never inherit them.
+CREATE OR REPLACE FUNCTION acl_entries_delete_resource() RETURNS TRIGGER AS
+$$
+BEGIN
+    DELETE FROM acl_entries WHERE resource_type = TG_ARGV[0] AND resource_id = OLD.id;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER films_delete_acl_entries
+    AFTER DELETE
+    ON films
+    FOR EACH ROW
+EXECUTE FUNCTION acl_entries_delete_resource('film');
+
+CREATE TRIGGER collections_delete_acl_entries
+    AFTER DELETE
+    ON collections
+    FOR EACH ROW
+EXECUTE FUNCTION acl_entries_delete_resource('collection');
+
+-- Convert the per-object permission codes: codes of owners are implied by ownership,
+-- codes of other users become ACL entries, and codes of deleted objects are dropped.
+WITH codes AS MATERIALIZED (
+    SELECT up.user_id,
+           split_part(p.code, ':', 1)         AS resource_type,
+           split_part(p.code, ':', 2)::BIGINT AS resource_id,
+           split_part(p.code, ':', 3)         AS action
+    FROM user_permissions up
+    JOIN permissions p ON p.id = up.permissions_id
+    WHERE p.code ~ '^(film|collection):[0-9]+:(read|update|delete)$'
+)
+INSERT INTO acl_entries (resource_type, resource_id, user_id, action)
+SELECT c.resource_type, c.resource_id, c.user_id, c.action
+FROM codes c
+WHERE (c.resource_type = 'film' AND EXISTS (
+        SELECT 1 FROM films f WHERE f.id = c.resource_id AND f.user_id <> c.user_id))
+   OR (c.resource_type = 'collection' AND EXISTS (
+        SELECT 1 FROM collections col WHERE col.id = c.resource_id AND col.user_id <> c.user_id))
+ON CONFLICT DO NOTHING;
+
+DELETE FROM permissions
+WHERE code ~ '^(film|collection):[0-9]+:';
@@ -0,0 +1,8 @@
+-- A collection can have one public read-only share link. Only the hash of its token is stored.
+CREATE TABLE IF NOT EXISTS collection_share_links
+(
+    collection_id BIGINT PRIMARY KEY,
+    token_hash    TEXT UNIQUE              NOT NULL,
+    created_at    TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (collection_id) REFERENCES collections (id) ON DELETE CASCADE
+);
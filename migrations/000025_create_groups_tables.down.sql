@@ -0,0 +1,6 @@
+DROP TRIGGER IF EXISTS collections_delete_group_resources ON collections;
+DROP TRIGGER IF EXISTS films_delete_group_resources ON films;
+DROP FUNCTION IF EXISTS group_resources_delete_resource();
+DROP TABLE IF EXISTS group_resources;
+DROP TABLE IF EXISTS group_members;
+DROP TABLE IF EXISTS groups;
@@ -0,0 +1,57 @@
+-- Groups, such as households, share a library of films and collections between their members.
+CREATE TABLE IF NOT EXISTS groups
+(
+    id         BIGSERIAL PRIMARY KEY,
+    user_id    BIGINT                   NOT NULL,
+    name       TEXT                     NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS group_members
+(
+    group_id  BIGINT                   NOT NULL,
+    user_id   BIGINT                   NOT NULL,
+    role      TEXT                     NOT NULL DEFAULT 'member',
+    joined_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (group_id, user_id),
+    FOREIGN KEY (group_id) REFERENCES groups (id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS group_members_user_id_idx ON group_members (user_id);
+
+-- A film or collection belongs to the library of at most one group.
+CREATE TABLE IF NOT EXISTS group_resources
+(
+    resource_type TEXT                     NOT NULL,
+    resource_id   BIGINT                   NOT NULL,
+    group_id      BIGINT                   NOT NULL,
+    added_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (resource_type, resource_id),
+    FOREIGN KEY (group_id) REFERENCES groups (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS group_resources_group_id_idx ON group_resources (group_id, resource_type);
+
+-- Deleted films and collections leave the library of their group.
+CREATE OR REPLACE FUNCTION group_resources_delete_resource() RETURNS TRIGGER AS
+$$
+BEGIN
+    DELETE FROM group_resources WHERE resource_type = TG_ARGV[0] AND resource_id = OLD.id;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER films_delete_group_resources
+    AFTER DELETE
+    ON films
+    FOR EACH ROW
+EXECUTE FUNCTION group_resources_delete_resource('film');
+
+CREATE TRIGGER collections_delete_group_resources
+    AFTER DELETE
+    ON collections
+    FOR EACH ROW
+EXECUTE FUNCTION group_resources_delete_resource('collection');
@@ -83,6 +83,12 @@ func (f Filters) Offset() int {
 	return (f.Page - 1) * f.PageSize
 }
 
+// Unpaged returns filters that select all items on a single page, sorted by the given field.
+// It is meant for internal queries such as exports; request parameters are always paged.
+func Unpaged(sort string) Filters {
+	return Filters{Page: 1, PageSize: math.MaxInt32, Sort: sort, SortSafeList: []string{sort}}
+}
+
 // isValueInList checks if a given string value is present in a list of strings.
 // Returns true if the value is found, otherwise false.
 func isValueInList(s string, list []string) bool {
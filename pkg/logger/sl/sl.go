@@ -88,6 +88,7 @@ func PrintEndpointInfo(r *http.Request) {
 		slog.String("method", r.Method),
 		slog.String("from", r.RemoteAddr),
 		slog.String("to", r.Host),
+		slog.String("request_id", r.Header.Get("X-Request-ID")),
 	)
 }
 
@@ -100,6 +101,7 @@ func PrintEndpointError(msg string, err interface{}, r *http.Request) {
 		slog.String("method", r.Method),
 		slog.String("from", r.RemoteAddr),
 		slog.String("to", r.Host),
+		slog.String("request_id", r.Header.Get("X-Request-ID")),
 	)
 }
 
@@ -112,5 +114,6 @@ func PrintEndpointWarn(msg string, err interface{}, r *http.Request) {
 		slog.String("method", r.Method),
 		slog.String("from", r.RemoteAddr),
 		slog.String("to", r.Host),
+		slog.String("request_id", r.Header.Get("X-Request-ID")),
 	)
 }
@@ -0,0 +1,28 @@
+package mailer
+
+import (
+	"fmt"
+	"io"
+	"sync"
+)
+
+// LogMailer writes messages to an io.Writer instead of delivering them.
+// It is intended for local development.
+type LogMailer struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewLogMailer creates a mailer that writes messages to w.
+func NewLogMailer(w io.Writer) *LogMailer {
+	return &LogMailer{w: w}
+}
+
+// Send writes the message to the underlying writer.
+func (m *LogMailer) Send(msg Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	_, err := fmt.Fprintf(m.w, "To: %s\nSubject: %s\n\n%s\n\n", msg.To, msg.Subject, msg.Body)
+	return err
+}
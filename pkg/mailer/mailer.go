@@ -0,0 +1,33 @@
+// Package mailer provides a pluggable way to deliver emails.
+//
+// The Mailer interface is implemented by an SMTP mailer for real delivery, a log mailer that writes
+// messages to an io.Writer, and an in-memory mailer that keeps sent messages for inspection.
+// The package-level Send function delivers messages through the mailer configured with Init.
+package mailer
+
+import "os"
+
+// Message represents an email message.
+type Message struct {
+	To      string // Recipient email address.
+	Subject string // Subject of the message.
+	Body    string // Plain text body of the message.
+}
+
+// Mailer delivers email messages.
+type Mailer interface {
+	Send(msg Message) error
+}
+
+// defaultMailer is used by Send. Messages are written to stdout until Init is called.
+var defaultMailer Mailer = NewLogMailer(os.Stdout)
+
+// Init sets the mailer used by Send.
+func Init(m Mailer) {
+	defaultMailer = m
+}
+
+// Send delivers a message using the configured mailer.
+func Send(msg Message) error {
+	return defaultMailer.Send(msg)
+}
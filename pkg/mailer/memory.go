@@ -0,0 +1,55 @@
+package mailer
+
+import "sync"
+
+// MemoryMailer keeps sent messages in memory.
+// It is intended for tests that need to inspect delivered messages.
+type MemoryMailer struct {
+	mu       sync.Mutex
+	messages []Message
+}
+
+// NewMemoryMailer creates an empty in-memory mailer.
+func NewMemoryMailer() *MemoryMailer {
+	return &MemoryMailer{}
+}
+
+// Send stores the message.
+func (m *MemoryMailer) Send(msg Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.messages = append(m.messages, msg)
+	return nil
+}
+
+// Messages returns a copy of all sent messages in the order they were sent.
+func (m *MemoryMailer) Messages() []Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	messages := make([]Message, len(m.messages))
+	copy(messages, m.messages)
+	return messages
+}
+
+// Last returns the most recent message sent to the given address.
+func (m *MemoryMailer) Last(to string) (Message, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i := len(m.messages) - 1; i >= 0; i-- {
+		if m.messages[i].To == to {
+			return m.messages[i], true
+		}
+	}
+	return Message{}, false
+}
+
+// Reset removes all stored messages.
+func (m *MemoryMailer) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.messages = nil
+}
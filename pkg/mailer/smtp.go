@@ -0,0 +1,63 @@
+package mailer
+
+import (
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+)
+
+// SMTPMailer delivers messages through an SMTP server.
+type SMTPMailer struct {
+	Host     string // Host of the SMTP server.
+	Port     int    // Port of the SMTP server.
+	Username string // Username for authentication; authentication is skipped if empty.
+	Password string // Password for authentication.
+	From     string // Sender email address.
+}
+
+// NewSMTPMailer creates a mailer that sends messages through the given SMTP server.
+func NewSMTPMailer(host string, port int, username, password, from string) *SMTPMailer {
+	return &SMTPMailer{
+		Host:     host,
+		Port:     port,
+		Username: username,
+		Password: password,
+		From:     from,
+	}
+}
+
+// Send delivers the message to the SMTP server.
+func (m *SMTPMailer) Send(msg Message) error {
+	addr := net.JoinHostPort(m.Host, strconv.Itoa(m.Port))
+
+	// The envelope sender must be a bare address, while the From header may include a display name.
+	from, err := mail.ParseAddress(m.From)
+	if err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if m.Username != "" {
+		auth = smtp.PlainAuth("", m.Username, m.Password, m.Host)
+	}
+
+	return smtp.SendMail(addr, auth, from.Address, []string{msg.To}, m.buildMessage(msg))
+}
+
+// buildMessage formats the message with the headers required by the SMTP protocol.
+func (m *SMTPMailer) buildMessage(msg Message) []byte {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "From: %s\r\n", m.From)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", msg.Subject)
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+
+	return []byte(b.String())
+}
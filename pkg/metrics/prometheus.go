@@ -31,12 +31,21 @@ var (
 		},
 		[]string{"status"},
 	)
+
+	permissionCacheRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "permission_cache_requests_total",
+			Help: "Total number of permission cache lookups by result (hit, miss)",
+		},
+		[]string{"result"},
+	)
 )
 
 // Register metrics
 func init() {
 	prometheus.MustRegister(requestDuration)
 	prometheus.MustRegister(statusCount)
+	prometheus.MustRegister(permissionCacheRequests)
 }
 
 func RecordRequestDuration(r *http.Request, duration float64) {
@@ -47,6 +56,14 @@ func IncStatusCount(status int) {
 	statusCount.WithLabelValues(fmt.Sprintf("%d", status)).Inc()
 }
 
+func IncPermissionCacheHit() {
+	permissionCacheRequests.WithLabelValues("hit").Inc()
+}
+
+func IncPermissionCacheMiss() {
+	permissionCacheRequests.WithLabelValues("miss").Inc()
+}
+
 func getResourceType(r *http.Request) string {
 	for prefix, handler := range routePrefixes {
 		if strings.HasPrefix(r.RequestURI, prefix) {
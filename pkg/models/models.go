@@ -15,7 +15,9 @@ import (
 
 // JWTClaims defines the structure of JWT claims for user authentication by credentials.
 type JWTClaims struct {
-	Sub string `json:"sub"`
+	Sub  string `json:"sub"`
+	Sid  string `json:"sid,omitempty"` // Identifier of the session the token was issued for.
+	Type string `json:"typ,omitempty"` // Type of the token: access or refresh.
 	jwt.StandardClaims
 }
 
@@ -27,15 +29,78 @@ type Credentials struct {
 	Password   string `json:"password,omitempty" validate:"required,password,min=8,max=128" swaggerignore:"true"`      // Password for the user account; omitted in responses for security.
 }
 
+// PasswordChange represents the information required to change the password of a user.
+type PasswordChange struct {
+	CurrentPassword string `json:"current_password" validate:"required"`                    // Current password of the user.
+	NewPassword     string `json:"new_password" validate:"required,password,min=8,max=128"` // New password of the user; must be a valid password.
+}
+
+// ForgotPassword represents the information required to request a password reset.
+type ForgotPassword struct {
+	Email string `json:"email" validate:"required,email,min=6,max=254"` // Email address of the account.
+}
+
+// PasswordReset represents the information required to reset the password using a reset token.
+type PasswordReset struct {
+	Token       string `json:"token" validate:"required"`                               // Password reset token received by email.
+	NewPassword string `json:"new_password" validate:"required,password,min=8,max=128"` // New password of the user; must be a valid password.
+}
+
+// EmailVerification represents the information required to verify an email address.
+type EmailVerification struct {
+	Token string `json:"token" validate:"required"` // Email verification token received by email.
+}
+
+// TOTP represents the time-based one-time password settings of a user.
+type TOTP struct {
+	UserID       int        // Identifier of the user.
+	Secret       string     // Base32-encoded shared secret.
+	EnabledAt    *time.Time // Timestamp when two-factor authentication was confirmed; nil while pending.
+	LastUsedStep int64      // Last accepted time step, used to reject replayed codes.
+}
+
+// TwoFactorEnrollment represents the information required to add a TOTP secret to an authenticator app.
+type TwoFactorEnrollment struct {
+	Secret          string `json:"secret" example:"JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"`                                                     // Base32-encoded shared secret.
+	ProvisioningURI string `json:"provisioning_uri" example:"otpauth://totp/Watchlist:john_doe?secret=JBSWY3DPEHPK3PXP&issuer=Watchlist"` // URI for QR codes.
+}
+
+// TwoFactorChallenge represents the response to a login that requires a second factor.
+type TwoFactorChallenge struct {
+	TwoFactorRequired bool      `json:"two_factor_required" example:"true"`                   // Always true; indicates that a code is required.
+	TwoFactorToken    string    `json:"two_factor_token" example:"q0N7x2Hk5e9rVb3LmA8sYw"`    // Token to exchange together with a code for tokens.
+	ExpiresAt         time.Time `json:"expires_at" example:"2024-09-04T13:42:24.87653+05:00"` // Timestamp when the token expires.
+}
+
+// TwoFactorCode represents a TOTP or recovery code.
+type TwoFactorCode struct {
+	Code string `json:"code" validate:"required,max=32"` // TOTP code from the authenticator app or a recovery code.
+}
+
+// TwoFactorLogin represents the information required to finish a login with a second factor.
+type TwoFactorLogin struct {
+	Token string `json:"two_factor_token" validate:"required"` // Token returned by the first login step.
+	Code  string `json:"code" validate:"required,max=32"`      // TOTP code from the authenticator app or a recovery code.
+}
+
+// TwoFactorDisable represents the information required to disable two-factor authentication.
+type TwoFactorDisable struct {
+	Password string `json:"password" validate:"required"`    // Current password of the user.
+	Code     string `json:"code" validate:"required,max=32"` // TOTP code from the authenticator app or a recovery code.
+}
+
 // User represents the user data stored in the system.
 type User struct {
-	ID         int       `json:"id" example:"1"` // Unique identifier for the user.
-	TelegramID int       `json:"telegram_id,omitempty" example:"123456789"`
-	Username   string    `json:"username,omitempty" validate:"omitempty,username,min=3,max=20" example:"john_doe"`        // Username of the user; must be unique and valid.
-	Email      string    `json:"email,omitempty" validate:"omitempty,email,min=6,max=254" example:"john_doe@example.com"` // Email address of the user; must be a valid email format.
-	Password   string    `json:"password,omitempty" swaggerignore:"true"`                                                 // Password for the user account; omitted in responses for security.
-	CreatedAt  time.Time `json:"created_at" example:"2024-09-04T13:37:24.87653+05:00"`                                    // Timestamp when the user was created.
-	Version    int       `json:"-"`                                                                                       // Internal version tracking; not included in JSON responses.
+	ID                  int        `json:"id" example:"1"` // Unique identifier for the user.
+	TelegramID          int        `json:"telegram_id,omitempty" example:"123456789"`
+	Username            string     `json:"username,omitempty" validate:"omitempty,username,min=3,max=20" example:"john_doe"`        // Username of the user; must be unique and valid.
+	Email               string     `json:"email,omitempty" validate:"omitempty,email,min=6,max=254" example:"john_doe@example.com"` // Email address of the user; must be a valid email format.
+	Password            string     `json:"password,omitempty" swaggerignore:"true"`                                                 // Password for the user account; omitted in responses for security.
+	EmailVerifiedAt     *time.Time `json:"email_verified_at,omitempty" example:"2024-09-04T13:37:24.87653+05:00"`                   // Timestamp when the email was verified; omitted if not verified.
+	SuspendedAt         *time.Time `json:"suspended_at,omitempty" example:"2024-09-05T13:37:24.87653+05:00"`                        // Timestamp when the account was suspended by an admin; omitted if active.
+	DeletionScheduledAt *time.Time `json:"deletion_scheduled_at,omitempty" example:"2024-09-06T13:37:24.87653+05:00"`               // Timestamp when the user deleted the account; omitted if not deleted.
+	CreatedAt           time.Time  `json:"created_at" example:"2024-09-04T13:37:24.87653+05:00"`                                    // Timestamp when the user was created.
+	Version             int        `json:"-"`                                                                                       // Internal version tracking; not included in JSON responses.
 }
 
 // AuthResponse represents the response returned upon successful authentication.
@@ -43,6 +108,176 @@ type AuthResponse struct {
 	*User
 	AccessToken  string `json:"access_token" example:"eyJhbGciOiJIUzI1NiIs.eyJzdWIilIn0.iTNuOHMObmeRmKU"` // JWT Access Token used to access protected resources.
 	RefreshToken string `json:"refresh_token" example:"eyJhbGciOI6IkpXVCJ9.eyJzdk5EbifQ.4CfEaMw6Ur_fszI"` // JWT Refresh Token used to obtain a new Access Token when it expires.
+	Restored     bool   `json:"restored,omitempty" example:"true"`                                        // Whether logging in restored an account scheduled for deletion.
+}
+
+// Session represents a login session of a user, backed by a family of rotated refresh tokens.
+type Session struct {
+	ID         string    `json:"id" example:"5b1c7a3e-2f4d-4c4b-9a53-0f8e5d7c1a2b"`      // Identifier of the session.
+	UserAgent  string    `json:"user_agent,omitempty" example:"Mozilla/5.0"`             // User agent of the client that last used the session.
+	ClientIP   string    `json:"client_ip,omitempty" example:"203.0.113.7"`              // IP address of the client that last used the session.
+	Current    bool      `json:"current" example:"true"`                                 // Indicates if the session is the one making the request.
+	CreatedAt  time.Time `json:"created_at" example:"2024-09-04T13:37:24.87653+05:00"`   // Timestamp when the session was started.
+	LastUsedAt time.Time `json:"last_used_at" example:"2024-09-04T13:37:24.87653+05:00"` // Timestamp when the session was last refreshed.
+	ExpiresAt  time.Time `json:"expires_at" example:"2024-09-06T13:37:24.87653+05:00"`   // Timestamp when the session expires unless refreshed.
+}
+
+// AccessTokenRevocation represents revoked access tokens: a single token, all tokens of a session,
+// or all tokens of a user issued before the revocation.
+type AccessTokenRevocation struct {
+	TokenID   string    // Revoked token ID (jti); empty unless a single token is revoked.
+	SessionID string    // Revoked session ID (sid); empty unless a session is revoked.
+	UserID    int       // Revoked user ID; zero unless all tokens of a user are revoked.
+	RevokedAt time.Time // Timestamp of the revocation.
+	ExpiresAt time.Time // Timestamp after which all affected tokens have expired.
+}
+
+// API key scopes
+const (
+	APIKeyScopeRead      = "read"       // Allows only read requests.
+	APIKeyScopeReadWrite = "read-write" // Allows all requests.
+)
+
+// APIKey represents a personal API key used by scripts and integrations instead of JWT tokens.
+type APIKey struct {
+	ID         int        `json:"id" example:"1"`                                                        // Unique identifier for the API key.
+	UserID     int        `json:"-"`                                                                     // Identifier of the owner.
+	Name       string     `json:"name" validate:"required,min=1,max=100" example:"Import script"`        // Name of the API key.
+	Scope      string     `json:"scope" validate:"required,oneof=read read-write" example:"read-write"`  // Scope of the API key: read or read-write.
+	Prefix     string     `json:"prefix" example:"wl_3kF9"`                                              // First characters of the key, to tell keys apart.
+	Key        string     `json:"key,omitempty" example:"wl_3kF9x2Hk5e9rVb3LmA8sYwq0N7x2Hk5e9rVb3LmA8s"` // Secret key; returned only when the key is created.
+	ExpiresAt  *time.Time `json:"expires_at,omitempty" example:"2025-09-04T13:37:24.87653+05:00"`        // Timestamp when the key expires; never if empty.
+	LastUsedAt *time.Time `json:"last_used_at,omitempty" example:"2024-09-05T13:37:24.87653+05:00"`      // Timestamp when the key was last used.
+	CreatedAt  time.Time  `json:"created_at" example:"2024-09-04T13:37:24.87653+05:00"`                  // Timestamp when the key was created.
+}
+
+// Login identity providers.
+const (
+	IdentityProviderPassword = "password" // Username and password.
+	IdentityProviderTelegram = "telegram" // Telegram account verified by the bot.
+	IdentityProviderOIDC     = "oidc"     // OpenID Connect provider account.
+)
+
+// UserIdentity represents a login method linked to a user.
+type UserIdentity struct {
+	ID        int       `json:"id" example:"1"`                                         // Unique identifier for the identity.
+	UserID    int       `json:"-"`                                                      // Identifier of the owner.
+	Provider  string    `json:"provider" example:"telegram"`                            // Login method: password, telegram or oidc.
+	Issuer    string    `json:"issuer,omitempty" example:"https://accounts.google.com"` // Issuer of an OpenID Connect identity.
+	Subject   string    `json:"subject,omitempty" example:"123456789"`                  // Telegram ID or OpenID Connect subject.
+	Email     string    `json:"email,omitempty" example:"john_doe@example.com"`         // Email reported by the OpenID Connect provider.
+	CreatedAt time.Time `json:"created_at" example:"2024-09-04T13:37:24.87653+05:00"`   // Timestamp when the identity was linked.
+}
+
+// PasswordIdentity represents the username and password linked to an account without a password.
+type PasswordIdentity struct {
+	Username string `json:"username" validate:"required,username,min=3,max=20" example:"john_doe"`  // Username to log in with; must be unique.
+	Password string `json:"password" validate:"required,password,min=8,max=128" example:"Secret1!"` // Password to log in with.
+}
+
+// PermissionCodes represents permission codes granted to or revoked from a user.
+type PermissionCodes struct {
+	Codes []string `json:"codes" validate:"required,dive,required,max=100" example:"film:create"` // Permission codes, such as film:create or film:1:read.
+}
+
+// ACLEntry represents an action on a film or collection that a user may perform without owning it.
+type ACLEntry struct {
+	ResourceType string    `json:"resource_type" example:"collection"`                   // Type of the resource: film or collection.
+	ResourceID   int       `json:"resource_id" example:"1"`                              // Identifier of the resource.
+	UserID       int       `json:"user_id" example:"2"`                                  // Identifier of the user granted access.
+	Action       string    `json:"action" example:"read"`                                // Allowed action: read, update or delete.
+	CreatedAt    time.Time `json:"created_at" example:"2024-09-04T13:37:24.87653+05:00"` // Timestamp when the access was granted.
+}
+
+// ResourcePermissions represents the actions a user may perform on a film, collection or group.
+type ResourcePermissions struct {
+	Resource string   `json:"resource,omitempty" example:"film"`          // Type of the resource: film, collection or group.
+	ID       int      `json:"id,omitempty" example:"5"`                   // Identifier of the resource.
+	Actions  []string `json:"actions" example:"read,update,delete,share"` // Allowed actions: read, update, delete and share.
+}
+
+// AdminAction represents an action performed by an admin.
+type AdminAction struct {
+	ID           int            `json:"id" example:"1"`                                       // Unique identifier for the action.
+	AdminID      int            `json:"admin_id" example:"1"`                                 // Identifier of the admin who performed the action.
+	Action       string         `json:"action" example:"user.suspend"`                        // Name of the action.
+	TargetUserID int            `json:"target_user_id,omitempty" example:"2"`                 // Identifier of the user the action was performed on.
+	Details      map[string]any `json:"details,omitempty"`                                    // Additional information about the action.
+	CreatedAt    time.Time      `json:"created_at" example:"2024-09-04T13:37:24.87653+05:00"` // Timestamp when the action was performed.
+}
+
+// AuditEvent represents a security-relevant event recorded in the audit log.
+type AuditEvent struct {
+	ID        int            `json:"id" example:"1"`                                        // Unique identifier for the event.
+	Event     string         `json:"event" example:"user.login"`                            // Name of the event.
+	UserID    int            `json:"user_id,omitempty" example:"1"`                         // Identifier of the user the event is about; omitted if unknown.
+	ActorID   int            `json:"actor_id,omitempty" example:"1"`                        // Identifier of the authenticated user who caused the event; omitted if anonymous.
+	ClientIP  string         `json:"client_ip" example:"203.0.113.7"`                       // IP address of the client.
+	UserAgent string         `json:"user_agent" example:"Mozilla/5.0"`                      // User agent of the client.
+	RequestID string         `json:"request_id" example:"8f14e45fceea167a5a36dedd4bea2543"` // Identifier of the request, as in the X-Request-ID header.
+	Details   map[string]any `json:"details,omitempty"`                                     // Additional information about the event.
+	CreatedAt time.Time      `json:"created_at" example:"2024-09-04T13:37:24.87653+05:00"`  // Timestamp when the event was recorded.
+}
+
+// Statuses of account exports.
+const (
+	AccountExportPending = "pending" // The archive is being generated.
+	AccountExportReady   = "ready"   // The archive can be downloaded.
+	AccountExportFailed  = "failed"  // Generating the archive failed.
+)
+
+// AccountExport represents an archive of all data of a user, generated in the background.
+type AccountExport struct {
+	ID          string     `json:"id" example:"5b1c7a3e-2f4d-4c4b-9a53-0f8e5d7c1a2b"`                // Identifier of the export.
+	UserID      int        `json:"-"`                                                                // Identifier of the owner.
+	Status      string     `json:"status" example:"ready"`                                           // Status of the export: pending, ready or failed.
+	Error       string     `json:"error,omitempty" example:"failed to read films"`                   // Reason of a failed export.
+	Size        int64      `json:"size,omitempty" example:"20480"`                                   // Size of the archive in bytes.
+	CreatedAt   time.Time  `json:"created_at" example:"2024-09-04T13:37:24.87653+05:00"`             // Timestamp when the export was requested.
+	CompletedAt *time.Time `json:"completed_at,omitempty" example:"2024-09-04T13:37:26.87653+05:00"` // Timestamp when the archive was generated.
+	ExpiresAt   *time.Time `json:"expires_at,omitempty" example:"2024-09-05T13:37:26.87653+05:00"`   // Timestamp after which the archive is deleted.
+}
+
+// AccountArchiveVersion is the version of the account archive format.
+const AccountArchiveVersion = 1
+
+// AccountArchive represents all data of a user as stored in the account.json file of an export.
+type AccountArchive struct {
+	Version         int                  `json:"version" example:"1"`                                   // Version of the archive format.
+	ExportedAt      time.Time            `json:"exported_at" example:"2024-09-04T13:37:24.87653+05:00"` // Timestamp when the archive was generated.
+	User            *User                `json:"user"`                                                  // Profile of the user.
+	Identities      []*UserIdentity      `json:"identities"`                                            // Login methods of the user.
+	Films           []Film               `json:"films"`                                                 // All films of the user.
+	Collections     []*Collection        `json:"collections"`                                           // All collections of the user.
+	CollectionFilms []CollectionFilmLink `json:"collection_films"`                                      // Films in the collections.
+	Permissions     []string             `json:"permissions"`                                           // Permission codes of the user.
+	Sessions        []*Session           `json:"sessions"`                                              // Active sessions of the user.
+	Images          map[string]string    `json:"images,omitempty"`                                      // Image URLs of films mapped to files in the images folder.
+}
+
+// AccountImportReport describes what restoring an account archive creates, or created, and the conflicts found.
+type AccountImportReport struct {
+	DryRun          bool             `json:"dry_run" example:"true"`       // Whether the archive was only checked.
+	Films           int              `json:"films" example:"10"`           // Number of films restored.
+	Collections     int              `json:"collections" example:"2"`      // Number of collections restored.
+	CollectionFilms int              `json:"collection_films" example:"6"` // Number of films added to collections.
+	Images          int              `json:"images" example:"3"`           // Number of uploaded images restored.
+	Conflicts       []ImportConflict `json:"conflicts"`                    // Conflicts found in the archive.
+}
+
+// ImportConflict represents a problem found when restoring an account archive.
+type ImportConflict struct {
+	Type     string `json:"type" example:"film_exists"`                             // Kind of the conflict.
+	Message  string `json:"message" example:"film 'My film' (2001) already exists"` // Description of the conflict.
+	Blocking bool   `json:"blocking" example:"true"`                                // Whether the conflict stops the restore unless forced; other items are skipped.
+}
+
+// CollectionFilmLink represents a film in a collection by their identifiers.
+type CollectionFilmLink struct {
+	CollectionID int       `json:"collection_id" example:"1"`                            // Identifier of the collection.
+	FilmID       int       `json:"film_id" example:"1"`                                  // Identifier of the film.
+	AddedAt      time.Time `json:"added_at" example:"2024-09-04T13:37:24.87653+05:00"`   // Timestamp when the film was added to the collection.
+	UpdatedAt    time.Time `json:"updated_at" example:"2024-09-04T13:37:24.87653+05:00"` // Timestamp when the association was last updated.
 }
 
 // Collection represents a collection of films created by a user.
@@ -53,10 +288,94 @@ type Collection struct {
 	Name        string    `json:"name" validate:"required,min=3,max=100" example:"My collection"`                   // Name of the collection; required, between 3 and 100 characters.
 	Description string    `json:"description,omitempty" validate:"omitempty,max=500" example:"This is description"` // Description of the collection; optional, up to 500 characters.
 	TotalFilms  int       `json:"total_films" example:"5"`                                                          // Total number of films in the collection.
+	Role        string    `json:"role,omitempty" example:"owner"`                                                   // Role of the user in the collection: owner, editor or viewer; set in lists of collections.
 	CreatedAt   time.Time `json:"created_at" example:"2024-09-04T13:37:24.87653+05:00"`                             // Timestamp when the collection was created.
 	UpdatedAt   time.Time `json:"updated_at" example:"2024-09-04T13:37:24.87653+05:00"`                             // Timestamp when the collection was last updated.
 }
 
+// Roles of users in a collection.
+const (
+	CollectionOwner  = "owner"  // The user created the collection and manages who it is shared with.
+	CollectionEditor = "editor" // The user can view and change the collection and its films.
+	CollectionViewer = "viewer" // The user can view the collection and its films.
+)
+
+// ShareLink represents a public read-only link to a collection. The token is only returned when the link is created.
+type ShareLink struct {
+	Token     string    `json:"token" example:"fV3Q2v7ZL1mU9x2nKc0bqZ5yR8wT4jHa6sD1pE3gN7o"`                                   // Unguessable token of the link.
+	URL       string    `json:"url" example:"http://localhost:8001/api/v1/shared/fV3Q2v7ZL1mU9x2nKc0bqZ5yR8wT4jHa6sD1pE3gN7o"` // Public URL of the collection.
+	CreatedAt time.Time `json:"created_at" example:"2024-09-04T13:37:24.87653+05:00"`                                          // Timestamp when the link was created.
+}
+
+// SharedCollection represents a collection opened by a share link. It has no private fields of the owner.
+type SharedCollection struct {
+	Name        string       `json:"name" example:"My collection"`                         // Name of the collection.
+	Description string       `json:"description,omitempty" example:"This is description"`  // Description of the collection.
+	TotalFilms  int          `json:"total_films" example:"5"`                              // Total number of films in the collection.
+	Films       []SharedFilm `json:"films"`                                                // Films of the collection on the requested page.
+	CreatedAt   time.Time    `json:"created_at" example:"2024-09-04T13:37:24.87653+05:00"` // Timestamp when the collection was created.
+	UpdatedAt   time.Time    `json:"updated_at" example:"2024-09-04T13:37:24.87653+05:00"` // Timestamp when the collection was last updated.
+}
+
+// SharedFilm represents a film of a shared collection without the owner's comment, review, rating and viewing status.
+type SharedFilm struct {
+	Title       string  `json:"title" example:"My film"`                                    // Title of the film.
+	Year        int     `json:"year,omitempty" example:"2001"`                              // Release year of the film.
+	Genre       string  `json:"genre,omitempty" example:"Horror"`                           // Genre of the film.
+	Description string  `json:"description,omitempty" example:"This is description"`        // Description of the film.
+	Rating      float64 `json:"rating,omitempty" example:"6.7"`                             // Rating of the film.
+	ImageURL    string  `json:"image_url,omitempty" example:"https://placeimg.com/640/480"` // URL of the film's image.
+	URL         string  `json:"url,omitempty" example:"https://www.imdb.com/video"`         // URL for additional film information.
+}
+
+// Collaborator represents a user a collection is shared with.
+type Collaborator struct {
+	UserID    int       `json:"user_id" example:"2"`                                  // Identifier of the user.
+	Username  string    `json:"username" example:"jane_doe"`                          // Username of the user.
+	Role      string    `json:"role" example:"viewer"`                                // Role of the user: editor or viewer.
+	CreatedAt time.Time `json:"created_at" example:"2024-09-04T13:37:24.87653+05:00"` // Timestamp when the collection was shared with the user.
+}
+
+// CollaboratorInvite represents a request to share a collection with a user.
+type CollaboratorInvite struct {
+	Username string `json:"username" validate:"required" example:"jane_doe"`               // Username of the user to share the collection with.
+	Role     string `json:"role" validate:"required,oneof=viewer editor" example:"viewer"` // Role of the user: editor or viewer.
+}
+
+// CollaboratorRole represents a new role of a collaborator.
+type CollaboratorRole struct {
+	Role string `json:"role" validate:"required,oneof=viewer editor" example:"editor"` // Role of the user: editor or viewer.
+}
+
+// Roles of users in a group.
+const (
+	GroupRoleOwner  = "owner"  // The user created the group and manages its members.
+	GroupRoleMember = "member" // The user shares the library of the group.
+)
+
+// Group represents a group of users, such as a household, sharing a library of films and collections.
+type Group struct {
+	ID        int       `json:"id" example:"1"`                                               // Unique identifier for the group.
+	UserID    int       `json:"user_id" example:"1"`                                          // Identifier of the user who created the group.
+	Name      string    `json:"name" validate:"required,min=3,max=100" example:"Living room"` // Name of the group; required, between 3 and 100 characters.
+	Role      string    `json:"role,omitempty" example:"owner"`                               // Role of the user in the group: owner or member.
+	CreatedAt time.Time `json:"created_at" example:"2024-09-04T13:37:24.87653+05:00"`         // Timestamp when the group was created.
+	UpdatedAt time.Time `json:"updated_at" example:"2024-09-04T13:37:24.87653+05:00"`         // Timestamp when the group was last updated.
+}
+
+// GroupMember represents a member of a group.
+type GroupMember struct {
+	UserID   int       `json:"user_id" example:"2"`                                 // Identifier of the user.
+	Username string    `json:"username" example:"jane_doe"`                         // Username of the user.
+	Role     string    `json:"role" example:"member"`                               // Role of the user: owner or member.
+	JoinedAt time.Time `json:"joined_at" example:"2024-09-04T13:37:24.87653+05:00"` // Timestamp when the user joined the group.
+}
+
+// GroupMemberInvite represents a request to add a user to a group.
+type GroupMemberInvite struct {
+	Username string `json:"username" validate:"required" example:"jane_doe"` // Username of the user to add.
+}
+
 // Film represents a film with its details and user-specific attributes.
 type Film struct {
 	ID          int       `json:"id"  example:"1"`     // Unique identifier for the film.
@@ -102,4 +421,17 @@ type FilmsQueryInput struct {
 	UserRating        string
 	HasURL            *bool
 	IsFavorite        *bool
+	GroupID           int // Lists the library of the group instead of the films of the user if set.
+}
+
+// AuditEventsQueryInput holds the parameters for querying audit events. Zero values do not filter.
+type AuditEventsQueryInput struct {
+	filters.Filters
+	UserID    int
+	ActorID   int
+	Event     string
+	ClientIP  string
+	RequestID string
+	From      *time.Time
+	To        *time.Time
 }
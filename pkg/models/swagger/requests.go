@@ -16,6 +16,44 @@ type UpdateUserRequest struct {
 	Email    string `json:"email" example:"new@example.com"`
 }
 
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" example:"Secret1!"`
+	NewPassword     string `json:"new_password" example:"NewSecret1!"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" example:"john_doe@example.com"`
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token" example:"q0N7x2Hk5e9rVb3LmA8sYw"`
+	NewPassword string `json:"new_password" example:"NewSecret1!"`
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" example:"q0N7x2Hk5e9rVb3LmA8sYw"`
+}
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" example:"123456"`
+}
+
+type TwoFactorLoginRequest struct {
+	Token string `json:"two_factor_token" example:"q0N7x2Hk5e9rVb3LmA8sYw"`
+	Code  string `json:"code" example:"123456"`
+}
+
+type TwoFactorDisableRequest struct {
+	Password string `json:"password" example:"Secret1!"`
+	Code     string `json:"code" example:"123456"`
+}
+
+type APIKeyRequest struct {
+	Name      string     `json:"name" example:"Import script"`
+	Scope     string     `json:"scope" example:"read-write"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty" example:"2025-09-04T13:37:24.87653+05:00"`
+}
+
 type FilmRequest struct {
 	IsFavorite  bool    `json:"is_favorite" example:"false"`
 	Title       string  `json:"title" example:"My film"`
@@ -37,6 +75,31 @@ type CollectionRequest struct {
 	Description string `json:"description,omitempty" example:"This is description"`
 }
 
+type CollaboratorRequest struct {
+	Username string `json:"username" example:"jane_doe"`
+	Role     string `json:"role" example:"viewer"`
+}
+
+type CollaboratorRoleRequest struct {
+	Role string `json:"role" example:"editor"`
+}
+
+type GroupRequest struct {
+	Name string `json:"name" example:"Living room"`
+}
+
+type GroupMemberRequest struct {
+	Username string `json:"username" example:"jane_doe"`
+}
+
 type CollectionFilmRequest struct {
 	AddedAt time.Time `json:"added_at" example:"2024-09-04T13:37:24.87653+05:00"`
 }
+
+type PasswordIdentityRequest struct {
+	LoginRequest
+}
+
+type PermissionCodesRequest struct {
+	Codes []string `json:"codes" example:"film:create,collection:create"`
+}
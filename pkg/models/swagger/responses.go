@@ -3,6 +3,7 @@ package swagger
 import (
 	"github.com/k4sper1love/watchlist-api/pkg/filters"
 	"github.com/k4sper1love/watchlist-api/pkg/models"
+	"time"
 )
 
 type AuthResponse struct {
@@ -12,6 +13,24 @@ type AuthResponse struct {
 type MessageResponse struct {
 	Message string `json:"message" example:"some kind of success message"`
 }
+type AccountDeletionResponse struct {
+	Message string    `json:"message" example:"user deleted"`
+	PurgeAt time.Time `json:"purge_at" example:"2024-09-18T13:37:24.87653+05:00"`
+}
+
+type AccountExportResponse struct {
+	Export models.AccountExport `json:"export"`
+}
+
+type AccountImportResponse struct {
+	Import models.AccountImportReport `json:"import"`
+}
+
+type AccountImportConflictResponse struct {
+	Error  string                     `json:"error" example:"the archive conflicts with the account; check the conflicts or force the restore"`
+	Import models.AccountImportReport `json:"import"`
+}
+
 type ErrorResponse struct {
 	Error string `json:"error" example:"some kind of error"`
 }
@@ -20,26 +39,129 @@ type UserResponse struct {
 	User models.User `json:"user"`
 }
 
+type UsersResponse struct {
+	Users    []models.User    `json:"users"`
+	Metadata filters.Metadata `json:"metadata"`
+}
+
+type PermissionsResponse struct {
+	Permissions []string `json:"permissions" example:"film:create,collection:create,film:1:read"`
+}
+
+type ResourcePermissionsResponse struct {
+	ResourcePermissions models.ResourcePermissions `json:"resource_permissions"`
+}
+
+type AdminActionsResponse struct {
+	Actions  []models.AdminAction `json:"actions"`
+	Metadata filters.Metadata     `json:"metadata"`
+}
+
+type AuditEventsResponse struct {
+	Events   []models.AuditEvent `json:"events"`
+	Metadata filters.Metadata    `json:"metadata"`
+}
+
+type SessionsResponse struct {
+	Sessions []models.Session `json:"sessions"`
+}
+
+type TwoFactorChallengeResponse struct {
+	TwoFactor models.TwoFactorChallenge `json:"two_factor"`
+}
+
+type IdentitiesResponse struct {
+	Identities []models.UserIdentity `json:"identities"`
+}
+
+type OIDCLoginResponse struct {
+	AuthorizationURL string `json:"authorization_url" example:"https://accounts.example.com/authorize?client_id=watchlist&state=..."`
+	State            string `json:"state" example:"q3l1F0yJb9pWkJc2m1c6dXbTqH8Nw0R4ZbQf2sYkAeI"`
+}
+
+type TwoFactorEnrollmentResponse struct {
+	TwoFactor models.TwoFactorEnrollment `json:"two_factor"`
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes" example:"k7m2p-x9qaz,4hd8w-ne3rt"`
+}
+
+type APIKeyResponse struct {
+	APIKey models.APIKey `json:"api_key"`
+}
+
+type APIKeysResponse struct {
+	APIKeys []models.APIKey `json:"api_keys"`
+}
+
 type AccessTokenResponse struct {
-	Token string `json:"access_token" example:"eyJhbGciOI6IkpXVCJ9.eyJzdk5EbifQ.4CfEaMw6Ur_fszI"`
+	Token        string `json:"access_token" example:"eyJhbGciOI6IkpXVCJ9.eyJzdk5EbifQ.4CfEaMw6Ur_fszI"`
+	RefreshToken string `json:"refresh_token" example:"eyJhbGciOI6IkpXVCJ9.eyJzdk5EbifQ.4CfEaMw6Ur_fszI"`
 }
 
 type FilmResponse struct {
-	Film models.Film `json:"film"`
+	Film        models.Film                 `json:"film"`
+	Permissions *models.ResourcePermissions `json:"_permissions,omitempty"`
 }
 
 type FilmsResponse struct {
-	Films    []models.Film    `json:"films"`
+	Films    []FilmItem       `json:"films"`
 	Metadata filters.Metadata `json:"metadata"`
 }
 
+type FilmItem struct {
+	models.Film
+	Permissions *models.ResourcePermissions `json:"_permissions,omitempty"`
+}
+
 type CollectionResponse struct {
-	Collection models.Collection `json:"collection"`
+	Collection  models.Collection           `json:"collection"`
+	Permissions *models.ResourcePermissions `json:"_permissions,omitempty"`
 }
 
 type CollectionsResponse struct {
-	Collections []models.Collection `json:"collections"`
-	Metadata    filters.Metadata    `json:"metadata"`
+	Collections []CollectionItem `json:"collections"`
+	Metadata    filters.Metadata `json:"metadata"`
+}
+
+type CollectionItem struct {
+	models.Collection
+	Permissions *models.ResourcePermissions `json:"_permissions,omitempty"`
+}
+
+type CollaboratorResponse struct {
+	Collaborator models.Collaborator `json:"collaborator"`
+}
+
+type CollaboratorsResponse struct {
+	Collaborators []models.Collaborator `json:"collaborators"`
+}
+
+type ShareLinkResponse struct {
+	ShareLink models.ShareLink `json:"share_link"`
+}
+
+type SharedCollectionResponse struct {
+	Collection models.SharedCollection `json:"collection"`
+	Metadata   filters.Metadata        `json:"metadata"`
+}
+
+type GroupResponse struct {
+	Group models.Group `json:"group"`
+}
+
+type GroupsResponse struct {
+	Groups []models.Group `json:"groups"`
+}
+
+type GroupMembersResponse struct {
+	Group   models.Group         `json:"group"`
+	Members []models.GroupMember `json:"members"`
+}
+
+type GroupMemberResponse struct {
+	Member models.GroupMember `json:"member"`
 }
 
 type CollectionFilmResponse struct {
@@ -47,6 +169,11 @@ type CollectionFilmResponse struct {
 }
 
 type CollectionFilmsResponse struct {
-	CollectionFilms models.CollectionFilms `json:"collection_films"`
-	Metadata        filters.Metadata       `json:"metadata"`
+	CollectionFilms CollectionFilmItems `json:"collection_films"`
+	Metadata        filters.Metadata    `json:"metadata"`
+}
+
+type CollectionFilmItems struct {
+	Collection models.Collection `json:"collection"`
+	Films      []FilmItem        `json:"films"`
 }
@@ -0,0 +1,191 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt"
+	"github.com/k4sper1love/watchlist-api/pkg/tokens"
+	"net/http"
+	"time"
+)
+
+// Verification settings
+const (
+	clockSkew          = time.Minute // Tolerated clock difference with the provider.
+	jwksRefreshBackoff = time.Minute // Minimum time between reloads of the provider keys.
+)
+
+// allowedAlgorithms lists the signing algorithms accepted for ID tokens.
+var allowedAlgorithms = map[string]struct{}{
+	"RS256": {},
+	"ES256": {},
+	"EdDSA": {},
+}
+
+// IDTokenClaims holds the claims of an ID token used for login and provisioning.
+type IDTokenClaims struct {
+	Issuer            string   `json:"iss"`
+	Subject           string   `json:"sub"`
+	Audience          audience `json:"aud"`
+	ExpiresAt         int64    `json:"exp"`
+	IssuedAt          int64    `json:"iat"`
+	Nonce             string   `json:"nonce"`
+	Email             string   `json:"email"`
+	EmailVerified     bool     `json:"email_verified"`
+	PreferredUsername string   `json:"preferred_username"`
+	Name              string   `json:"name"`
+}
+
+// Valid checks the time claims of the token, tolerating a small clock skew.
+func (c *IDTokenClaims) Valid() error {
+	now := time.Now()
+
+	if c.ExpiresAt == 0 || now.After(time.Unix(c.ExpiresAt, 0).Add(clockSkew)) {
+		return errors.New("token is expired")
+	}
+
+	if c.IssuedAt != 0 && now.Add(clockSkew).Before(time.Unix(c.IssuedAt, 0)) {
+		return errors.New("token is used before it was issued")
+	}
+
+	return nil
+}
+
+// audience is the "aud" claim, which may be a string or an array of strings.
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+
+	var multiple []string
+	if err := json.Unmarshal(data, &multiple); err != nil {
+		return err
+	}
+
+	*a = multiple
+	return nil
+}
+
+// contains reports whether the audience includes the client ID.
+func (a audience) contains(clientID string) bool {
+	for _, aud := range a {
+		if aud == clientID {
+			return true
+		}
+	}
+	return false
+}
+
+// VerifyIDToken verifies the signature of an ID token with the provider keys and checks its issuer,
+// audience, expiration and nonce.
+func (p *Provider) VerifyIDToken(ctx context.Context, rawIDToken, nonce string) (*IDTokenClaims, error) {
+	d, err := p.getDiscovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := &IDTokenClaims{}
+	token, err := jwt.ParseWithClaims(rawIDToken, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := allowedAlgorithms[token.Method.Alg()]; !ok {
+			return nil, fmt.Errorf("unexpected signing algorithm %q", token.Method.Alg())
+		}
+
+		kid, _ := token.Header["kid"].(string)
+		return p.publicKey(ctx, d.JWKSURI, kid)
+	})
+	if err != nil || !token.Valid {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidIDToken, err)
+	}
+
+	if claims.Issuer != d.Issuer {
+		return nil, fmt.Errorf("%w: unexpected issuer %q", ErrInvalidIDToken, claims.Issuer)
+	}
+
+	if !claims.Audience.contains(p.ClientID) {
+		return nil, fmt.Errorf("%w: client is not in the audience", ErrInvalidIDToken)
+	}
+
+	if claims.Nonce != nonce {
+		return nil, fmt.Errorf("%w: nonce mismatch", ErrInvalidIDToken)
+	}
+
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: missing subject", ErrInvalidIDToken)
+	}
+
+	return claims, nil
+}
+
+// keySet is a cached JSON Web Key Set of the provider.
+type keySet struct {
+	keys     map[string]interface{}
+	loadedAt time.Time
+}
+
+// publicKey returns the provider key with the given ID. The key set is reloaded when the key is unknown,
+// which picks up rotated keys, but not more often than the refresh backoff.
+func (p *Provider) publicKey(ctx context.Context, jwksURI, kid string) (interface{}, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.keys != nil {
+		if key, ok := p.lookupKey(kid); ok {
+			return key, nil
+		}
+
+		if time.Since(p.keys.loadedAt) < jwksRefreshBackoff {
+			return nil, tokens.ErrUnknownKey
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, jwksURI, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var set tokens.JWKS
+	if err := p.do(req, &set); err != nil {
+		return nil, fmt.Errorf("provider keys: %w", err)
+	}
+
+	keys := make(map[string]interface{}, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		key, err := jwk.PublicKey()
+		if err != nil {
+			continue // Skip keys of unsupported types.
+		}
+		keys[jwk.Kid] = key
+	}
+
+	p.keys = &keySet{keys: keys, loadedAt: time.Now()}
+
+	if key, ok := p.lookupKey(kid); ok {
+		return key, nil
+	}
+	return nil, tokens.ErrUnknownKey
+}
+
+// lookupKey finds a cached key by ID. A token without an ID matches the only key of a single-key set.
+func (p *Provider) lookupKey(kid string) (interface{}, bool) {
+	if key, ok := p.keys.keys[kid]; ok {
+		return key, true
+	}
+
+	if kid == "" && len(p.keys.keys) == 1 {
+		for _, key := range p.keys.keys {
+			return key, true
+		}
+	}
+
+	return nil, false
+}
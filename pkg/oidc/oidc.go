@@ -0,0 +1,213 @@
+// Package oidc implements the client side of OpenID Connect login.
+//
+// It supports the authorization code flow with PKCE (RFC 7636): the provider configuration is read from
+// the discovery document of the issuer, codes are exchanged at the token endpoint, and ID tokens are
+// verified against the JSON Web Key Set of the provider.
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Errors returned by the provider.
+var (
+	ErrInvalidIDToken = errors.New("invalid ID token")
+	ErrNoIDToken      = errors.New("token response does not contain an ID token")
+)
+
+// Provider is an OpenID Connect provider with the client registered at it.
+type Provider struct {
+	Issuer       string   // Issuer URL; the discovery document is read from Issuer + "/.well-known/openid-configuration".
+	ClientID     string   // Client ID registered at the provider.
+	ClientSecret string   // Client secret; empty for public clients.
+	RedirectURL  string   // Redirect URL registered at the provider.
+	Scopes       []string // Requested scopes; "openid" is always included.
+
+	client *http.Client
+
+	mu        sync.Mutex
+	discovery *discovery
+	keys      *keySet
+}
+
+// discovery holds the fields of the provider discovery document used by the client.
+type discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// TokenResponse is the response of the token endpoint.
+type TokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	IDToken     string `json:"id_token"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+// NewProvider creates a provider. The discovery document is loaded on first use.
+func NewProvider(issuer, clientID, clientSecret, redirectURL string, scopes []string) *Provider {
+	return &Provider{
+		Issuer:       strings.TrimSuffix(issuer, "/"),
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		RedirectURL:  redirectURL,
+		Scopes:       scopes,
+		client:       &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// AuthCodeURL returns the URL of the authorization endpoint that starts a login.
+// The state and nonce must be random and checked in the callback; the code challenge is derived from the PKCE verifier.
+func (p *Provider) AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error) {
+	d, err := p.getDiscovery(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	params := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {p.ClientID},
+		"redirect_uri":          {p.RedirectURL},
+		"scope":                 {p.scope()},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {codeChallenge},
+		"code_challenge_method": {"S256"},
+	}
+
+	separator := "?"
+	if strings.Contains(d.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+
+	return d.AuthorizationEndpoint + separator + params.Encode(), nil
+}
+
+// Exchange exchanges an authorization code and its PKCE verifier for tokens.
+func (p *Provider) Exchange(ctx context.Context, code, codeVerifier string) (*TokenResponse, error) {
+	d, err := p.getDiscovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.RedirectURL},
+		"client_id":     {p.ClientID},
+		"code_verifier": {codeVerifier},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if p.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(p.ClientID), url.QueryEscape(p.ClientSecret))
+	}
+
+	var token TokenResponse
+	if err := p.do(req, &token); err != nil {
+		return nil, fmt.Errorf("token exchange: %w", err)
+	}
+
+	if token.IDToken == "" {
+		return nil, ErrNoIDToken
+	}
+
+	return &token, nil
+}
+
+// scope returns the space-separated scopes, including "openid".
+func (p *Provider) scope() string {
+	scopes := []string{"openid"}
+	for _, s := range p.Scopes {
+		if s != "" && s != "openid" {
+			scopes = append(scopes, s)
+		}
+	}
+	return strings.Join(scopes, " ")
+}
+
+// getDiscovery loads the discovery document once and checks that it belongs to the configured issuer.
+func (p *Provider) getDiscovery(ctx context.Context) (*discovery, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.Issuer+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var d discovery
+	if err := p.do(req, &d); err != nil {
+		return nil, fmt.Errorf("provider discovery: %w", err)
+	}
+
+	if strings.TrimSuffix(d.Issuer, "/") != p.Issuer {
+		return nil, fmt.Errorf("provider discovery: issuer %q does not match %q", d.Issuer, p.Issuer)
+	}
+
+	if d.AuthorizationEndpoint == "" || d.TokenEndpoint == "" || d.JWKSURI == "" {
+		return nil, errors.New("provider discovery: missing endpoints")
+	}
+
+	p.discovery = &d
+	return p.discovery, nil
+}
+
+// do sends a request and decodes a successful JSON response into target.
+func (p *Provider) do(req *http.Request, target any) error {
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return json.Unmarshal(body, target)
+}
+
+// GenerateRandom returns a random URL-safe string for states, nonces and PKCE verifiers.
+func GenerateRandom() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// CodeChallenge returns the S256 PKCE code challenge of a verifier.
+func CodeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,70 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often the memory limiter removes full buckets.
+const sweepInterval = time.Minute
+
+// bucket is the state of a token bucket.
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+	fullAt    time.Time // Time when the bucket is full again and can be forgotten.
+}
+
+// MemoryLimiter keeps token buckets in memory.
+// Limits are not shared between application instances.
+type MemoryLimiter struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+// NewMemoryLimiter creates an empty in-memory limiter.
+func NewMemoryLimiter() *MemoryLimiter {
+	return &MemoryLimiter{
+		buckets:   make(map[string]*bucket),
+		lastSweep: time.Now(),
+	}
+}
+
+// Allow takes a token from the bucket of the key.
+func (m *MemoryLimiter) Allow(key string, limit Limit) (Result, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	m.sweep(now)
+
+	b, ok := m.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Requests), updatedAt: now}
+		m.buckets[key] = b
+	}
+
+	tokens, result := Take(b.tokens, now.Sub(b.updatedAt), limit)
+
+	b.tokens = tokens
+	b.updatedAt = now
+	b.fullAt = now.Add(result.Reset)
+
+	return result, nil
+}
+
+// sweep removes buckets that have been refilled, so that memory does not grow with the number of clients.
+func (m *MemoryLimiter) sweep(now time.Time) {
+	if now.Sub(m.lastSweep) < sweepInterval {
+		return
+	}
+
+	for key, b := range m.buckets {
+		if now.After(b.fullAt) {
+			delete(m.buckets, key)
+		}
+	}
+
+	m.lastSweep = now
+}
@@ -0,0 +1,77 @@
+// Package ratelimit provides token-bucket rate limiting with pluggable storage.
+//
+// The Limiter interface is implemented by an in-memory limiter for single-instance deployments.
+// Other backends, such as a shared database, can implement it using the Take function.
+// The package-level Allow function uses the limiter configured with Init.
+package ratelimit
+
+import (
+	"math"
+	"time"
+)
+
+// Limit describes a token bucket that holds up to Requests tokens and refills completely within Period.
+type Limit struct {
+	Requests int           // Maximum number of requests in a burst.
+	Period   time.Duration // Time needed to refill an empty bucket.
+}
+
+// rate returns the number of tokens added per second.
+func (l Limit) rate() float64 {
+	return float64(l.Requests) / l.Period.Seconds()
+}
+
+// Result describes the outcome of a rate limit check.
+type Result struct {
+	Allowed    bool          // Indicates if the request is allowed.
+	Limit      int           // Maximum number of requests in a burst.
+	Remaining  int           // Number of requests left in the bucket.
+	Reset      time.Duration // Time until the bucket is full again.
+	RetryAfter time.Duration // Time until the next request is allowed; zero if the request is allowed.
+}
+
+// Limiter takes tokens from buckets identified by keys.
+type Limiter interface {
+	Allow(key string, limit Limit) (Result, error)
+}
+
+// defaultLimiter is used by Allow. Requests are limited in memory until Init is called.
+var defaultLimiter Limiter = NewMemoryLimiter()
+
+// Init sets the limiter used by Allow.
+func Init(l Limiter) {
+	defaultLimiter = l
+}
+
+// Allow takes a token for the key using the configured limiter.
+func Allow(key string, limit Limit) (Result, error) {
+	return defaultLimiter.Allow(key, limit)
+}
+
+// Take refills a bucket holding the given number of tokens for the elapsed time and takes one token from it.
+// It returns the new number of tokens and the result. A new bucket should be passed with limit.Requests tokens.
+func Take(tokens float64, elapsed time.Duration, limit Limit) (float64, Result) {
+	rate := limit.rate()
+	burst := float64(limit.Requests)
+
+	tokens = math.Min(burst, tokens+elapsed.Seconds()*rate)
+
+	result := Result{Limit: limit.Requests}
+
+	if tokens >= 1 {
+		tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = seconds((1 - tokens) / rate)
+	}
+
+	result.Remaining = int(tokens)
+	result.Reset = seconds((burst - tokens) / rate)
+
+	return tokens, result
+}
+
+// seconds converts a number of seconds to a duration.
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
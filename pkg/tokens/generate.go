@@ -1,25 +1,62 @@
 package tokens
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"github.com/golang-jwt/jwt"
 	"github.com/k4sper1love/watchlist-api/pkg/models"
 	"strconv"
 	"time"
 )
 
-// GenerateToken creates a JWT token for a user with a specified expiration duration.
+// Token types. Only access tokens authenticate API requests; refresh tokens are only accepted to refresh and log out.
+const (
+	TypeAccess  = "access"
+	TypeRefresh = "refresh"
+)
+
+// GenerateToken creates an HS256 JWT access token for a user with a specified expiration duration.
 func GenerateToken(secret string, id int, duration time.Duration) (string, error) {
-	expirationTime := time.Now().Add(duration)
+	keys, err := NewKeySet(NewHMACKey("", []byte(secret)))
+	if err != nil {
+		return "", err
+	}
+
+	return GenerateSignedToken(keys, id, "", TypeAccess, duration)
+}
+
+// GenerateSignedToken creates a JWT token of the given type for a user bound to a session, signed with the signing key of the key set.
+func GenerateSignedToken(keys *KeySet, id int, sessionID, tokenType string, duration time.Duration) (string, error) {
+	now := time.Now()
+	expirationTime := now.Add(duration)
 
-	// Create the claims including user ID and expiration time.
+	tokenID, err := generateTokenID()
+	if err != nil {
+		return "", err
+	}
+
+	// Create the claims including user ID, token type, expiration time and a unique token ID.
+	// The token ID keeps tokens issued within the same second distinct.
 	claims := &models.JWTClaims{
-		Sub: strconv.Itoa(id),
+		Sub:  strconv.Itoa(id),
+		Sid:  sessionID,
+		Type: tokenType,
 		StandardClaims: jwt.StandardClaims{
+			Id:        tokenID,
+			IssuedAt:  now.Unix(),
 			ExpiresAt: expirationTime.Unix(),
 		},
 	}
 
-	// Create and sign the token using the HS256 signing method.
-	token := jwt.NewWithClaims(jwt.GetSigningMethod("HS256"), claims)
-	return token.SignedString([]byte(secret))
+	// Sign the token with the signing key, which also sets its algorithm and key ID.
+	return keys.Sign(claims)
+}
+
+// generateTokenID returns a random 128-bit identifier encoded as a hexadecimal string.
+func generateTokenID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
 }
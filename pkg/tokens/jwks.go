@@ -0,0 +1,127 @@
+package tokens
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+	"sort"
+)
+
+// JWK is a public key in JSON Web Key format (RFC 7517).
+type JWK struct {
+	Kty string `json:"kty"`           // Key type: "RSA" or "OKP".
+	Kid string `json:"kid"`           // Key ID matching the "kid" header of tokens.
+	Alg string `json:"alg"`           // Signing algorithm of the key.
+	Use string `json:"use"`           // Intended use of the key; always "sig".
+	N   string `json:"n,omitempty"`   // RSA modulus.
+	E   string `json:"e,omitempty"`   // RSA public exponent.
+	Crv string `json:"crv,omitempty"` // Curve of an EC or OKP key, such as "P-256" or "Ed25519".
+	X   string `json:"x,omitempty"`   // Ed25519 public key or EC x coordinate.
+	Y   string `json:"y,omitempty"`   // EC y coordinate.
+}
+
+// JWKS is a JSON Web Key Set.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS returns the public keys of the key set. HMAC keys are never published.
+func (s *KeySet) JWKS() JWKS {
+	set := JWKS{Keys: []JWK{}}
+
+	for _, key := range s.keys {
+		jwk := JWK{Kid: key.ID, Alg: key.Method.Alg(), Use: "sig"}
+
+		switch k := key.PublicKey().(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = encodeSegment(k.N.Bytes())
+			jwk.E = encodeSegment(big.NewInt(int64(k.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = encodeSegment(k)
+		default:
+			continue
+		}
+
+		set.Keys = append(set.Keys, jwk)
+	}
+
+	sort.Slice(set.Keys, func(i, j int) bool { return set.Keys[i].Kid < set.Keys[j].Kid })
+
+	return set
+}
+
+// PublicKey decodes the public key of an RSA, EC (P-256, P-384, P-521) or Ed25519 JSON Web Key.
+func (k JWK) PublicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeSegment(k.N)
+		if err != nil {
+			return nil, err
+		}
+
+		e, err := decodeSegment(k.E)
+		if err != nil {
+			return nil, err
+		}
+
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, ErrUnsupportedKey
+		}
+
+		x, err := decodeSegment(k.X)
+		if err != nil {
+			return nil, err
+		}
+
+		y, err := decodeSegment(k.Y)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, ErrUnsupportedKey
+		}
+
+		x, err := decodeSegment(k.X)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(x) != ed25519.PublicKeySize {
+			return nil, ErrUnsupportedKey
+		}
+
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, ErrUnsupportedKey
+	}
+}
+
+// encodeSegment encodes bytes as unpadded base64url, as required for JWK members.
+func encodeSegment(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decodeSegment decodes unpadded base64url, as used for JWK members.
+func decodeSegment(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(s)
+}
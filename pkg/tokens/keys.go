@@ -0,0 +1,209 @@
+package tokens
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Errors returned when loading keys or verifying tokens.
+var (
+	ErrUnknownKey        = errors.New("unknown signing key")
+	ErrUnexpectedAlg     = errors.New("unexpected signing algorithm")
+	ErrNoSigningKey      = errors.New("signing key is not configured")
+	ErrUnsupportedKey    = errors.New("unsupported key type")
+	ErrUnsupportedMethod = errors.New("unsupported signing algorithm")
+)
+
+// Key is a key used to sign and verify tokens.
+// A key without a private part can only verify tokens, which is used for retired keys during rotation.
+type Key struct {
+	ID        string            // Key ID placed in the "kid" header of tokens.
+	Method    jwt.SigningMethod // Signing method of the key.
+	signKey   interface{}       // HMAC secret or private key; nil for verification-only keys.
+	verifyKey interface{}       // HMAC secret or public key.
+}
+
+// NewHMACKey creates an HS256 key from a shared secret.
+func NewHMACKey(id string, secret []byte) *Key {
+	return &Key{ID: id, Method: jwt.SigningMethodHS256, signKey: secret, verifyKey: secret}
+}
+
+// NewKey creates a key from an RSA or Ed25519 private or public key.
+// RSA keys sign tokens with RS256 and Ed25519 keys with EdDSA.
+func NewKey(id string, key interface{}) (*Key, error) {
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return &Key{ID: id, Method: jwt.SigningMethodRS256, signKey: k, verifyKey: &k.PublicKey}, nil
+	case *rsa.PublicKey:
+		return &Key{ID: id, Method: jwt.SigningMethodRS256, verifyKey: k}, nil
+	case ed25519.PrivateKey:
+		return &Key{ID: id, Method: jwt.SigningMethodEdDSA, signKey: k, verifyKey: k.Public()}, nil
+	case ed25519.PublicKey:
+		return &Key{ID: id, Method: jwt.SigningMethodEdDSA, verifyKey: k}, nil
+	default:
+		return nil, ErrUnsupportedKey
+	}
+}
+
+// GenerateKey creates a new random key for the algorithm (RS256 or EdDSA).
+func GenerateKey(id, algorithm string) (*Key, error) {
+	var (
+		key interface{}
+		err error
+	)
+
+	switch algorithm {
+	case jwt.SigningMethodRS256.Alg():
+		key, err = rsa.GenerateKey(rand.Reader, 2048)
+	case jwt.SigningMethodEdDSA.Alg():
+		_, key, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		return nil, ErrUnsupportedMethod
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewKey(id, key)
+}
+
+// ParsePEMKey parses a PEM-encoded RSA or Ed25519 key.
+// Private keys may be in PKCS #1 or PKCS #8 form, public keys in PKIX form.
+func ParsePEMKey(id string, data []byte) (*Key, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("key %q: no PEM data found", id)
+	}
+
+	var (
+		key interface{}
+		err error
+	)
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		key, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	case "PUBLIC KEY":
+		key, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("key %q: unsupported PEM block %q", id, block.Type)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("key %q: %w", id, err)
+	}
+
+	return NewKey(id, key)
+}
+
+// LoadKeys loads all "*.pem" files of a directory. The file name without the extension is used as the key ID.
+// Keys are returned sorted by ID.
+func LoadKeys(dir string) ([]*Key, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(paths)
+
+	keys := make([]*Key, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		key, err := ParsePEMKey(strings.TrimSuffix(filepath.Base(path), ".pem"), data)
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// CanSign reports whether the key has a private part.
+func (k *Key) CanSign() bool {
+	return k.signKey != nil
+}
+
+// PublicKey returns the public key of an asymmetric key, or nil for an HMAC key.
+func (k *Key) PublicKey() crypto.PublicKey {
+	if k.Method == jwt.SigningMethodHS256 {
+		return nil
+	}
+	return k.verifyKey
+}
+
+// KeySet holds the key used to sign new tokens and all keys accepted when verifying tokens.
+type KeySet struct {
+	signing *Key
+	keys    map[string]*Key
+}
+
+// NewKeySet creates a key set that signs tokens with the signing key and verifies tokens with any of the keys.
+// The signing key is always accepted for verification.
+func NewKeySet(signing *Key, verification ...*Key) (*KeySet, error) {
+	if signing == nil || !signing.CanSign() {
+		return nil, ErrNoSigningKey
+	}
+
+	keys := map[string]*Key{signing.ID: signing}
+	for _, key := range verification {
+		keys[key.ID] = key
+	}
+
+	return &KeySet{signing: signing, keys: keys}, nil
+}
+
+// SigningKey returns the key used to sign new tokens.
+func (s *KeySet) SigningKey() *Key {
+	return s.signing
+}
+
+// Sign signs the claims with the signing key and sets the "kid" header, unless the key ID is empty.
+func (s *KeySet) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(s.signing.Method, claims)
+	if s.signing.ID != "" {
+		token.Header["kid"] = s.signing.ID
+	}
+	return token.SignedString(s.signing.signKey)
+}
+
+// Parse verifies a token and decodes its claims.
+// The verification key is selected by the "kid" header; tokens without it are verified with the key with an empty ID.
+// Tokens whose "alg" header does not match the algorithm of the selected key are rejected.
+func (s *KeySet) Parse(tokenString string, claims jwt.Claims) (*jwt.Token, error) {
+	return jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+
+		key, ok := s.keys[kid]
+		if !ok {
+			return nil, ErrUnknownKey
+		}
+
+		if token.Method.Alg() != key.Method.Alg() {
+			return nil, ErrUnexpectedAlg
+		}
+
+		return key.verifyKey, nil
+	})
+}
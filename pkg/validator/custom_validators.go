@@ -3,6 +3,7 @@ package validator
 import (
 	"github.com/go-playground/validator/v10"
 	"regexp"
+	"strings"
 	"unicode"
 )
 
@@ -19,6 +20,7 @@ var validationMessages = map[string]string{
 	"gte":      "must be greater than or equal to ",
 	"min":      "must be at least ",
 	"max":      "must be at most ",
+	"oneof":    "must be one of: ",
 }
 
 // getValidationMessage returns a human-readable error message for a given validation error.
@@ -33,6 +35,8 @@ func getValidationMessage(fe validator.FieldError) string {
 		return message + fe.Param()
 	case "min", "max":
 		return message + fe.Param() + " characters long"
+	case "oneof":
+		return message + strings.ReplaceAll(fe.Param(), " ", ", ")
 	default:
 		return message
 	}
@@ -0,0 +1,245 @@
+// Package main runs a mock OpenID Connect provider for the Postman tests of OpenID Connect login.
+//
+// The provider logs every user in without asking: the authorization endpoint redirects back to the client with a code
+// at once, and the token endpoint exchanges the code for an ID token signed with a key generated at startup.
+// The claims of the ID token can be set with additional parameters of the authorization URL, so that tests can
+// simulate other users and misbehaving providers:
+//   - mock_sub, mock_username and mock_email set the subject, preferred username and verified email of the user.
+//   - mock_aud replaces the audience, which is the client ID by default.
+//   - mock_nonce replaces the nonce, which is the nonce of the authorization request by default.
+//
+// It must never be used outside of tests.
+package main
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"github.com/golang-jwt/jwt"
+	"github.com/k4sper1love/watchlist-api/pkg/tokens"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// authorization is a login waiting for its code to be exchanged.
+type authorization struct {
+	clientID      string
+	redirectURI   string
+	codeChallenge string
+	claims        jwt.MapClaims
+}
+
+// provider is the state of the mock provider.
+type provider struct {
+	issuer    string // Issuer URL used by the client for discovery, keys and tokens.
+	publicURL string // URL of the authorization endpoint as seen by the browser or Postman.
+	clientID  string // The only client registered at the provider.
+	keys      *tokens.KeySet
+
+	mu    sync.Mutex
+	codes map[string]*authorization
+}
+
+func main() {
+	addr := flag.String("addr", ":9000", "Address to listen on")
+	issuer := flag.String("issuer", "http://localhost:9000", "Issuer URL, as reached by the API")
+	publicURL := flag.String("public-url", "", "URL of the provider as reached by the browser; defaults to the issuer URL")
+	clientID := flag.String("client-id", "watchlist", "Client ID of the API")
+	flag.Parse()
+
+	key, err := tokens.GenerateKey("mock", jwt.SigningMethodRS256.Alg())
+	if err != nil {
+		slog.Error("failed to generate the signing key", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	keys, err := tokens.NewKeySet(key)
+	if err != nil {
+		slog.Error("failed to create the key set", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	p := &provider{
+		issuer:    strings.TrimSuffix(*issuer, "/"),
+		publicURL: strings.TrimSuffix(*publicURL, "/"),
+		clientID:  *clientID,
+		keys:      keys,
+		codes:     make(map[string]*authorization),
+	}
+	if p.publicURL == "" {
+		p.publicURL = p.issuer
+	}
+
+	slog.Info("mock OpenID Connect provider started", slog.String("addr", *addr), slog.String("issuer", p.issuer))
+
+	if err := http.ListenAndServe(*addr, p.routes()); err != nil {
+		slog.Error("mock OpenID Connect provider stopped", slog.Any("error", err))
+		os.Exit(1)
+	}
+}
+
+// routes returns the handler of the provider endpoints.
+func (p *provider) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", p.discoveryHandler)
+	mux.HandleFunc("GET /jwks", p.jwksHandler)
+	mux.HandleFunc("GET /authorize", p.authorizeHandler)
+	mux.HandleFunc("POST /token", p.tokenHandler)
+	return mux
+}
+
+// discoveryHandler returns the discovery document.
+func (p *provider) discoveryHandler(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"issuer":                                p.issuer,
+		"authorization_endpoint":                p.publicURL + "/authorize",
+		"token_endpoint":                        p.issuer + "/token",
+		"jwks_uri":                              p.issuer + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{jwt.SigningMethodRS256.Alg()},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+// jwksHandler returns the public key that signs ID tokens.
+func (p *provider) jwksHandler(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, p.keys.JWKS())
+}
+
+// authorizeHandler logs the user in at once and redirects back to the client with a code and the state.
+func (p *provider) authorizeHandler(w http.ResponseWriter, r *http.Request) {
+	qs := r.URL.Query()
+
+	if qs.Get("response_type") != "code" || qs.Get("client_id") != p.clientID || qs.Get("redirect_uri") == "" {
+		writeError(w, http.StatusBadRequest, "invalid_request")
+		return
+	}
+
+	if qs.Get("code_challenge") == "" || qs.Get("code_challenge_method") != "S256" {
+		writeError(w, http.StatusBadRequest, "invalid_request")
+		return
+	}
+
+	claims := jwt.MapClaims{
+		"sub":   valueOr(qs.Get("mock_sub"), randomString()),
+		"aud":   valueOr(qs.Get("mock_aud"), p.clientID),
+		"nonce": valueOr(qs.Get("mock_nonce"), qs.Get("nonce")),
+	}
+
+	if username := qs.Get("mock_username"); username != "" {
+		claims["preferred_username"] = username
+	}
+
+	if email := qs.Get("mock_email"); email != "" {
+		claims["email"] = email
+		claims["email_verified"] = true
+	}
+
+	code := randomString()
+
+	p.mu.Lock()
+	p.codes[code] = &authorization{
+		clientID:      p.clientID,
+		redirectURI:   qs.Get("redirect_uri"),
+		codeChallenge: qs.Get("code_challenge"),
+		claims:        claims,
+	}
+	p.mu.Unlock()
+
+	redirect, err := url.Parse(qs.Get("redirect_uri"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request")
+		return
+	}
+
+	params := redirect.Query()
+	params.Set("code", code)
+	params.Set("state", qs.Get("state"))
+	redirect.RawQuery = params.Encode()
+
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+// tokenHandler exchanges a code for an ID token. Codes can be exchanged only once and only with their PKCE verifier.
+func (p *provider) tokenHandler(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil || r.PostForm.Get("grant_type") != "authorization_code" {
+		writeError(w, http.StatusBadRequest, "invalid_request")
+		return
+	}
+
+	code := r.PostForm.Get("code")
+
+	p.mu.Lock()
+	auth, ok := p.codes[code]
+	delete(p.codes, code)
+	p.mu.Unlock()
+
+	if !ok || auth.redirectURI != r.PostForm.Get("redirect_uri") || auth.clientID != r.PostForm.Get("client_id") {
+		writeError(w, http.StatusBadRequest, "invalid_grant")
+		return
+	}
+
+	sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if base64.RawURLEncoding.EncodeToString(sum[:]) != auth.codeChallenge {
+		writeError(w, http.StatusBadRequest, "invalid_grant")
+		return
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{"iss": p.issuer, "iat": now.Unix(), "exp": now.Add(5 * time.Minute).Unix()}
+	for name, value := range auth.claims {
+		claims[name] = value
+	}
+
+	idToken, err := p.keys.Sign(claims)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "server_error")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token": randomString(),
+		"token_type":   "Bearer",
+		"id_token":     idToken,
+		"expires_in":   300,
+	})
+}
+
+// writeJSON writes the data as a JSON response.
+func writeJSON(w http.ResponseWriter, status int, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		slog.Error("failed to write the response", slog.Any("error", err))
+	}
+}
+
+// writeError writes an OAuth 2.0 error response.
+func writeError(w http.ResponseWriter, status int, code string) {
+	writeJSON(w, status, map[string]string{"error": code})
+}
+
+// valueOr returns the value, or the fallback if the value is empty.
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+// randomString returns a random URL-safe string for codes and subjects.
+func randomString() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}